@@ -0,0 +1,182 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/signature/cose"
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+	"github.com/notaryproject/notation/v2/internal/envelope"
+	"github.com/notaryproject/tspclient-go"
+)
+
+// signatureBundleSuffix is the file name suffix of a signature bundle.
+const signatureBundleSuffix = ".bundle.json"
+
+// signatureBundle is a self-contained blob signature. The certificate chain
+// and the timestamp countersignature are carried by the signature envelope,
+// and the bundle optionally captures the CRLs of the certificates so that
+// the signature can be verified offline.
+type signatureBundle struct {
+	MediaType  string                     `json:"mediaType"`
+	Signature  signatureBundleEnvelope    `json:"signature"`
+	Revocation *signatureBundleRevocation `json:"revocation,omitempty"`
+}
+
+// signatureBundleEnvelope is the signature envelope in a signature bundle.
+type signatureBundleEnvelope struct {
+	MediaType string `json:"mediaType"`
+	Envelope  []byte `json:"envelope"`
+}
+
+// signatureBundleRevocation is the revocation data captured in a signature
+// bundle.
+type signatureBundleRevocation struct {
+	CRLs []signatureBundleCRL `json:"crls,omitempty"`
+}
+
+// signatureBundleCRL is a DER encoded CRL fetched from URL.
+type signatureBundleCRL struct {
+	URL string `json:"url"`
+	CRL []byte `json:"crl"`
+}
+
+// bundleFilepath returns the path to the signature bundle file.
+func bundleFilepath(signatureDirectory, blobPath, signatureFormat string) string {
+	bundleFilename := fmt.Sprintf("%s.%s%s", filepath.Base(blobPath), signatureFormat, signatureBundleSuffix)
+	return filepath.Join(signatureDirectory, bundleFilename)
+}
+
+// newSignatureBundle wraps the signature envelope sig of media type
+// signatureMediaType into a signature bundle, capturing the CRLs of the
+// signing and timestamping certificate chains with fetcher. Failing to fetch
+// a CRL is not fatal and only prints a warning.
+func newSignatureBundle(ctx context.Context, sig []byte, signatureMediaType string, fetcher corecrl.Fetcher) (*signatureBundle, error) {
+	sigEnv, err := signature.ParseEnvelope(signatureMediaType, sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature: %w", err)
+	}
+	envelopeContent, err := sigEnv.Content()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature: %w", err)
+	}
+	certs := envelopeContent.SignerInfo.CertificateChain
+	if timestamp := envelopeContent.SignerInfo.UnsignedAttributes.TimestampSignature; timestamp != nil {
+		signedToken, err := tspclient.ParseSignedToken(timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse timestamp countersignature: %w", err)
+		}
+		certs = append(certs, signedToken.Certificates...)
+	}
+
+	bundle := &signatureBundle{
+		MediaType: envelope.MediaTypeSignatureBundle,
+		Signature: signatureBundleEnvelope{
+			MediaType: signatureMediaType,
+			Envelope:  sig,
+		},
+	}
+	if crls := fetchCRLs(ctx, certs, fetcher); len(crls) > 0 {
+		bundle.Revocation = &signatureBundleRevocation{
+			CRLs: crls,
+		}
+	}
+	return bundle, nil
+}
+
+// fetchCRLs fetches the CRLs from the CRL distribution points of certs.
+func fetchCRLs(ctx context.Context, certs []*x509.Certificate, fetcher corecrl.Fetcher) []signatureBundleCRL {
+	logger := log.GetLogger(ctx)
+
+	var crls []signatureBundleCRL
+	fetched := make(map[string]bool)
+	for _, cert := range certs {
+		for _, url := range cert.CRLDistributionPoints {
+			if fetched[url] {
+				continue
+			}
+			fetched[url] = true
+			logger.Infof("Fetching CRL from %s", url)
+			crlBundle, err := fetcher.Fetch(ctx, url)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to fetch CRL from %s, the CRL is not bundled: %v\n", url, err)
+				continue
+			}
+			crls = append(crls, signatureBundleCRL{
+				URL: url,
+				CRL: crlBundle.BaseCRL.Raw,
+			})
+		}
+	}
+	return crls
+}
+
+// isSignatureBundle returns true if content is a signature bundle.
+func isSignatureBundle(content []byte) bool {
+	var header struct {
+		MediaType string `json:"mediaType"`
+	}
+	return json.Unmarshal(content, &header) == nil && header.MediaType == envelope.MediaTypeSignatureBundle
+}
+
+// parseSignatureBundle parses and validates the content of a signature
+// bundle.
+func parseSignatureBundle(content []byte) (*signatureBundle, error) {
+	var bundle signatureBundle
+	if err := json.Unmarshal(content, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse signature bundle: %w", err)
+	}
+	if bundle.MediaType != envelope.MediaTypeSignatureBundle {
+		return nil, fmt.Errorf("unsupported signature bundle media type %q", bundle.MediaType)
+	}
+	switch bundle.Signature.MediaType {
+	case jws.MediaTypeEnvelope, cose.MediaTypeEnvelope:
+	default:
+		return nil, fmt.Errorf("unsupported signature media type %q in signature bundle", bundle.Signature.MediaType)
+	}
+	if len(bundle.Signature.Envelope) == 0 {
+		return nil, errors.New("signature bundle does not contain a signature envelope")
+	}
+	return &bundle, nil
+}
+
+// CRLs returns the CRLs captured in the bundle.
+func (b *signatureBundle) CRLs() ([]metadata.BundledCRL, error) {
+	if b.Revocation == nil {
+		return nil, nil
+	}
+	crls := make([]metadata.BundledCRL, 0, len(b.Revocation.CRLs))
+	for _, bundledCRL := range b.Revocation.CRLs {
+		crl, err := x509.ParseRevocationList(bundledCRL.CRL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the bundled CRL of %s: %w", bundledCRL.URL, err)
+		}
+		crls = append(crls, metadata.BundledCRL{
+			URL: bundledCRL.URL,
+			CRL: crl,
+		})
+	}
+	return crls, nil
+}
@@ -0,0 +1,175 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
+	"github.com/notaryproject/notation/v2/internal/envelope"
+)
+
+func TestBundleFilepath(t *testing.T) {
+	expected := filepath.Join("sigs", "blob.txt.jws.bundle.json")
+	if got := bundleFilepath("sigs", filepath.Join("dir", "blob.txt"), "jws"); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestParseSignatureBundle(t *testing.T) {
+	crl := newTestCRL(t)
+	bundle := &signatureBundle{
+		MediaType: envelope.MediaTypeSignatureBundle,
+		Signature: signatureBundleEnvelope{
+			MediaType: "application/jose+json",
+			Envelope:  []byte("envelope"),
+		},
+		Revocation: &signatureBundleRevocation{
+			CRLs: []signatureBundleCRL{
+				{URL: "http://example.com/crl", CRL: crl.Raw},
+			},
+		},
+	}
+	content, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isSignatureBundle(content) {
+		t.Fatal("expected content to be a signature bundle")
+	}
+	parsed, err := parseSignatureBundle(content)
+	if err != nil {
+		t.Fatalf("parseSignatureBundle() error = %v", err)
+	}
+	if string(parsed.Signature.Envelope) != "envelope" {
+		t.Fatalf("expected envelope %q, got %q", "envelope", parsed.Signature.Envelope)
+	}
+	crls, err := parsed.CRLs()
+	if err != nil {
+		t.Fatalf("CRLs() error = %v", err)
+	}
+	if len(crls) != 1 || crls[0].URL != "http://example.com/crl" || crls[0].CRL.Number.Cmp(crl.Number) != 0 {
+		t.Fatalf("unexpected CRLs %+v", crls)
+	}
+
+	t.Run("not a bundle", func(t *testing.T) {
+		if isSignatureBundle([]byte(`{"payload":"eyJ9","protected":"eyJ9","signature":"c2ln"}`)) {
+			t.Fatal("expected content not to be a signature bundle")
+		}
+	})
+
+	for name, content := range map[string]string{
+		"invalid json":             `{`,
+		"invalid media type":       `{"mediaType":"application/json","signature":{"mediaType":"application/jose+json","envelope":"ZW52"}}`,
+		"invalid signature format": `{"mediaType":"application/vnd.cncf.notary.signature-bundle.v1+json","signature":{"mediaType":"application/json","envelope":"ZW52"}}`,
+		"missing envelope":         `{"mediaType":"application/vnd.cncf.notary.signature-bundle.v1+json","signature":{"mediaType":"application/cose"}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseSignatureBundle([]byte(content)); err == nil {
+				t.Fatal("expected error, but got nil")
+			}
+		})
+	}
+
+	t.Run("invalid CRL", func(t *testing.T) {
+		bundle := &signatureBundle{
+			Revocation: &signatureBundleRevocation{
+				CRLs: []signatureBundleCRL{
+					{URL: "http://example.com/crl", CRL: []byte("invalid")},
+				},
+			},
+		}
+		if _, err := bundle.CRLs(); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
+
+func TestFetchCRLs(t *testing.T) {
+	crl := newTestCRL(t)
+	certs := []*x509.Certificate{
+		{CRLDistributionPoints: []string{"http://example.com/crl", "http://unavailable.example.com/crl"}},
+		{CRLDistributionPoints: []string{"http://example.com/crl"}},
+		{},
+	}
+	fetcher := &testCRLFetcher{
+		crls: map[string]*x509.RevocationList{
+			"http://example.com/crl": crl,
+		},
+	}
+	crls := fetchCRLs(context.Background(), certs, fetcher)
+	if len(crls) != 1 || crls[0].URL != "http://example.com/crl" || string(crls[0].CRL) != string(crl.Raw) {
+		t.Fatalf("unexpected CRLs %+v", crls)
+	}
+	if fetcher.fetchCount != 2 {
+		t.Fatalf("expected 2 fetches, got %d", fetcher.fetchCount)
+	}
+}
+
+type testCRLFetcher struct {
+	crls       map[string]*x509.RevocationList
+	fetchCount int
+}
+
+func (f *testCRLFetcher) Fetch(ctx context.Context, url string) (*corecrl.Bundle, error) {
+	f.fetchCount++
+	crl, ok := f.crls[url]
+	if !ok {
+		return nil, errors.New("failed to fetch CRL")
+	}
+	return &corecrl.Bundle{BaseCRL: crl}, nil
+}
+
+func newTestCRL(t *testing.T) *x509.RevocationList {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	issuer := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	crlBytes, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(7),
+		ThisUpdate: now,
+		NextUpdate: now.Add(time.Hour),
+	}, issuer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crl, err := x509.ParseRevocationList(crlBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crl
+}
@@ -0,0 +1,87 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// checksumEntry is an entry of a checksum file.
+type checksumEntry struct {
+	// Path is the path of the file as written in the checksum file.
+	Path string
+
+	// Digest is the digest of the file.
+	Digest digest.Digest
+}
+
+// checksumAlgorithms maps the length of a hex encoded checksum to its digest
+// algorithm.
+var checksumAlgorithms = map[int]digest.Algorithm{
+	digest.SHA256.Size() * 2: digest.SHA256,
+	digest.SHA384.Size() * 2: digest.SHA384,
+	digest.SHA512.Size() * 2: digest.SHA512,
+}
+
+// parseChecksums parses a checksum file in the format produced by sha256sum,
+// sha384sum and sha512sum, where each line is "{hex checksum}  {file name}"
+// in text mode or "{hex checksum} *{file name}" in binary mode. The digest
+// algorithm is determined by the length of the checksum. Empty lines are
+// skipped.
+func parseChecksums(r io.Reader) ([]checksumEntry, error) {
+	var entries []checksumEntry
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		checksum, name, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid checksum entry at line %d: expecting \"{checksum}  {file name}\"", lineNumber)
+		}
+		// the second separator marks the text mode (' ') or the binary mode ('*')
+		if strings.HasPrefix(name, " ") || strings.HasPrefix(name, "*") {
+			name = name[1:]
+		}
+		if name == "" {
+			return nil, fmt.Errorf("invalid checksum entry at line %d: file name is empty", lineNumber)
+		}
+		algorithm, ok := checksumAlgorithms[len(checksum)]
+		if !ok {
+			return nil, fmt.Errorf("invalid checksum entry at line %d: unsupported checksum length %d", lineNumber, len(checksum))
+		}
+		d := digest.NewDigestFromEncoded(algorithm, strings.ToLower(checksum))
+		if err := d.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid checksum entry at line %d: %w", lineNumber, err)
+		}
+		entries = append(entries, checksumEntry{
+			Path:   name,
+			Digest: d,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("no checksum entry found")
+	}
+	return entries, nil
+}
@@ -0,0 +1,55 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestParseChecksums(t *testing.T) {
+	sha256Digest := digest.FromString("a")
+	sha512Digest := digest.SHA512.FromString("b")
+	content := sha256Digest.Encoded() + "  a.txt\n" +
+		"\n" +
+		strings.ToUpper(sha512Digest.Encoded()) + " *dir/b.bin\r\n"
+	entries, err := parseChecksums(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("parseChecksums() error = %v", err)
+	}
+	expected := []checksumEntry{
+		{Path: "a.txt", Digest: sha256Digest},
+		{Path: "dir/b.bin", Digest: sha512Digest},
+	}
+	if !reflect.DeepEqual(expected, entries) {
+		t.Fatalf("expected entries %+v, got %+v", expected, entries)
+	}
+
+	for name, content := range map[string]string{
+		"empty file":         "\n",
+		"missing file name":  sha256Digest.Encoded() + "  \n",
+		"missing separator":  sha256Digest.Encoded() + "\n",
+		"unsupported length": "abcdef  a.txt\n",
+		"invalid hex":        strings.Repeat("z", 64) + "  a.txt\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseChecksums(strings.NewReader(content)); err == nil {
+				t.Fatal("expected error, but got nil")
+			}
+		})
+	}
+}
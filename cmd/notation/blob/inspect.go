@@ -16,12 +16,13 @@ package blob
 import (
 	"errors"
 	"fmt"
-	"os"
 
 	"github.com/notaryproject/notation-core-go/signature"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	envelopeutil "github.com/notaryproject/notation/v2/internal/envelope"
 	"github.com/spf13/cobra"
 )
 
@@ -29,6 +30,7 @@ type inspectOpts struct {
 	outputFormat flag.OutputFormatFlagOpts
 	printer      *output.Printer
 	sigPath      string
+	blobPath     string
 }
 
 func inspectCommand() *cobra.Command {
@@ -43,6 +45,9 @@ Example - Inspect a signature:
 
 Example - Inspect a signature and output as JSON:
   notation blob inspect -o json blob.cose.sig
+
+Example - Inspect a signature and check whether a blob matches the digest and the size of the signed artifact:
+  notation blob inspect --blob blob blob.cose.sig
 `,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
@@ -51,8 +56,12 @@ Example - Inspect a signature and output as JSON:
 			opts.sigPath = args[0]
 			return nil
 		},
-		PreRun: func(cmd *cobra.Command, args []string) {
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("blob") && opts.blobPath == "" {
+				return errors.New("blob path cannot be empty")
+			}
 			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runInspect(opts)
@@ -60,6 +69,7 @@ Example - Inspect a signature and output as JSON:
 	}
 
 	opts.outputFormat.ApplyFlags(command.Flags(), output.FormatTree, output.FormatJSON)
+	command.Flags().StringVar(&opts.blobPath, "blob", "", "path to a blob to check against the digest and the size of the signed artifact. The signature is not verified")
 	return command
 }
 
@@ -71,21 +81,51 @@ func runInspect(opts *inspectOpts) error {
 	}
 
 	// parse signature file
-	signatureMediaType, err := parseSignatureMediaType(opts.sigPath)
+	sigFile, err := readSignatureFile(opts.sigPath)
 	if err != nil {
 		return err
 	}
-	envelopeBytes, err := os.ReadFile(opts.sigPath)
-	if err != nil {
-		return fmt.Errorf("failed to read signature file: %w", err)
-	}
-	envelope, err := signature.ParseEnvelope(signatureMediaType, envelopeBytes)
+	envelope, err := signature.ParseEnvelope(sigFile.mediaType, sigFile.envelope)
 	if err != nil {
 		return fmt.Errorf("failed to parse signature: %w", err)
 	}
-	if err := displayHandler.OnEnvelopeParsed(opts.sigPath, signatureMediaType, envelope); err != nil {
+	if err := displayHandler.OnEnvelopeParsed(opts.sigPath, sigFile.mediaType, envelope); err != nil {
 		return err
 	}
+	if len(sigFile.bundledCRLs) > 0 {
+		displayHandler.OnBundledCRLsParsed(sigFile.bundledCRLs)
+	}
+	if opts.blobPath != "" {
+		result, err := checkBlob(opts.blobPath, envelope)
+		if err != nil {
+			return err
+		}
+		displayHandler.OnBlobChecked(result)
+	}
 
 	return displayHandler.Render()
 }
+
+// checkBlob checks the blob at blobPath against the digest and the size of
+// the artifact signed by sigEnv, without verifying the signature.
+func checkBlob(blobPath string, sigEnv signature.Envelope) (metadata.BlobCheckResult, error) {
+	envelopeContent, err := sigEnv.Content()
+	if err != nil {
+		return metadata.BlobCheckResult{}, fmt.Errorf("failed to parse signature: %w", err)
+	}
+	signedArtifactDesc, err := envelopeutil.DescriptorFromSignaturePayload(&envelopeContent.Payload)
+	if err != nil {
+		return metadata.BlobCheckResult{}, fmt.Errorf("failed to parse signature: %w", err)
+	}
+	blobDigest, blobSize, err := digestFile(blobPath, signedArtifactDesc.Digest.Algorithm())
+	if err != nil {
+		return metadata.BlobCheckResult{}, fmt.Errorf("failed to check blob %s: %w", blobPath, err)
+	}
+	return metadata.BlobCheckResult{
+		Path:          blobPath,
+		Digest:        blobDigest,
+		Size:          blobSize,
+		DigestMatched: blobDigest == signedArtifactDesc.Digest,
+		SizeMatched:   blobSize == signedArtifactDesc.Size,
+	}, nil
+}
@@ -0,0 +1,67 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/opencontainers/go-digest"
+)
+
+func TestCheckBlob(t *testing.T) {
+	sig, _ := newTestBlobSignature(t)
+	sigEnv, err := signature.ParseEnvelope(jws.MediaTypeEnvelope, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+
+	t.Run("match", func(t *testing.T) {
+		blobPath := filepath.Join(dir, "blob")
+		if err := os.WriteFile(blobPath, []byte("test blob"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		result, err := checkBlob(blobPath, sigEnv)
+		if err != nil {
+			t.Fatalf("checkBlob() error = %v", err)
+		}
+		if !result.Matched() || result.Digest != digest.FromString("test blob") || result.Size != 9 {
+			t.Fatalf("unexpected result %+v", result)
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		blobPath := filepath.Join(dir, "other")
+		if err := os.WriteFile(blobPath, []byte("test blub"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		result, err := checkBlob(blobPath, sigEnv)
+		if err != nil {
+			t.Fatalf("checkBlob() error = %v", err)
+		}
+		if result.Matched() || result.DigestMatched || !result.SizeMatched {
+			t.Fatalf("unexpected result %+v", result)
+		}
+	})
+
+	t.Run("missing blob", func(t *testing.T) {
+		if _, err := checkBlob(filepath.Join(dir, "missing"), sigEnv); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
@@ -0,0 +1,232 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	// mediaTypeBlobManifest is the media type of the manifest produced by
+	// `notation blob sign --recursive`.
+	mediaTypeBlobManifest = "application/vnd.cncf.notary.blob-manifest.v1+json"
+
+	// blobManifestVersion is the version of the blob manifest format.
+	blobManifestVersion = "1.0"
+
+	// blobManifestSuffix is the file name suffix of a blob manifest.
+	blobManifestSuffix = ".manifest.json"
+)
+
+// blobManifest describes the files under a directory signed by
+// `notation blob sign --recursive`.
+type blobManifest struct {
+	Version string              `json:"version"`
+	Files   []blobManifestEntry `json:"files"`
+}
+
+// blobManifestEntry describes a single file in the blob manifest. Path is
+// relative to the signed directory and always uses forward slashes.
+type blobManifestEntry struct {
+	Path      string        `json:"path"`
+	Size      int64         `json:"size"`
+	Digest    digest.Digest `json:"digest"`
+	MediaType string        `json:"mediaType"`
+}
+
+// blobManifestDiff is the difference between a blob manifest and the
+// content of a directory.
+type blobManifestDiff struct {
+	Missing  []string
+	Extra    []string
+	Modified []string
+}
+
+// IsEmpty returns true if the directory matches the manifest.
+func (d *blobManifestDiff) IsEmpty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Modified) == 0
+}
+
+// String returns one line per difference.
+func (d *blobManifestDiff) String() string {
+	var sb strings.Builder
+	for _, p := range d.Missing {
+		fmt.Fprintf(&sb, "  missing:  %s\n", p)
+	}
+	for _, p := range d.Extra {
+		fmt.Fprintf(&sb, "  extra:    %s\n", p)
+	}
+	for _, p := range d.Modified {
+		fmt.Fprintf(&sb, "  modified: %s\n", p)
+	}
+	return sb.String()
+}
+
+// blobManifestPath returns the path of the manifest file for the directory
+// root when it is written to manifestDirectory.
+func blobManifestPath(manifestDirectory, root string) string {
+	return filepath.Join(manifestDirectory, filepath.Base(filepath.Clean(root))+blobManifestSuffix)
+}
+
+// generateBlobManifest walks root and returns the manifest of all regular
+// files in it, sorted by path. Files listed in exclude are skipped.
+func generateBlobManifest(root, mediaType string, exclude ...string) (*blobManifest, error) {
+	files, err := walkBlobDirectory(root, exclude...)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &blobManifest{
+		Version: blobManifestVersion,
+		Files:   []blobManifestEntry{},
+	}
+	for _, relPath := range files {
+		d, size, err := digestFile(filepath.Join(root, filepath.FromSlash(relPath)), digest.SHA256)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, blobManifestEntry{
+			Path:      relPath,
+			Size:      size,
+			Digest:    d,
+			MediaType: mediaType,
+		})
+	}
+	return manifest, nil
+}
+
+// parseBlobManifest parses and validates the content of a blob manifest.
+func parseBlobManifest(content []byte) (*blobManifest, error) {
+	var manifest blobManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse blob manifest: %w", err)
+	}
+	if manifest.Version != blobManifestVersion {
+		return nil, fmt.Errorf("unsupported blob manifest version %q", manifest.Version)
+	}
+	for _, entry := range manifest.Files {
+		if entry.Path == "" || !fs.ValidPath(entry.Path) {
+			return nil, fmt.Errorf("invalid path %q in blob manifest", entry.Path)
+		}
+		if err := entry.Digest.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid digest for %q in blob manifest: %w", entry.Path, err)
+		}
+	}
+	return &manifest, nil
+}
+
+// Compare checks every file under root against the manifest and returns the
+// missing, extra and modified files. Files listed in exclude are skipped.
+func (m *blobManifest) Compare(root string, exclude ...string) (*blobManifestDiff, error) {
+	files, err := walkBlobDirectory(root, exclude...)
+	if err != nil {
+		return nil, err
+	}
+	present := make(map[string]bool, len(files))
+	for _, relPath := range files {
+		present[relPath] = true
+	}
+	diff := &blobManifestDiff{}
+	listed := make(map[string]bool, len(m.Files))
+	for _, entry := range m.Files {
+		listed[entry.Path] = true
+		if !present[entry.Path] {
+			diff.Missing = append(diff.Missing, entry.Path)
+			continue
+		}
+		d, size, err := digestFile(filepath.Join(root, filepath.FromSlash(entry.Path)), entry.Digest.Algorithm())
+		if err != nil {
+			return nil, err
+		}
+		if d != entry.Digest || size != entry.Size {
+			diff.Modified = append(diff.Modified, entry.Path)
+		}
+	}
+	for _, relPath := range files {
+		if !listed[relPath] {
+			diff.Extra = append(diff.Extra, relPath)
+		}
+	}
+	return diff, nil
+}
+
+// walkBlobDirectory returns the sorted slash-separated paths of all regular
+// files under root, relative to root.
+func walkBlobDirectory(root string, exclude ...string) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+	excluded := make(map[string]bool, len(exclude))
+	for _, p := range exclude {
+		if absPath, err := filepath.Abs(p); err == nil {
+			excluded[absPath] = true
+		}
+	}
+	var files []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if absPath, err := filepath.Abs(path); err == nil && excluded[absPath] {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", path)
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relPath))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// digestFile returns the digest and the size of the file at path.
+func digestFile(path string, algorithm digest.Algorithm) (digest.Digest, int64, error) {
+	if !algorithm.Available() {
+		return "", 0, fmt.Errorf("unsupported digest algorithm %q", algorithm)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+	digester := algorithm.Digester()
+	size, err := io.Copy(digester.Hash(), file)
+	if err != nil {
+		return "", 0, err
+	}
+	return digester.Digest(), size, nil
+}
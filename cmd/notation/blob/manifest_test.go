@@ -0,0 +1,152 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGenerateBlobManifest(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"b.txt":        "b",
+		"a/nested.bin": "nested",
+		"skip.sig":     "signature",
+	})
+
+	manifest, err := generateBlobManifest(root, "application/octet-stream", filepath.Join(root, "skip.sig"))
+	if err != nil {
+		t.Fatalf("generateBlobManifest() error = %v", err)
+	}
+	expected := &blobManifest{
+		Version: blobManifestVersion,
+		Files: []blobManifestEntry{
+			{
+				Path:      "a/nested.bin",
+				Size:      6,
+				Digest:    digest.FromString("nested"),
+				MediaType: "application/octet-stream",
+			},
+			{
+				Path:      "b.txt",
+				Size:      1,
+				Digest:    digest.FromString("b"),
+				MediaType: "application/octet-stream",
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, manifest) {
+		t.Fatalf("expected manifest %+v, got %+v", expected, manifest)
+	}
+
+	t.Run("not a directory", func(t *testing.T) {
+		if _, err := generateBlobManifest(filepath.Join(root, "b.txt"), "application/octet-stream"); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
+
+func TestBlobManifestCompare(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"keep.txt":     "keep",
+		"modify.txt":   "original",
+		"remove.txt":   "remove",
+		"dir/same.txt": "same",
+	})
+	manifest, err := generateBlobManifest(root, "application/octet-stream")
+	if err != nil {
+		t.Fatalf("generateBlobManifest() error = %v", err)
+	}
+
+	diff, err := manifest.Compare(root)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if !diff.IsEmpty() {
+		t.Fatalf("expected no difference, got %+v", diff)
+	}
+
+	writeTestFiles(t, root, map[string]string{
+		"modify.txt":    "modified",
+		"dir/extra.txt": "extra",
+	})
+	if err := os.Remove(filepath.Join(root, "remove.txt")); err != nil {
+		t.Fatal(err)
+	}
+	diff, err = manifest.Compare(root)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	expected := &blobManifestDiff{
+		Missing:  []string{"remove.txt"},
+		Extra:    []string{"dir/extra.txt"},
+		Modified: []string{"modify.txt"},
+	}
+	if !reflect.DeepEqual(expected, diff) {
+		t.Fatalf("expected diff %+v, got %+v", expected, diff)
+	}
+}
+
+func TestParseBlobManifest(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{"a.txt": "a"})
+	manifest, err := generateBlobManifest(root, "application/octet-stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := parseBlobManifest(content)
+	if err != nil {
+		t.Fatalf("parseBlobManifest() error = %v", err)
+	}
+	if !reflect.DeepEqual(manifest, parsed) {
+		t.Fatalf("expected manifest %+v, got %+v", manifest, parsed)
+	}
+
+	for name, content := range map[string]string{
+		"invalid json":    `{`,
+		"invalid version": `{"version":"2.0","files":[]}`,
+		"escaping path":   `{"version":"1.0","files":[{"path":"../a.txt","size":1,"digest":"sha256:ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"}]}`,
+		"invalid digest":  `{"version":"1.0","files":[{"path":"a.txt","size":1,"digest":"sha256:abc"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseBlobManifest([]byte(content)); err == nil {
+				t.Fatal("expected error, but got nil")
+			}
+		})
+	}
+}
@@ -11,14 +11,15 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package policy provides the import and show commands for blob trust policy.
+// Package policy provides the commands to manage blob trust policy.
 package policy
 
 import (
 	"github.com/spf13/cobra"
 )
 
-// Cmd returns the commands for policy including import and show.
+// Cmd returns the commands for policy including import, show, init, add,
+// update, remove, lint and test.
 func Cmd() *cobra.Command {
 	command := &cobra.Command{
 		Use:   "policy [command]",
@@ -30,6 +31,11 @@ func Cmd() *cobra.Command {
 		importCmd(),
 		showCmd(),
 		initCmd(),
+		addCmd(),
+		updateCmd(),
+		removeCmd(),
+		lintCmd(),
+		testCmd(),
 	)
 
 	return command
@@ -0,0 +1,70 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/internal/osutil"
+)
+
+// loadBlobDocument loads the blob trust policy configuration for editing and
+// returns it with its path. If the configuration does not exist and
+// allowNotExist is true, an empty document is returned.
+func loadBlobDocument(allowNotExist bool) (*trustpolicy.BlobDocument, string, error) {
+	policyPath, err := dir.ConfigFS().SysPath(dir.PathBlobTrustPolicy)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to obtain path of blob trust policy configuration: %w", err)
+	}
+	if _, err := os.Stat(policyPath); errors.Is(err, fs.ErrNotExist) && allowNotExist {
+		return &trustpolicy.BlobDocument{Version: "1.0"}, policyPath, nil
+	}
+	doc, err := trustpolicy.LoadBlobDocument()
+	if err != nil {
+		return nil, "", err
+	}
+	return doc, policyPath, nil
+}
+
+// saveBlobDocument validates doc and writes it to policyPath atomically.
+func saveBlobDocument(doc *trustpolicy.BlobDocument, policyPath string) error {
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("invalid blob trust policy configuration: %w", err)
+	}
+	policyJSON, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal blob trust policy: %w", err)
+	}
+	if err := osutil.WriteFileAtomic(policyPath, policyJSON); err != nil {
+		return fmt.Errorf("failed to write blob trust policy configuration: %w", err)
+	}
+	return nil
+}
+
+// findBlobTrustPolicy returns the index of the statement named name in doc,
+// or -1 if not found.
+func findBlobTrustPolicy(doc *trustpolicy.BlobDocument, name string) int {
+	for i, statement := range doc.TrustPolicies {
+		if statement.Name == name {
+			return i
+		}
+	}
+	return -1
+}
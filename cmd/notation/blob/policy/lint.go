@@ -0,0 +1,109 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/notaryproject/notation/v2/internal/layered"
+	"github.com/spf13/cobra"
+)
+
+type lintOpts struct {
+	outputFormat    flag.OutputFormatFlagOpts
+	printer         *output.Printer
+	expiryThreshold time.Duration
+}
+
+func lintCmd() *cobra.Command {
+	opts := lintOpts{}
+	command := &cobra.Command{
+		Use:   "lint [flags]",
+		Short: "Check the blob trust policy configuration and the trust stores it references for issues",
+		Long: `Check the blob trust policy configuration and the trust stores it references for issues.
+
+Besides the validation of the configuration, the following issues are reported:
+  - referenced trust stores that do not exist or have no certificates
+  - wildcard "*" trusted identities
+  - "audit" or "skip" verification levels of the global policy
+  - trusted identities that no certificate in the referenced trust stores could ever satisfy
+  - expired, expiring and leaf (non-CA) certificates in the referenced trust stores
+
+The command fails if any issue of severity "error" is found.
+
+Example - Lint the blob trust policy configuration:
+  notation blob policy lint
+
+Example - Lint the blob trust policy configuration and output as JSON:
+  notation blob policy lint -o json
+
+Example - Report the trusted certificates expiring in 90 days:
+  notation blob policy lint --expiry-threshold 2160h
+`,
+		Args: cobra.ExactArgs(0),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.outputFormat.Validate(cmd); err != nil {
+				return err
+			}
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLint(cmd.Context(), &opts)
+		},
+	}
+
+	opts.outputFormat.ApplyFlags(command.Flags(), output.FormatText, output.FormatJSON)
+	command.Flags().DurationVar(&opts.expiryThreshold, "expiry-threshold", policy.DefaultExpiryThreshold, "period before the expiry of a trusted certificate in which it is reported as expiring")
+	return command
+}
+
+func runLint(ctx context.Context, opts *lintOpts) error {
+	// initialize display handler
+	displayHandler, err := display.NewPolicyLintHandler(opts.printer, opts.outputFormat.CurrentFormat)
+	if err != nil {
+		return err
+	}
+
+	// trust policy and trust stores layered from the system level and the
+	// user level configurations
+	doc, warnings, err := layered.LoadBlobDocument()
+	if err != nil {
+		return fmt.Errorf("failed to load blob trust policy configuration: %w", err)
+	}
+	policyPath, err := layered.ConfigPath(dir.PathBlobTrustPolicy)
+	if err != nil {
+		return fmt.Errorf("failed to obtain path of blob trust policy configuration: %w", err)
+	}
+
+	findings := policy.SystemPolicyConflicts(warnings)
+	findings = append(findings, policy.Lint(ctx, layered.NewX509TrustStore(), policy.BlobStatements(doc), doc.Validate(), policy.LintOptions{
+		ExpiryThreshold: opts.expiryThreshold,
+	})...)
+	displayHandler.OnPolicyLinted(policyPath, findings)
+	if err := displayHandler.Render(); err != nil {
+		return err
+	}
+	if errorCount, _ := policy.CountFindings(findings); errorCount > 0 {
+		return fmt.Errorf("found %d error(s) in the blob trust policy configuration", errorCount)
+	}
+	return nil
+}
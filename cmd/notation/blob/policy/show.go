@@ -22,10 +22,16 @@ import (
 
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/internal/layered"
 	"github.com/spf13/cobra"
 )
 
+type showOpts struct {
+	effective bool
+}
+
 func showCmd() *cobra.Command {
+	var opts showOpts
 	command := &cobra.Command{
 		Use:   "show [flags]",
 		Short: "Show blob trust policy configuration",
@@ -36,16 +42,24 @@ Example - Show current blob trust policy configuration:
 
 Example - Save current blob trust policy configuration to a file:
   notation blob policy show > my_policy.json
+
+Example - Show the effective blob trust policy configuration merged from the system level and the user level configurations:
+  notation blob policy show --effective
 `,
 		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runShow()
+			return runShow(opts)
 		},
 	}
+	command.Flags().BoolVar(&opts.effective, "effective", false, "show the effective blob trust policy configuration merged from the system level and the user level configurations")
 	return command
 }
 
-func runShow() error {
+func runShow(opts showOpts) error {
+	if opts.effective {
+		return runShowEffective()
+	}
+
 	policyJSON, err := fs.ReadFile(dir.ConfigFS(), dir.PathBlobTrustPolicy)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
@@ -67,3 +81,22 @@ func runShow() error {
 	_, err = os.Stdout.Write(policyJSON)
 	return err
 }
+
+func runShowEffective() error {
+	doc, warnings, err := layered.LoadBlobDocument()
+	if err != nil {
+		return fmt.Errorf("failed to show effective blob trust policy: %w", err)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("effective blob trust policy configuration is invalid: %w", err)
+	}
+	policyJSON, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(policyJSON))
+	return err
+}
@@ -0,0 +1,100 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/notaryproject/notation/v2/internal/layered"
+	"github.com/spf13/cobra"
+)
+
+type testOpts struct {
+	outputFormat flag.OutputFormatFlagOpts
+	printer      *output.Printer
+	policyName   string
+}
+
+func testCmd() *cobra.Command {
+	opts := testOpts{}
+	command := &cobra.Command{
+		Use:   "test [flags]",
+		Short: "Show the blob trust policy statement applying to blob verification",
+		Long: `Show the blob trust policy statement applying to blob verification.
+
+The statement is selected by the policy name the same way as "notation blob verify" does. The output shows why the statement is selected, the effective action of each validation type, the trusted identities, and the certificates in the trust stores used as trust anchors.
+
+Example - Show the global blob trust policy statement:
+  notation blob policy test
+
+Example - Show the blob trust policy statement named "wabbit-networks-policy" and output as JSON:
+  notation blob policy test --policy-name wabbit-networks-policy -o json
+`,
+		Args: cobra.ExactArgs(0),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.outputFormat.Validate(cmd); err != nil {
+				return err
+			}
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTest(cmd.Context(), &opts)
+		},
+	}
+
+	opts.outputFormat.ApplyFlags(command.Flags(), output.FormatTree, output.FormatJSON)
+	command.Flags().StringVar(&opts.policyName, "policy-name", "", "policy name as used by \"notation blob verify\". If not provided, the global policy is used if exists")
+	return command
+}
+
+func runTest(ctx context.Context, opts *testOpts) error {
+	// initialize display handler
+	displayHandler, err := display.NewPolicyTestHandler(opts.printer, opts.outputFormat.CurrentFormat)
+	if err != nil {
+		return err
+	}
+
+	policyPath, err := dir.ConfigFS().SysPath(dir.PathBlobTrustPolicy)
+	if err != nil {
+		return err
+	}
+	doc, warnings, err := layered.LoadBlobDocument()
+	if err != nil {
+		return fmt.Errorf("failed to load blob trust policy configuration: %w", err)
+	}
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("invalid blob trust policy configuration: %w", err)
+	}
+	for _, warning := range warnings {
+		opts.printer.PrintErrorf("Warning: %s\n", warning)
+	}
+
+	statement, reason, err := policy.SelectBlobStatement(doc, opts.policyName)
+	if err != nil {
+		return err
+	}
+	resolution, err := policy.Resolve(ctx, layered.NewX509TrustStore(), statement, reason)
+	if err != nil {
+		return err
+	}
+	displayHandler.OnStatementResolved(policyPath, resolution)
+	return displayHandler.Render()
+}
@@ -0,0 +1,96 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type updateOpts struct {
+	policy.StatementFlagOpts
+	printer *output.Printer
+	name    string
+	global  bool
+}
+
+func updateCmd() *cobra.Command {
+	opts := updateOpts{}
+	command := &cobra.Command{
+		Use:   "update [flags] <policy_name>",
+		Short: "Update a statement of the blob trust policy configuration",
+		Long: `Update a statement of the blob trust policy configuration. Only the properties of the flags set are updated.
+
+Example - Replace the trust stores and the trusted identities of a blob trust policy statement:
+  notation blob policy update --trust-store ca:exampleStore --trust-store ca:exampleStore2 --trusted-identity "x509.subject: C=US, ST=WA, O=acme-rockets.io" examplePolicy
+
+Example - Change the verification level of a blob trust policy statement and override the action of the expiry validation:
+  notation blob policy update --verification-level permissive --override expiry=log examplePolicy
+
+Example - Remove the override of the expiry validation of a blob trust policy statement:
+  notation blob policy update --remove-override expiry examplePolicy
+
+Example - Set a blob trust policy statement as the global policy:
+  notation blob policy update --global examplePolicy
+
+Example - Unset the global flag of a blob trust policy statement:
+  notation blob policy update --global=false examplePolicy
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 || args[0] == "" {
+				return errors.New("missing policy name: use `notation blob policy update --help` to see what parameters are required")
+			}
+			opts.name = args[0]
+			return nil
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpdate(cmd.Flags(), &opts)
+		},
+	}
+
+	command.Flags().BoolVar(&opts.global, "global", false, "set or unset the statement as the global policy")
+	opts.StatementFlagOpts.ApplyFlags(command.Flags())
+	return command
+}
+
+func runUpdate(fs *pflag.FlagSet, opts *updateOpts) error {
+	doc, policyPath, err := loadBlobDocument(false)
+	if err != nil {
+		return err
+	}
+	index := findBlobTrustPolicy(doc, opts.name)
+	if index < 0 {
+		return fmt.Errorf("blob trust policy statement %q does not exist", opts.name)
+	}
+
+	statement := &doc.TrustPolicies[index]
+	if err := opts.StatementFlagOpts.Apply(fs, &statement.SignatureVerification, &statement.TrustStores, &statement.TrustedIdentities); err != nil {
+		return err
+	}
+	if fs.Changed("global") {
+		statement.GlobalPolicy = opts.global
+	}
+	if err := saveBlobDocument(doc, policyPath); err != nil {
+		return err
+	}
+	return opts.printer.Printf("Successfully updated blob trust policy statement %q in %s.\n", opts.name, policyPath)
+}
@@ -0,0 +1,325 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-go/log"
+	notationregistry "github.com/notaryproject/notation-go/registry"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	registryutil "github.com/notaryproject/notation/v2/cmd/notation/internal/registry"
+	"github.com/notaryproject/notation/v2/internal/envelope"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+const (
+	// artifactTypeBlob is the artifact type of the manifest pushed by
+	// `notation blob sign --push`. It is the default artifact type used by
+	// ORAS.
+	artifactTypeBlob = "application/vnd.unknown.artifact.v1"
+
+	// maxSignatureAttempts is the maximum number of signatures fetched from
+	// the registry by `notation blob verify --from`.
+	maxSignatureAttempts = 100
+
+	// notationBlobDownloadTmpFile is the name pattern of the temporary file
+	// the blob is downloaded to by `notation blob verify --from`.
+	notationBlobDownloadTmpFile = "notation-blob-download-*"
+)
+
+// remoteSignature is a signature envelope attached to a blob artifact.
+type remoteSignature struct {
+	// Manifest is the descriptor of the signature manifest.
+	Manifest ocispec.Descriptor
+
+	// MediaType is the media type of the signature envelope.
+	MediaType string
+
+	// Envelope is the signature envelope.
+	Envelope []byte
+}
+
+// blobArtifact is a blob pushed to a remote repository as an OCI artifact.
+type blobArtifact struct {
+	// Repository is the remote repository of the artifact.
+	Repository *remote.Repository
+
+	// Manifest is the descriptor of the artifact manifest.
+	Manifest ocispec.Descriptor
+
+	// Blob is the descriptor of the blob, the only layer of the manifest.
+	Blob ocispec.Descriptor
+}
+
+// newBlobRepository returns the remote repository of reference, which is in
+// the format of <registry>/<repository>[:<tag>|@<digest>].
+func newBlobRepository(ctx context.Context, opts *flag.SecureFlagOpts, reference string) (*remote.Repository, error) {
+	ref, err := registry.ParseReference(reference)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %w. Expecting <registry>/<repository>[:<tag>]", reference, err)
+	}
+	return registryutil.GetRepositoryClient(ctx, opts, ref)
+}
+
+// validatePushReference validates the reference of `notation blob sign
+// --push`, which is in the format of <registry>/<repository>[:<tag>].
+func validatePushReference(reference string) error {
+	if reference == "" {
+		return errors.New("push reference cannot be empty")
+	}
+	ref, err := registry.ParseReference(reference)
+	if err != nil {
+		return fmt.Errorf("%q: %w. Expecting <registry>/<repository>[:<tag>]", reference, err)
+	}
+	if _, err := ref.Digest(); err == nil {
+		return fmt.Errorf("%q: pushing to a digest is not supported. Expecting <registry>/<repository>[:<tag>]", reference)
+	}
+	return nil
+}
+
+// pushBlobArtifact pushes the blob described by blobDesc as an OCI artifact
+// to repo and tags the artifact if repo has a tag. If the tag already refers
+// to an artifact containing the blob, the existing artifact is reused. The
+// blob content is read from blobPath, unless it already exists in repo.
+func pushBlobArtifact(ctx context.Context, repo *remote.Repository, blobPath string, blobDesc ocispec.Descriptor) (*blobArtifact, error) {
+	logger := log.GetLogger(ctx)
+
+	// reuse the existing artifact
+	tag := repo.Reference.Reference
+	if tag != "" {
+		if artifact, err := resolveBlobArtifact(ctx, repo, tag); err == nil && artifact.Blob.Digest == blobDesc.Digest {
+			logger.Infof("Reusing artifact %s@%s", repo.Reference.Repository, artifact.Manifest.Digest)
+			return artifact, nil
+		} else if err != nil && !errors.Is(err, errdef.ErrNotFound) {
+			logger.Infof("Failed to resolve %s, pushing a new artifact: %v", tag, err)
+		}
+	}
+
+	// push the blob
+	exists, err := repo.Exists(ctx, blobDesc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the existence of blob %s: %w", blobDesc.Digest, err)
+	}
+	if !exists {
+		blobFile, err := os.Open(blobPath)
+		if err != nil {
+			return nil, fmt.Errorf("blob %s does not exist in the repository and cannot be pushed: %w", blobDesc.Digest, err)
+		}
+		defer blobFile.Close()
+		logger.Infof("Pushing blob %s", blobDesc.Digest)
+		if err := repo.Push(ctx, blobDesc, blobFile); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+			return nil, fmt.Errorf("failed to push blob %s: %w", blobDesc.Digest, err)
+		}
+	}
+	if err := registryutil.PushIfNotExist(ctx, repo, ocispec.DescriptorEmptyJSON, ocispec.DescriptorEmptyJSON.Data); err != nil {
+		return nil, fmt.Errorf("failed to push the config of the artifact: %w", err)
+	}
+
+	// push the manifest. The manifest is deterministic so that pushing the
+	// same blob again reuses the existing artifact.
+	manifest := ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: artifactTypeBlob,
+		Config:       ocispec.DescriptorEmptyJSON,
+		Layers:       []ocispec.Descriptor{blobDesc},
+	}
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the artifact manifest: %w", err)
+	}
+	manifestDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifestJSON)
+	manifestDesc.ArtifactType = artifactTypeBlob
+	if tag != "" {
+		err = repo.PushReference(ctx, manifestDesc, bytes.NewReader(manifestJSON), tag)
+	} else {
+		err = registryutil.PushIfNotExist(ctx, repo, manifestDesc, manifestJSON)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to push the artifact manifest: %w", err)
+	}
+	return &blobArtifact{
+		Repository: repo,
+		Manifest:   manifestDesc,
+		Blob:       blobDesc,
+	}, nil
+}
+
+// resolveBlobArtifact resolves reference, a tag or a digest, in repo to a
+// blob artifact, which is a manifest with exactly one layer.
+func resolveBlobArtifact(ctx context.Context, repo *remote.Repository, reference string) (*blobArtifact, error) {
+	manifestDesc, manifestJSON, err := repo.FetchReference(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	defer manifestJSON.Close()
+	if manifestDesc.MediaType != ocispec.MediaTypeImageManifest {
+		return nil, fmt.Errorf("%s is not a blob artifact: unsupported manifest media type %q", reference, manifestDesc.MediaType)
+	}
+	manifestBytes, err := content.ReadAll(manifestJSON, manifestDesc)
+	if err != nil {
+		return nil, err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse the manifest of %s: %w", reference, err)
+	}
+	if len(manifest.Layers) != 1 {
+		return nil, fmt.Errorf("%s is not a blob artifact: expecting exactly one layer, but got %d", reference, len(manifest.Layers))
+	}
+	return &blobArtifact{
+		Repository: repo,
+		Manifest:   manifestDesc,
+		Blob:       manifest.Layers[0],
+	}, nil
+}
+
+// fetchBlob downloads the blob of artifact into a temporary file and
+// returns its path. The content is checked against the digest and the size
+// of the blob descriptor. The caller is responsible for removing the file.
+func fetchBlob(ctx context.Context, artifact *blobArtifact) (string, error) {
+	blobReader, err := artifact.Repository.Fetch(ctx, artifact.Blob)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch blob %s: %w", artifact.Blob.Digest, err)
+	}
+	defer blobReader.Close()
+	tmpFile, err := os.CreateTemp("", notationBlobDownloadTmpFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file required for downloading blob: %w", err)
+	}
+	vr := content.NewVerifyReader(blobReader, artifact.Blob)
+	if _, err := io.Copy(tmpFile, vr); err == nil {
+		err = vr.Verify()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return "", fmt.Errorf("failed to fetch blob %s: %w", artifact.Blob.Digest, err)
+	}
+	return tmpFile.Name(), nil
+}
+
+// attachBlobSignature pushes the signature envelope sig to the repository
+// of artifact as a referrer of the artifact manifest, and returns the
+// descriptor of the signature manifest.
+func attachBlobSignature(ctx context.Context, artifact *blobArtifact, sig []byte, signatureMediaType string) (ocispec.Descriptor, error) {
+	annotations, err := signatureAnnotations(sig, signatureMediaType)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	_, sigManifestDesc, err := notationregistry.NewRepository(artifact.Repository).PushSignature(ctx, signatureMediaType, sig, artifact.Manifest, annotations)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to push the signature: %w", err)
+	}
+	return sigManifestDesc, nil
+}
+
+// fetchBlobSignatures fetches at most maxSignatureAttempts signatures
+// attached to artifact.
+func fetchBlobSignatures(ctx context.Context, artifact *blobArtifact) ([]*remoteSignature, error) {
+	sigRepo := notationregistry.NewRepository(artifact.Repository)
+	var sigManifestDescs []ocispec.Descriptor
+	errMaxSignatures := errors.New("maximum number of signatures reached")
+	err := sigRepo.ListSignatures(ctx, artifact.Manifest, func(signatureManifests []ocispec.Descriptor) error {
+		for _, sigManifestDesc := range signatureManifests {
+			if len(sigManifestDescs) >= maxSignatureAttempts {
+				return errMaxSignatures
+			}
+			sigManifestDescs = append(sigManifestDescs, sigManifestDesc)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errMaxSignatures) {
+		return nil, fmt.Errorf("failed to list the signatures: %w", err)
+	}
+
+	signatures := make([]*remoteSignature, 0, len(sigManifestDescs))
+	for _, sigManifestDesc := range sigManifestDescs {
+		sig, sigDesc, err := sigRepo.FetchSignatureBlob(ctx, sigManifestDesc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch signature %s: %w", sigManifestDesc.Digest, err)
+		}
+		signatures = append(signatures, &remoteSignature{
+			Manifest:  sigManifestDesc,
+			MediaType: sigDesc.MediaType,
+			Envelope:  sig,
+		})
+	}
+	return signatures, nil
+}
+
+// signatureAnnotations returns the annotations of the signature manifest,
+// which contain the thumbprints of the signing certificate chain.
+func signatureAnnotations(sig []byte, signatureMediaType string) (map[string]string, error) {
+	sigEnv, err := signature.ParseEnvelope(signatureMediaType, sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature: %w", err)
+	}
+	envelopeContent, err := sigEnv.Content()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature: %w", err)
+	}
+	var thumbprints []string
+	for _, cert := range envelopeContent.SignerInfo.CertificateChain {
+		checkSum := sha256.Sum256(cert.Raw)
+		thumbprints = append(thumbprints, hex.EncodeToString(checkSum[:]))
+	}
+	value, err := json.Marshal(thumbprints)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		envelope.AnnotationX509ChainThumbprint: string(value),
+	}, nil
+}
+
+// blobLayerDescriptor returns the descriptor of the blob at blobPath as a
+// layer of the blob artifact. If blobDigest is not empty, the declared
+// digest and size are used instead of reading the blob content.
+func blobLayerDescriptor(blobPath, mediaType string, blobDigest digest.Digest, size int64) (ocispec.Descriptor, error) {
+	if blobDigest == "" {
+		d, s, err := digestFile(blobPath, digest.SHA256)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		blobDigest, size = d, s
+	}
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    blobDigest,
+		Size:      size,
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: filepath.Base(blobPath),
+		},
+	}, nil
+}
@@ -0,0 +1,256 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/signer"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/registry/registrytest"
+	"github.com/notaryproject/notation/v2/internal/envelope"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestValidatePushReference(t *testing.T) {
+	for _, reference := range []string{"localhost:5000/test", "localhost:5000/test:v1"} {
+		if err := validatePushReference(reference); err != nil {
+			t.Errorf("validatePushReference(%q) error = %v", reference, err)
+		}
+	}
+	for _, reference := range []string{"", "localhost:5000", "localhost:5000/test@" + digest.FromString("test").String()} {
+		if err := validatePushReference(reference); err == nil {
+			t.Errorf("validatePushReference(%q) expected error, but got nil", reference)
+		}
+	}
+}
+
+func TestPushBlobArtifact(t *testing.T) {
+	ctx := context.Background()
+	reg := registrytest.New(t)
+	blobPath := filepath.Join(t.TempDir(), "blob.txt")
+	if err := os.WriteFile(blobPath, []byte("test blob"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	blobDesc, err := blobLayerDescriptor(blobPath, "text/plain", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blobDesc.Digest != digest.FromString("test blob") || blobDesc.Size != 9 || blobDesc.Annotations[ocispec.AnnotationTitle] != "blob.txt" {
+		t.Fatalf("unexpected blob descriptor %+v", blobDesc)
+	}
+
+	repo, err := newBlobRepository(ctx, &flag.SecureFlagOpts{InsecureRegistry: true}, reg.Host+"/test/blob:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifact, err := pushBlobArtifact(ctx, repo, blobPath, blobDesc)
+	if err != nil {
+		t.Fatalf("pushBlobArtifact() error = %v", err)
+	}
+	if artifact.Blob.Digest != blobDesc.Digest || artifact.Manifest.ArtifactType != artifactTypeBlob {
+		t.Fatalf("unexpected artifact %+v", artifact)
+	}
+
+	// pushing the same blob again reuses the artifact
+	pushed, err := pushBlobArtifact(ctx, repo, blobPath, blobDesc)
+	if err != nil {
+		t.Fatalf("pushBlobArtifact() error = %v", err)
+	}
+	if pushed.Manifest.Digest != artifact.Manifest.Digest {
+		t.Fatalf("expected artifact %s to be reused, got %s", artifact.Manifest.Digest, pushed.Manifest.Digest)
+	}
+	if reg.BlobUploads() != 2 {
+		t.Fatalf("expected the blob and the config to be uploaded once, got %d uploads", reg.BlobUploads())
+	}
+
+	resolved, err := resolveBlobArtifact(ctx, repo, artifact.Manifest.Digest.String())
+	if err != nil {
+		t.Fatalf("resolveBlobArtifact() error = %v", err)
+	}
+	if resolved.Blob.Digest != blobDesc.Digest {
+		t.Fatalf("expected blob %s, got %s", blobDesc.Digest, resolved.Blob.Digest)
+	}
+
+	t.Run("declared digest of a missing blob", func(t *testing.T) {
+		blobDesc, err := blobLayerDescriptor(filepath.Join(t.TempDir(), "missing"), "text/plain", digest.FromString("missing"), 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pushBlobArtifact(ctx, repo, filepath.Join(t.TempDir(), "missing"), blobDesc); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+
+	t.Run("not a blob artifact", func(t *testing.T) {
+		if _, err := resolveBlobArtifact(ctx, repo, ocispec.DescriptorEmptyJSON.Digest.String()); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
+
+func TestFetchBlob(t *testing.T) {
+	ctx := context.Background()
+	reg := registrytest.New(t)
+	blobPath := filepath.Join(t.TempDir(), "blob.txt")
+	if err := os.WriteFile(blobPath, []byte("test blob"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	blobDesc, err := blobLayerDescriptor(blobPath, "text/plain", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := newBlobRepository(ctx, &flag.SecureFlagOpts{InsecureRegistry: true}, reg.Host+"/test/blob:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifact, err := pushBlobArtifact(ctx, repo, blobPath, blobDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fetchedPath, err := fetchBlob(ctx, artifact)
+	if err != nil {
+		t.Fatalf("fetchBlob() error = %v", err)
+	}
+	defer os.Remove(fetchedPath)
+	fetched, err := os.ReadFile(fetchedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(fetched) != "test blob" {
+		t.Fatalf("expected blob content %q, got %q", "test blob", fetched)
+	}
+
+	t.Run("tampered blob", func(t *testing.T) {
+		reg.SetBlob(blobDesc.Digest, []byte("evil blob"))
+		if _, err := fetchBlob(ctx, artifact); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
+
+func TestAttachBlobSignature(t *testing.T) {
+	ctx := context.Background()
+	reg := registrytest.New(t)
+	repo, err := newBlobRepository(ctx, &flag.SecureFlagOpts{InsecureRegistry: true}, reg.Host+"/test/blob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blobPath := filepath.Join(t.TempDir(), "blob.txt")
+	if err := os.WriteFile(blobPath, []byte("test blob"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	blobDesc, err := blobLayerDescriptor(blobPath, "text/plain", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifact, err := pushBlobArtifact(ctx, repo, blobPath, blobDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signatures, err := fetchBlobSignatures(ctx, artifact)
+	if err != nil {
+		t.Fatalf("fetchBlobSignatures() error = %v", err)
+	}
+	if len(signatures) != 0 {
+		t.Fatalf("expected no signature, got %d", len(signatures))
+	}
+
+	sig, cert := newTestBlobSignature(t)
+	sigManifestDesc, err := attachBlobSignature(ctx, artifact, sig, jws.MediaTypeEnvelope)
+	if err != nil {
+		t.Fatalf("attachBlobSignature() error = %v", err)
+	}
+	checkSum := sha256.Sum256(cert.Raw)
+	expectedThumbprints := fmt.Sprintf("[%q]", hex.EncodeToString(checkSum[:]))
+	if got := sigManifestDesc.Annotations[envelope.AnnotationX509ChainThumbprint]; got != expectedThumbprints {
+		t.Fatalf("expected thumbprints %s, got %s", expectedThumbprints, got)
+	}
+
+	signatures, err = fetchBlobSignatures(ctx, artifact)
+	if err != nil {
+		t.Fatalf("fetchBlobSignatures() error = %v", err)
+	}
+	if len(signatures) != 1 || signatures[0].MediaType != jws.MediaTypeEnvelope || !bytes.Equal(signatures[0].Envelope, sig) {
+		t.Fatalf("unexpected signatures %+v", signatures)
+	}
+
+	t.Run("invalid signature", func(t *testing.T) {
+		if _, err := attachBlobSignature(ctx, artifact, []byte("invalid"), jws.MediaTypeEnvelope); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
+
+// newTestBlobSignature returns a JWS signature of a test blob and the
+// self-signed signing certificate.
+func newTestBlobSignature(t *testing.T) ([]byte, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test", Organization: []string{"Notary"}, Country: []string{"US"}, Province: []string{"WA"}, Locality: []string{"Seattle"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blobSigner, err := signer.NewGenericSigner(key, []*x509.Certificate{cert})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, _, err := notation.SignBlob(context.Background(), blobSigner, strings.NewReader("test blob"), notation.SignBlobOptions{
+		SignerSignOptions: notation.SignerSignOptions{
+			SignatureMediaType: jws.MediaTypeEnvelope,
+		},
+		ContentMediaType: "text/plain",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig, cert
+}
@@ -14,7 +14,9 @@
 package blob
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -23,6 +25,7 @@ import (
 	"time"
 
 	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/signature"
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
@@ -34,6 +37,8 @@ import (
 	clirev "github.com/notaryproject/notation/v2/internal/revocation"
 	nx509 "github.com/notaryproject/notation/v2/internal/x509"
 	"github.com/notaryproject/tspclient-go"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 )
 
@@ -44,6 +49,7 @@ const timestampingTimeout = 15 * time.Second
 type blobSignOpts struct {
 	flag.LoggingFlagOpts
 	flag.SignerFlagOpts
+	flag.SecureFlagOpts
 	expiry                 time.Duration
 	pluginConfig           []string
 	userMetadata           []string
@@ -53,6 +59,12 @@ type blobSignOpts struct {
 	tsaServerURL           string
 	tsaRootCertificatePath string
 	force                  bool
+	recursive              bool
+	bundle                 bool
+	blobDigest             string
+	blobSize               int64
+	checksumsPath          string
+	pushReference          string
 }
 
 func signCommand(opts *blobSignOpts) *cobra.Command {
@@ -94,6 +106,21 @@ Example - Sign a blob artifact and specify the signature expiry duration, for ex
 
 Example - Sign a blob artifact with timestamping:
   notation blob sign --timestamp-url <TSA_url> --timestamp-root-cert <TSA_root_certificate_filepath> <blob_path>
+
+Example - Sign all files in a directory by signing a manifest of their digests:
+  notation blob sign --recursive <directory_path>
+
+Example - Sign a blob artifact by its digest and size without reading the blob content:
+  notation blob sign --digest sha256:<hex> --size <size_in_bytes> --media-type <media type> <blob_path>
+
+Example - Sign every blob listed in a checksum file produced by sha256sum:
+  notation blob sign --checksums SHA256SUMS
+
+Example - Sign a blob artifact, push it to a registry as an OCI artifact, or reuse the existing one, and attach the signature to it:
+  notation blob sign --push <registry>/<repository>[:<tag>] <blob_path>
+
+Example - Sign a blob artifact and write a self-contained signature bundle "{blob file name}.{signature format}.bundle.json" with the CRLs of the certificates:
+  notation blob sign --bundle <blob_path>
 `
 
 	command := &cobra.Command{
@@ -101,6 +128,12 @@ Example - Sign a blob artifact with timestamping:
 		Short: "Produce a detached signature for a given blob",
 		Long:  longMessage,
 		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.checksumsPath != "" {
+				if len(args) > 0 {
+					return errors.New("blob path cannot be used with --checksums: the blobs to sign are listed in the checksum file")
+				}
+				return nil
+			}
 			if len(args) == 0 {
 				return errors.New("missing file path to the blob artifact: use `notation blob sign --help` to see what parameters are required")
 			}
@@ -116,10 +149,32 @@ Example - Sign a blob artifact with timestamping:
 				if opts.signatureDirectory == "" {
 					return errors.New("signature directory cannot be empty")
 				}
-			} else {
+			} else if opts.checksumsPath == "" {
 				// --signature-directory flag is not set. By default, save the
-				// signature file in the same directory as the blob.
-				opts.signatureDirectory = filepath.Dir(opts.blobPath)
+				// signature file in the same directory as the blob. For
+				// --checksums, it is resolved for each blob in the checksum
+				// file.
+				opts.signatureDirectory = filepath.Dir(filepath.Clean(opts.blobPath))
+			}
+
+			// signing by digest
+			if cmd.Flags().Changed("digest") {
+				if _, err := digest.Parse(opts.blobDigest); err != nil {
+					return fmt.Errorf("invalid digest %q: %w", opts.blobDigest, err)
+				}
+				if opts.blobSize < 0 {
+					return errors.New("blob size cannot be negative")
+				}
+			}
+			if cmd.Flags().Changed("checksums") && opts.checksumsPath == "" {
+				return errors.New("filepath of the checksum file cannot be empty")
+			}
+
+			// pushing to a registry
+			if cmd.Flags().Changed("push") {
+				if err := validatePushReference(opts.pushReference); err != nil {
+					return err
+				}
 			}
 
 			// timestamping
@@ -141,6 +196,7 @@ Example - Sign a blob artifact with timestamping:
 	}
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
 	opts.SignerFlagOpts.ApplyFlagsToCommand(command)
+	opts.SecureFlagOpts.ApplyFlags(command.Flags())
 	flag.SetPflagExpiry(command.Flags(), &opts.expiry)
 	flag.SetPflagPluginConfig(command.Flags(), &opts.pluginConfig)
 	flag.SetPflagUserMetadata(command.Flags(), &opts.userMetadata, flag.PflagUserMetadataSignUsage)
@@ -149,7 +205,16 @@ Example - Sign a blob artifact with timestamping:
 	command.Flags().StringVar(&opts.tsaServerURL, "timestamp-url", "", "RFC 3161 Timestamping Authority (TSA) server URL")
 	command.Flags().StringVar(&opts.tsaRootCertificatePath, "timestamp-root-cert", "", "filepath of timestamp authority root certificate")
 	command.Flags().BoolVar(&opts.force, "force", false, "override the existing signature file, never prompt")
+	command.Flags().BoolVar(&opts.bundle, "bundle", false, "write a signature bundle \"{blob file name}.{signature format}.bundle.json\" containing the signature envelope and the CRLs of the signing and timestamping certificates, instead of the signature file")
+	command.Flags().BoolVar(&opts.recursive, "recursive", false, "sign all files in the directory by signing a manifest of their paths, sizes and digests. The manifest file \"{directory name}.manifest.json\" is written to the signature directory")
+	command.Flags().StringVar(&opts.blobDigest, "digest", "", "digest of the blob, for example sha256:<hex>. The blob is signed by the declared digest and size without reading its content. Requires --size")
+	command.Flags().Int64Var(&opts.blobSize, "size", 0, "size of the blob in bytes, used with --digest")
+	command.Flags().StringVar(&opts.checksumsPath, "checksums", "", "filepath of a checksum file in the format of sha256sum output. Every blob listed in the file is signed by its checksum, and its size is read from the blob file located relative to the checksum file")
+	command.Flags().StringVar(&opts.pushReference, "push", "", "push the blob as an OCI artifact to the repository <registry>/<repository>[:<tag>], or reuse the existing artifact of the blob, and attach the signature to the artifact")
 	command.MarkFlagsRequiredTogether("timestamp-url", "timestamp-root-cert")
+	command.MarkFlagsRequiredTogether("digest", "size")
+	command.MarkFlagsMutuallyExclusive("digest", "checksums", "recursive")
+	command.MarkFlagsMutuallyExclusive("push", "checksums", "recursive")
 	return command
 }
 
@@ -166,44 +231,308 @@ func runBlobSign(command *cobra.Command, cmdOpts *blobSignOpts) error {
 	if err != nil {
 		return err
 	}
-	blobFile, err := os.Open(cmdOpts.blobPath)
+	switch {
+	case cmdOpts.recursive:
+		return runRecursiveBlobSign(ctx, cmdOpts, blobSigner, blobOpts)
+	case cmdOpts.checksumsPath != "":
+		return runChecksumsBlobSign(ctx, cmdOpts, blobSigner, blobOpts)
+	}
+
+	// core process
+	var sig []byte
+	if cmdOpts.blobDigest != "" {
+		desc := ocispec.Descriptor{
+			Digest: digest.Digest(cmdOpts.blobDigest),
+			Size:   cmdOpts.blobSize,
+		}
+		logger.Infof("Signing blob %s by digest %s and size %d", cmdOpts.blobPath, desc.Digest, desc.Size)
+		if sig, err = signBlobDescriptor(ctx, blobSigner, desc, blobOpts); err != nil {
+			return err
+		}
+	} else {
+		blobFile, err := os.Open(cmdOpts.blobPath)
+		if err != nil {
+			return err
+		}
+		defer blobFile.Close()
+		if sig, _, err = notation.SignBlob(ctx, blobSigner, blobFile, blobOpts); err != nil {
+			return err
+		}
+	}
+	if _, err = writeBlobSignature(ctx, cmdOpts, cmdOpts.signatureDirectory, cmdOpts.blobPath, sig, blobOpts.SignatureMediaType); err != nil {
+		return err
+	}
+	if cmdOpts.pushReference != "" {
+		return pushBlobSignature(ctx, cmdOpts, sig, blobOpts)
+	}
+	return nil
+}
+
+// pushBlobSignature pushes the blob at cmdOpts.blobPath as an OCI artifact to
+// cmdOpts.pushReference, or reuses the existing artifact, and attaches the
+// signature envelope sig to the artifact.
+func pushBlobSignature(ctx context.Context, cmdOpts *blobSignOpts, sig []byte, blobOpts notation.SignBlobOptions) error {
+	repo, err := newBlobRepository(ctx, &cmdOpts.SecureFlagOpts, cmdOpts.pushReference)
+	if err != nil {
+		return err
+	}
+	blobDesc, err := blobLayerDescriptor(cmdOpts.blobPath, cmdOpts.blobMediaType, digest.Digest(cmdOpts.blobDigest), cmdOpts.blobSize)
 	if err != nil {
 		return err
 	}
-	defer blobFile.Close()
+	artifact, err := pushBlobArtifact(ctx, repo, cmdOpts.blobPath, blobDesc)
+	if err != nil {
+		return err
+	}
+	artifactRef := fmt.Sprintf("%s/%s@%s", repo.Reference.Registry, repo.Reference.Repository, artifact.Manifest.Digest)
+	fmt.Printf("Blob artifact pushed to %s\n", artifactRef)
+	sigManifestDesc, err := attachBlobSignature(ctx, artifact, sig, blobOpts.SignatureMediaType)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Signature %s attached to %s\n", sigManifestDesc.Digest, artifactRef)
+	return nil
+}
 
-	// core process
-	sig, _, err := notation.SignBlob(ctx, blobSigner, blobFile, blobOpts)
+// runChecksumsBlobSign signs every blob listed in the checksum file
+// cmdOpts.checksumsPath by its checksum.
+func runChecksumsBlobSign(ctx context.Context, cmdOpts *blobSignOpts, blobSigner notation.BlobSigner, blobOpts notation.SignBlobOptions) error {
+	logger := log.GetLogger(ctx)
+
+	checksumsFile, err := os.Open(cmdOpts.checksumsPath)
 	if err != nil {
 		return err
 	}
-	signaturePath := signatureFilepath(cmdOpts.signatureDirectory, cmdOpts.blobPath, cmdOpts.SignatureFormat)
+	defer checksumsFile.Close()
+	entries, err := parseChecksums(checksumsFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse checksum file %s: %w", cmdOpts.checksumsPath, err)
+	}
+
+	// blobs are located relative to the checksum file
+	checksumsDirectory := filepath.Dir(cmdOpts.checksumsPath)
+	var signedCount int
+	for _, entry := range entries {
+		blobPath := filepath.FromSlash(entry.Path)
+		if !filepath.IsAbs(blobPath) {
+			blobPath = filepath.Join(checksumsDirectory, blobPath)
+		}
+		info, err := os.Stat(blobPath)
+		if err != nil {
+			return fmt.Errorf("failed to get the size of %s: %w", blobPath, err)
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", blobPath)
+		}
+		desc := ocispec.Descriptor{
+			Digest: entry.Digest,
+			Size:   info.Size(),
+		}
+		logger.Infof("Signing blob %s by digest %s and size %d", blobPath, desc.Digest, desc.Size)
+		sig, err := signBlobDescriptor(ctx, blobSigner, desc, blobOpts)
+		if err != nil {
+			return fmt.Errorf("failed to sign %s: %w", blobPath, err)
+		}
+		signatureDirectory := cmdOpts.signatureDirectory
+		if signatureDirectory == "" {
+			signatureDirectory = filepath.Dir(blobPath)
+		}
+		written, err := writeBlobSignature(ctx, cmdOpts, signatureDirectory, blobPath, sig, blobOpts.SignatureMediaType)
+		if err != nil {
+			return err
+		}
+		if written {
+			signedCount++
+		}
+	}
+	fmt.Printf("Successfully signed %d of %d blobs listed in %s\n", signedCount, len(entries), cmdOpts.checksumsPath)
+	return nil
+}
+
+// signBlobDescriptor signs the blob described by its declared digest and
+// size in desc, without reading the blob content. The digest algorithm must
+// be the one required by the signing key.
+func signBlobDescriptor(ctx context.Context, blobSigner notation.BlobSigner, desc ocispec.Descriptor, blobOpts notation.SignBlobOptions) ([]byte, error) {
+	// notation.SignBlob validates the options and adds the user metadata to
+	// the descriptor generated from the empty content, whose digest and size
+	// are then replaced with the declared ones.
+	signer := &descriptorBlobSigner{BlobSigner: blobSigner, desc: desc}
+	sig, _, err := notation.SignBlob(ctx, signer, bytes.NewReader(nil), blobOpts)
+	return sig, err
+}
+
+// descriptorBlobSigner is a notation.BlobSigner signing the declared digest
+// and size of desc instead of the ones of the content.
+type descriptorBlobSigner struct {
+	notation.BlobSigner
+	desc ocispec.Descriptor
+}
+
+// SignBlob signs the descriptor generated by genDesc with the digest and the
+// size of s.desc.
+func (s *descriptorBlobSigner) SignBlob(ctx context.Context, genDesc notation.BlobDescriptorGenerator, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	return s.BlobSigner.SignBlob(ctx, func(hashAlgo digest.Algorithm) (ocispec.Descriptor, error) {
+		if s.desc.Digest.Algorithm() != hashAlgo {
+			return ocispec.Descriptor{}, fmt.Errorf("the signing key requires a %s digest, but the blob digest %s is computed with %s", hashAlgo, s.desc.Digest, s.desc.Digest.Algorithm())
+		}
+		desc, err := genDesc(hashAlgo)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		desc.Digest = s.desc.Digest
+		desc.Size = s.desc.Size
+		return desc, nil
+	}, opts)
+}
+
+// writeBlobSignature writes the signature sig of the blob at blobPath to
+// signatureDirectory, as a signature bundle if --bundle is set. It returns
+// false if the user declined to overwrite the existing signature file.
+func writeBlobSignature(ctx context.Context, cmdOpts *blobSignOpts, signatureDirectory, blobPath string, sig []byte, signatureMediaType string) (bool, error) {
+	logger := log.GetLogger(ctx)
+
+	signaturePath := outputFilepath(cmdOpts, signatureDirectory, blobPath)
+	if cmdOpts.bundle {
+		var err error
+		if sig, err = bundleSignature(ctx, sig, signatureMediaType); err != nil {
+			return false, err
+		}
+	}
 	logger.Infof("Writing signature to file %s", signaturePath)
+	written, err := writeSignatureFile(signaturePath, sig, cmdOpts.force)
+	if err != nil || !written {
+		return false, err
+	}
+	fmt.Printf("Successfully signed %s\n ", blobPath)
+	fmt.Printf("Signature file written to %s\n", signaturePath)
+	return true, nil
+}
 
-	// optional confirmation
-	if !cmdOpts.force {
-		if _, err := os.Stat(signaturePath); err == nil {
-			confirmed, err := display.AskForConfirmation(os.Stdin, "The signature file already exists, do you want to overwrite it?", cmdOpts.force)
-			if err != nil {
-				return err
-			}
-			if !confirmed {
-				return nil
-			}
+// runRecursiveBlobSign generates a manifest of all files in the directory
+// cmdOpts.blobPath and signs the manifest.
+func runRecursiveBlobSign(ctx context.Context, cmdOpts *blobSignOpts, blobSigner notation.BlobSigner, blobOpts notation.SignBlobOptions) error {
+	logger := log.GetLogger(ctx)
+
+	manifestPath := blobManifestPath(cmdOpts.signatureDirectory, cmdOpts.blobPath)
+	signaturePath := outputFilepath(cmdOpts, cmdOpts.signatureDirectory, manifestPath)
+
+	// the manifest and the signature are excluded in case the signature
+	// directory is inside the signed directory
+	logger.Infof("Generating manifest for directory %s", cmdOpts.blobPath)
+	manifest, err := generateBlobManifest(cmdOpts.blobPath, cmdOpts.blobMediaType, manifestPath, signaturePath)
+	if err != nil {
+		return fmt.Errorf("failed to generate manifest for directory %s: %w", cmdOpts.blobPath, err)
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal blob manifest: %w", err)
+	}
+
+	// core process
+	blobOpts.ContentMediaType = mediaTypeBlobManifest
+	sig, _, err := notation.SignBlob(ctx, blobSigner, bytes.NewReader(manifestJSON), blobOpts)
+	if err != nil {
+		return err
+	}
+	if cmdOpts.bundle {
+		if sig, err = bundleSignature(ctx, sig, blobOpts.SignatureMediaType); err != nil {
+			return err
 		}
-	} else {
-		fmt.Fprintln(os.Stderr, "Warning: existing signature file will be overwritten")
+	}
+	confirmed, err := confirmSignatureOverwrite(signaturePath, cmdOpts.force)
+	if err != nil || !confirmed {
+		return err
 	}
 
-	// write signature to file
-	if err := osutil.WriteFile(signaturePath, sig); err != nil {
+	// the signature is moved into place only after the manifest it signs is
+	// written, so that an existing signature is kept if writing the manifest
+	// fails
+	logger.Infof("Writing signature to file %s", signaturePath)
+	tmpPath, err := writeTempFile(signaturePath, sig)
+	if err != nil {
 		return fmt.Errorf("failed to write signature to file: %w", err)
 	}
-	fmt.Printf("Successfully signed %s\n ", cmdOpts.blobPath)
+	if err := osutil.WriteFile(manifestPath, manifestJSON); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write manifest to file: %w", err)
+	}
+	if err := os.Rename(tmpPath, signaturePath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write signature to file: %w", err)
+	}
+	fmt.Printf("Successfully signed %d files in %s\n", len(manifest.Files), cmdOpts.blobPath)
+	fmt.Printf("Manifest file written to %s\n", manifestPath)
 	fmt.Printf("Signature file written to %s\n", signaturePath)
 	return nil
 }
 
+// bundleSignature wraps the signature envelope sig into a signature bundle
+// and returns the content of the bundle.
+func bundleSignature(ctx context.Context, sig []byte, signatureMediaType string) ([]byte, error) {
+	bundle, err := newSignatureBundle(ctx, sig, signatureMediaType, clirev.NewCRLFetcher(ctx))
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signature bundle: %w", err)
+	}
+	return content, nil
+}
+
+// writeSignatureFile writes sig to signaturePath. If the file already exists
+// and force is false, the user is asked to confirm overwriting it.
+// It returns false if the user declined.
+func writeSignatureFile(signaturePath string, sig []byte, force bool) (bool, error) {
+	confirmed, err := confirmSignatureOverwrite(signaturePath, force)
+	if err != nil || !confirmed {
+		return false, err
+	}
+
+	// write signature to file
+	if err := osutil.WriteFile(signaturePath, sig); err != nil {
+		return false, fmt.Errorf("failed to write signature to file: %w", err)
+	}
+	return true, nil
+}
+
+// confirmSignatureOverwrite asks the user to confirm overwriting the
+// signature file at signaturePath if it already exists and force is false.
+// It returns false if the user declined.
+func confirmSignatureOverwrite(signaturePath string, force bool) (bool, error) {
+	if force {
+		fmt.Fprintln(os.Stderr, "Warning: existing signature file will be overwritten")
+		return true, nil
+	}
+	if _, err := os.Stat(signaturePath); err == nil {
+		return display.AskForConfirmation(os.Stdin, "The signature file already exists, do you want to overwrite it?", force)
+	}
+	return true, nil
+}
+
+// writeTempFile writes data to a new temporary file in the directory of
+// path, with all parent directories created, and returns the path of the
+// temporary file.
+func writeTempFile(path string, data []byte) (string, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	tmpFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	_, err = tmpFile.Write(data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return "", err
+	}
+	return tmpFile.Name(), nil
+}
+
 func prepareBlobSigningOpts(ctx context.Context, opts *blobSignOpts) (notation.SignBlobOptions, error) {
 	logger := log.GetLogger(ctx)
 
@@ -248,6 +577,16 @@ func prepareBlobSigningOpts(ctx context.Context, opts *blobSignOpts) (notation.S
 	return signBlobOpts, nil
 }
 
+// outputFilepath returns the path in signatureDirectory to the signature
+// file, or to the signature bundle file if --bundle is set, of the blob at
+// blobPath.
+func outputFilepath(opts *blobSignOpts, signatureDirectory, blobPath string) string {
+	if opts.bundle {
+		return bundleFilepath(signatureDirectory, blobPath, opts.SignatureFormat)
+	}
+	return signatureFilepath(signatureDirectory, blobPath, opts.SignatureFormat)
+}
+
 // signatureFilepath returns the path to the signature file.
 func signatureFilepath(signatureDirectory, blobPath, signatureFormat string) string {
 	blobFilename := filepath.Base(blobPath)
@@ -14,13 +14,21 @@
 package blob
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
 	"github.com/notaryproject/notation/v2/internal/envelope"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 func TestBlobSignCommand_BasicArgs(t *testing.T) {
@@ -304,3 +312,275 @@ func TestBlobSignCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestBlobSignCommand_Bundle(t *testing.T) {
+	opts := &blobSignOpts{}
+	command := signCommand(opts)
+	expected := &blobSignOpts{
+		blobPath: "path",
+		SignerFlagOpts: flag.SignerFlagOpts{
+			Key:             "key",
+			SignatureFormat: envelope.JWS,
+		},
+		blobMediaType: "application/octet-stream",
+		bundle:        true,
+	}
+	if err := command.ParseFlags([]string{
+		expected.blobPath,
+		"--key", expected.Key,
+		"--bundle"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := command.Args(command, command.Flags().Args()); err != nil {
+		t.Fatalf("Parse args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect blob sign opts: %v, got: %v", expected, opts)
+	}
+	expectedPath := filepath.Join("sigs", "blob.jws.bundle.json")
+	if got := outputFilepath(opts, "sigs", filepath.Join("dir", "blob")); got != expectedPath {
+		t.Fatalf("expected output file path %s, got %s", expectedPath, got)
+	}
+}
+
+func TestBlobSignCommand_Digest(t *testing.T) {
+	opts := &blobSignOpts{}
+	command := signCommand(opts)
+	expected := &blobSignOpts{
+		blobPath: "path",
+		SignerFlagOpts: flag.SignerFlagOpts{
+			Key:             "key",
+			SignatureFormat: envelope.JWS,
+		},
+		blobMediaType: "application/vnd.example+tar",
+		blobDigest:    digest.FromString("content").String(),
+		blobSize:      7,
+	}
+	if err := command.ParseFlags([]string{
+		expected.blobPath,
+		"--key", expected.Key,
+		"--digest", expected.blobDigest,
+		"--size", "7",
+		"--media-type", expected.blobMediaType}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := command.Args(command, command.Flags().Args()); err != nil {
+		t.Fatalf("Parse args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect blob sign opts: %v, got: %v", expected, opts)
+	}
+
+	t.Run("missing size", func(t *testing.T) {
+		command := signCommand(nil)
+		if err := command.ParseFlags([]string{"path", "--digest", expected.blobDigest}); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		err := command.ValidateFlagGroups()
+		if err == nil || err.Error() != "if any flags in the group [digest size] are set they must all be set; missing [size]" {
+			t.Fatalf("Didn't get the expected error, but got: %v", err)
+		}
+	})
+
+	t.Run("invalid digest", func(t *testing.T) {
+		command := signCommand(nil)
+		if err := command.ParseFlags([]string{"path", "--digest", "sha256:abc", "--size", "7"}); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		if err := command.Args(command, command.Flags().Args()); err != nil {
+			t.Fatalf("Parse args failed: %v", err)
+		}
+		if err := command.PreRunE(command, command.Flags().Args()); err == nil {
+			t.Fatal("PreRunE expected error, but ok")
+		}
+	})
+}
+
+func TestBlobSignCommand_Checksums(t *testing.T) {
+	opts := &blobSignOpts{}
+	command := signCommand(opts)
+	if err := command.ParseFlags([]string{"--checksums", "SHA256SUMS"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := command.Args(command, command.Flags().Args()); err != nil {
+		t.Fatalf("Parse args failed: %v", err)
+	}
+	if err := command.PreRunE(command, command.Flags().Args()); err != nil {
+		t.Fatalf("PreRunE failed: %v", err)
+	}
+	if opts.checksumsPath != "SHA256SUMS" || opts.signatureDirectory != "" {
+		t.Fatalf("unexpected blob sign opts: %v", opts)
+	}
+
+	t.Run("with blob path", func(t *testing.T) {
+		command := signCommand(nil)
+		if err := command.ParseFlags([]string{"path", "--checksums", "SHA256SUMS"}); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		if err := command.Args(command, command.Flags().Args()); err == nil {
+			t.Fatal("Parse Args expected error, but ok")
+		}
+	})
+}
+
+func TestBlobSignCommand_Push(t *testing.T) {
+	opts := &blobSignOpts{}
+	command := signCommand(opts)
+	if err := command.ParseFlags([]string{"path", "--push", "localhost:5000/test:v1", "--insecure-registry"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := command.Args(command, command.Flags().Args()); err != nil {
+		t.Fatalf("Parse args failed: %v", err)
+	}
+	if err := command.PreRunE(command, command.Flags().Args()); err != nil {
+		t.Fatalf("PreRunE failed: %v", err)
+	}
+	if opts.pushReference != "localhost:5000/test:v1" || !opts.InsecureRegistry {
+		t.Fatalf("unexpected blob sign opts: %v", opts)
+	}
+
+	t.Run("push to a digest", func(t *testing.T) {
+		command := signCommand(nil)
+		if err := command.ParseFlags([]string{"path", "--push", "localhost:5000/test@" + digest.FromString("test").String()}); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		if err := command.Args(command, command.Flags().Args()); err != nil {
+			t.Fatalf("Parse args failed: %v", err)
+		}
+		if err := command.PreRunE(command, command.Flags().Args()); err == nil {
+			t.Fatal("PreRunE expected error, but ok")
+		}
+	})
+
+	t.Run("with recursive", func(t *testing.T) {
+		command := signCommand(nil)
+		if err := command.ParseFlags([]string{"path", "--push", "localhost:5000/test", "--recursive"}); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		if err := command.ValidateFlagGroups(); err == nil {
+			t.Fatal("ValidateFlagGroups expected error, but ok")
+		}
+	})
+}
+
+func TestSignBlobDescriptor(t *testing.T) {
+	desc := ocispec.Descriptor{
+		Digest: digest.FromString("content"),
+		Size:   7,
+	}
+	signOpts := notation.SignerSignOptions{
+		SignatureMediaType: "application/jose+json",
+	}
+	blobOpts := notation.SignBlobOptions{
+		SignerSignOptions: signOpts,
+		ContentMediaType:  "application/octet-stream",
+		UserMetadata:      map[string]string{"buildId": "123"},
+	}
+
+	signer := &testBlobSigner{algorithm: digest.SHA256}
+	if _, err := signBlobDescriptor(context.Background(), signer, desc, blobOpts); err != nil {
+		t.Fatalf("signBlobDescriptor() error = %v", err)
+	}
+	expected := ocispec.Descriptor{
+		MediaType:   "application/octet-stream",
+		Digest:      desc.Digest,
+		Size:        7,
+		Annotations: map[string]string{"buildId": "123"},
+	}
+	if !reflect.DeepEqual(expected, signer.signedDesc) {
+		t.Fatalf("expected signed descriptor %+v, got %+v", expected, signer.signedDesc)
+	}
+
+	t.Run("digest algorithm mismatch", func(t *testing.T) {
+		signer := &testBlobSigner{algorithm: digest.SHA384}
+		if _, err := signBlobDescriptor(context.Background(), signer, desc, blobOpts); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+
+	t.Run("reserved user metadata", func(t *testing.T) {
+		blobOpts := notation.SignBlobOptions{
+			SignerSignOptions: signOpts,
+			ContentMediaType:  "application/octet-stream",
+			UserMetadata:      map[string]string{"io.cncf.notary.key": "value"},
+		}
+		if _, err := signBlobDescriptor(context.Background(), signer, desc, blobOpts); err == nil || !strings.Contains(err.Error(), "reserved prefix") {
+			t.Fatalf("expected reserved prefix error, got %v", err)
+		}
+	})
+
+	t.Run("invalid media type", func(t *testing.T) {
+		blobOpts := notation.SignBlobOptions{
+			SignerSignOptions: signOpts,
+			ContentMediaType:  "invalid/",
+		}
+		if _, err := signBlobDescriptor(context.Background(), signer, desc, blobOpts); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
+
+func TestRunRecursiveBlobSign_ManifestWriteFailure(t *testing.T) {
+	root := t.TempDir()
+	blobDir := filepath.Join(root, "bundle")
+	if err := os.MkdirAll(blobDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(blobDir, "a.txt"), []byte("a"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// a directory at the manifest path makes writing the manifest fail
+	if err := os.Mkdir(blobManifestPath(root, blobDir), 0700); err != nil {
+		t.Fatal(err)
+	}
+	cmdOpts := &blobSignOpts{
+		SignerFlagOpts: flag.SignerFlagOpts{
+			SignatureFormat: envelope.JWS,
+		},
+		blobPath:           blobDir,
+		signatureDirectory: root,
+		force:              true,
+	}
+	blobOpts := notation.SignBlobOptions{
+		SignerSignOptions: notation.SignerSignOptions{
+			SignatureMediaType: "application/jose+json",
+		},
+	}
+	// an existing signature is kept if writing the manifest fails
+	signaturePath := outputFilepath(cmdOpts, root, blobManifestPath(root, blobDir))
+	if err := os.WriteFile(signaturePath, []byte("previous signature"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	err := runRecursiveBlobSign(context.Background(), cmdOpts, &testBlobSigner{algorithm: digest.SHA256}, blobOpts)
+	if err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+	content, err := os.ReadFile(signaturePath)
+	if err != nil {
+		t.Fatalf("expected signature file %s to be kept, got %v", signaturePath, err)
+	}
+	if string(content) != "previous signature" {
+		t.Fatalf("expected signature file %s to be unchanged, got %q", signaturePath, content)
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected no temporary signature file left in %s, got %d entries", root, len(entries))
+	}
+}
+
+type testBlobSigner struct {
+	algorithm  digest.Algorithm
+	signedDesc ocispec.Descriptor
+}
+
+func (s *testBlobSigner) SignBlob(ctx context.Context, genDesc notation.BlobDescriptorGenerator, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	desc, err := genDesc(s.algorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.signedDesc = desc
+	return []byte("signature"), &signature.SignerInfo{}, nil
+}
@@ -14,14 +14,21 @@
 package blob
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
 	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/verify"
@@ -31,13 +38,17 @@ import (
 
 type blobVerifyOpts struct {
 	flag.LoggingFlagOpts
+	flag.SecureFlagOpts
 	printer             *output.Printer
 	blobPath            string
 	signaturePath       string
+	signatureDirectory  string
 	pluginConfig        []string
 	userMetadata        []string
 	policyStatementName string
 	blobMediaType       string
+	recursive           bool
+	fromReference       string
 }
 
 func verifyCommand(opts *blobVerifyOpts) *cobra.Command {
@@ -51,6 +62,15 @@ Prerequisite: added a certificate into trust store and created a trust policy.
 Example - Verify a signature on a blob artifact:
   notation blob verify --signature <signature_path> <blob_path>
 
+Example - Verify all signatures of a blob artifact found in the same directory as the blob:
+  notation blob verify <blob_path>
+
+Example - Verify all signatures of a blob artifact found in a particular directory:
+  notation blob verify --signature-directory <signature_directory_path> <blob_path>
+
+Example - Verify a signature bundle on a blob artifact. The CRLs in the bundle are used if fetching a CRL fails:
+  notation blob verify --signature <blob_path>.jws.bundle.json <blob_path>
+
 Example - Verify the signature on a blob artifact with user metadata:
   notation blob verify --user-metadata <metadata> --signature <signature_path> <blob_path>
 
@@ -59,12 +79,24 @@ Example - Verify the signature on a blob artifact with media type:
  
 Example - Verify the signature on a blob artifact using a policy statement name:
   notation blob verify --policy-name <policy_name> --signature <signature_path> <blob_path>
+
+Example - Verify the signature on a manifest produced by "notation blob sign --recursive" and check every file in the directory against it:
+  notation blob verify --recursive --signature <signature_path> <directory_path>
+
+Example - Verify a blob artifact pushed by "notation blob sign --push" against the signatures attached to it in the registry:
+  notation blob verify --from <registry>/<repository>@<digest>
 `
 	command := &cobra.Command{
-		Use:   "verify [flags] --signature <signature_path> <blob_path>",
+		Use:   "verify [flags] {[--signature <signature_path>] <blob_path>|--from <reference>}",
 		Short: "Verify a signature associated with a blob",
 		Long:  longMessage,
 		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.fromReference != "" {
+				if len(args) > 0 {
+					return errors.New("blob path cannot be used with --from: the blob is pulled from the reference")
+				}
+				return nil
+			}
 			if len(args) == 0 {
 				return errors.New("missing path to the blob artifact: use `notation blob verify --help` to see what parameters are required")
 			}
@@ -72,12 +104,21 @@ Example - Verify the signature on a blob artifact using a policy statement name:
 			return nil
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if opts.signaturePath == "" {
+			if cmd.Flags().Changed("signature") && opts.signaturePath == "" {
 				return errors.New("filepath of the signature cannot be empty")
 			}
+			if cmd.Flags().Changed("signature-directory") && opts.signatureDirectory == "" {
+				return errors.New("signature directory cannot be empty")
+			}
 			if cmd.Flags().Changed("media-type") && opts.blobMediaType == "" {
 				return errors.New("--media-type is set but with empty value")
 			}
+			if cmd.Flags().Changed("from") && opts.fromReference == "" {
+				return errors.New("reference of the blob artifact cannot be empty")
+			}
+			if opts.recursive && cmd.Flags().Changed("media-type") {
+				return errors.New("--media-type cannot be used with --recursive")
+			}
 			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
 			return nil
 		},
@@ -86,84 +127,355 @@ Example - Verify the signature on a blob artifact using a policy statement name:
 		},
 	}
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
-	command.Flags().StringVarP(&opts.signaturePath, "signature", "s", "", "filepath of the signature to be verified")
+	opts.SecureFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().StringVarP(&opts.signaturePath, "signature", "s", "", "filepath of the signature or the signature bundle to be verified. If not set, the signature files \"{blob file name}.{jws|cose}.sig\" and the signature bundles \"{blob file name}.{jws|cose}.bundle.json\" are looked up in the signature directory and all of them are verified")
+	command.Flags().StringVar(&opts.signatureDirectory, "signature-directory", "", "directory to look up signature files when --signature is not set (default same directory as the blob)")
 	command.Flags().StringArrayVar(&opts.pluginConfig, "plugin-config", nil, "{key}={value} pairs that are passed as it is to a plugin, if the verification is associated with a verification plugin, refer plugin documentation to set appropriate values")
 	command.Flags().StringVar(&opts.blobMediaType, "media-type", "", "media type of the blob to verify")
 	command.Flags().StringVar(&opts.policyStatementName, "policy-name", "", "policy name to verify against. If not provided, the global policy is used if exists")
+	command.Flags().BoolVar(&opts.recursive, "recursive", false, "verify the signature of the manifest \"{directory name}.manifest.json\" next to the signature file, then check every file in the directory against the manifest")
 	flag.SetPflagUserMetadata(command.Flags(), &opts.userMetadata, flag.PflagUserMetadataVerifyUsage)
-	command.MarkFlagRequired("signature")
+	command.Flags().StringVar(&opts.fromReference, "from", "", "reference <registry>/<repository>{:<tag>|@<digest>} of a blob artifact pushed by \"notation blob sign --push\". The blob and the signatures attached to it are pulled from the registry, and the verification succeeds if any of the signatures is verified")
+	command.MarkFlagsMutuallyExclusive("signature", "signature-directory")
+	command.MarkFlagsMutuallyExclusive("from", "signature")
+	command.MarkFlagsMutuallyExclusive("from", "signature-directory")
+	command.MarkFlagsMutuallyExclusive("from", "recursive")
 	return command
 }
 
 func runVerify(command *cobra.Command, cmdOpts *blobVerifyOpts) error {
 	// set log level
 	ctx := cmdOpts.LoggingFlagOpts.InitializeLogger(command.Context())
+	logger := log.GetLogger(ctx)
 
 	// initialize
 	displayHandler := display.NewBlobVerifyHandler(cmdOpts.printer)
-	blobFile, err := os.Open(cmdOpts.blobPath)
+	if cmdOpts.fromReference != "" {
+		return runVerifyFromReference(ctx, cmdOpts, displayHandler)
+	}
+	signatureDirectory := cmdOpts.signatureDirectory
+	if cmdOpts.signaturePath != "" {
+		signatureDirectory = filepath.Dir(cmdOpts.signaturePath)
+	} else if signatureDirectory == "" {
+		// by default, signature files are in the same directory as the blob
+		signatureDirectory = filepath.Dir(filepath.Clean(cmdOpts.blobPath))
+	}
+	blobPath := cmdOpts.blobPath
+	contentMediaType := cmdOpts.blobMediaType
+	var manifestJSON []byte
+	if cmdOpts.recursive {
+		// the signed blob is the manifest of the directory. It is read into
+		// memory so that the verified content is the one checked against
+		// the directory.
+		blobPath = blobManifestPath(signatureDirectory, cmdOpts.blobPath)
+		contentMediaType = mediaTypeBlobManifest
+		content, err := os.ReadFile(blobPath)
+		if err != nil {
+			return err
+		}
+		manifestJSON = content
+	}
+	openBlob := func() (io.ReadCloser, error) {
+		if cmdOpts.recursive {
+			return io.NopCloser(bytes.NewReader(manifestJSON)), nil
+		}
+		return os.Open(blobPath)
+	}
+
+	// signature files
+	var signaturePaths, discoveredSignaturePaths []string
+	if cmdOpts.signaturePath != "" {
+		signaturePaths = []string{cmdOpts.signaturePath}
+	} else {
+		discovered, err := discoverSignatures(signatureDirectory, blobPath)
+		if err != nil {
+			return err
+		}
+		logger.Infof("Found signature files %v", discovered)
+		signaturePaths = discovered
+		discoveredSignaturePaths = discovered
+	}
+
+	signatureFiles := make([]*signatureFile, 0, len(signaturePaths))
+	for _, signaturePath := range signaturePaths {
+		sigFile, err := readSignatureFile(signaturePath)
+		if err != nil {
+			return err
+		}
+		signatureFiles = append(signatureFiles, sigFile)
+	}
+
+	// set up verification plugin config
+	pluginConfigs, err := flag.ParseFlagMap(cmdOpts.pluginConfig, flag.PflagPluginConfig.Name)
 	if err != nil {
 		return err
 	}
-	defer blobFile.Close()
 
-	signatureBytes, err := os.ReadFile(cmdOpts.signaturePath)
+	// set up user metadata
+	userMetadata, err := flag.ParseFlagMap(cmdOpts.userMetadata, flag.PflagUserMetadata.Name)
 	if err != nil {
 		return err
 	}
-	blobVerifier, err := verify.GetBlobVerifier(ctx)
+
+	// core process
+	// every signature file found must be successfully verified
+	var outcomes []*notation.VerificationOutcome
+	for _, sigFile := range signatureFiles {
+		// the CRLs bundled with a signature file only apply to the
+		// verification of its own signature
+		fallbackCRLs := make(map[string]*corecrl.Bundle)
+		for _, bundledCRL := range sigFile.bundledCRLs {
+			fallbackCRLs[bundledCRL.URL] = &corecrl.Bundle{BaseCRL: bundledCRL.CRL}
+		}
+		blobVerifier, err := verify.GetBlobVerifierWithFallbackCRLs(ctx, fallbackCRLs)
+		if err != nil {
+			return err
+		}
+		verifyBlobOpts := notation.VerifyBlobOptions{
+			BlobVerifierVerifyOptions: notation.BlobVerifierVerifyOptions{
+				SignatureMediaType: sigFile.mediaType,
+				PluginConfig:       pluginConfigs,
+				UserMetadata:       userMetadata,
+				TrustPolicyName:    cmdOpts.policyStatementName,
+			},
+			ContentMediaType: contentMediaType,
+		}
+		blobReader, err := openBlob()
+		if err != nil {
+			return err
+		}
+		_, outcome, err := notation.VerifyBlob(ctx, blobVerifier, blobReader, sigFile.envelope, verifyBlobOpts)
+		blobReader.Close()
+		err = verify.ComposeBlobVerificationFailurePrintout([]*notation.VerificationOutcome{outcome}, blobPath, err)
+		if err != nil {
+			if len(discoveredSignaturePaths) > 0 {
+				return fmt.Errorf("%s: %w", sigFile.path, err)
+			}
+			return err
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	if cmdOpts.recursive {
+		fileCount, err := verifyBlobManifest(manifestJSON, cmdOpts.blobPath, append([]string{blobPath}, signaturePaths...)...)
+		if err != nil {
+			return err
+		}
+		displayHandler.OnVerifySucceeded(outcomes, cmdOpts.blobPath, discoveredSignaturePaths)
+		if err := displayHandler.Render(); err != nil {
+			return err
+		}
+		return cmdOpts.printer.Printf("Verified %d files against manifest %s\n", fileCount, blobPath)
+	}
+	displayHandler.OnVerifySucceeded(outcomes, cmdOpts.blobPath, discoveredSignaturePaths)
+	return displayHandler.Render()
+}
+
+// runVerifyFromReference pulls the blob artifact cmdOpts.fromReference and
+// the signatures attached to it, and verifies the blob against the
+// signatures. The verification succeeds if any of the signatures is verified.
+func runVerifyFromReference(ctx context.Context, cmdOpts *blobVerifyOpts, displayHandler metadata.BlobVerifyHandler) error {
+	logger := log.GetLogger(ctx)
+
+	repo, err := newBlobRepository(ctx, &cmdOpts.SecureFlagOpts, cmdOpts.fromReference)
 	if err != nil {
 		return err
 	}
+	if repo.Reference.Reference == "" {
+		return fmt.Errorf("%q: invalid reference: no tag or digest. Expecting <registry>/<repository>:<tag> or <registry>/<repository>@<digest>", cmdOpts.fromReference)
+	}
+	artifact, err := resolveBlobArtifact(ctx, repo, repo.Reference.Reference)
+	if err != nil {
+		return fmt.Errorf("failed to resolve blob artifact %s: %w", cmdOpts.fromReference, err)
+	}
+	artifactRef := fmt.Sprintf("%s/%s@%s", repo.Reference.Registry, repo.Reference.Repository, artifact.Manifest.Digest)
+	if _, err := repo.Reference.Digest(); err != nil {
+		cmdOpts.printer.PrintErrorf("Warning: Always verify the artifact using digest(@sha256:...) rather than a tag(:%s) because resolved digest may not point to the same signed artifact, as tags are mutable.\n", repo.Reference.Reference)
+	}
+	logger.Infof("Resolved blob artifact %s with blob %s", artifactRef, artifact.Blob.Digest)
 
-	// set up verification plugin config
+	signatures, err := fetchBlobSignatures(ctx, artifact)
+	if err != nil {
+		return err
+	}
+	if len(signatures) == 0 {
+		return fmt.Errorf("no signature is associated with %q, make sure the blob was signed successfully", artifactRef)
+	}
+	blobVerifier, err := verify.GetBlobVerifier(ctx)
+	if err != nil {
+		return err
+	}
 	pluginConfigs, err := flag.ParseFlagMap(cmdOpts.pluginConfig, flag.PflagPluginConfig.Name)
 	if err != nil {
 		return err
 	}
-
-	// set up user metadata
 	userMetadata, err := flag.ParseFlagMap(cmdOpts.userMetadata, flag.PflagUserMetadata.Name)
 	if err != nil {
 		return err
 	}
-	signatureMediaType, err := parseSignatureMediaType(cmdOpts.signaturePath)
+
+	// the blob is fetched once and verified against each signature
+	blobPath, err := fetchBlob(ctx, artifact)
 	if err != nil {
 		return err
 	}
-	verifyBlobOpts := notation.VerifyBlobOptions{
-		BlobVerifierVerifyOptions: notation.BlobVerifierVerifyOptions{
-			SignatureMediaType: signatureMediaType,
-			PluginConfig:       pluginConfigs,
-			UserMetadata:       userMetadata,
-			TrustPolicyName:    cmdOpts.policyStatementName,
-		},
-		ContentMediaType: cmdOpts.blobMediaType,
+	defer os.Remove(blobPath)
+
+	// core process
+	// the first verified signature wins
+	var lastErr error
+	for _, sig := range signatures {
+		logger.Infof("Verifying signature %s", sig.Manifest.Digest)
+		verifyBlobOpts := notation.VerifyBlobOptions{
+			BlobVerifierVerifyOptions: notation.BlobVerifierVerifyOptions{
+				SignatureMediaType: sig.MediaType,
+				PluginConfig:       pluginConfigs,
+				UserMetadata:       userMetadata,
+				TrustPolicyName:    cmdOpts.policyStatementName,
+			},
+			ContentMediaType: cmdOpts.blobMediaType,
+		}
+		blobReader, err := os.Open(blobPath)
+		if err != nil {
+			return err
+		}
+		_, outcome, err := notation.VerifyBlob(ctx, blobVerifier, blobReader, sig.Envelope, verifyBlobOpts)
+		blobReader.Close()
+		if err = verify.ComposeBlobVerificationFailurePrintout([]*notation.VerificationOutcome{outcome}, artifactRef, err); err != nil {
+			logger.Infof("Failed to verify signature %s: %v", sig.Manifest.Digest, err)
+			lastErr = fmt.Errorf("signature %s: %w", sig.Manifest.Digest, err)
+			continue
+		}
+		displayHandler.OnVerifySucceeded([]*notation.VerificationOutcome{outcome}, artifactRef, nil)
+		return displayHandler.Render()
+	}
+	return fmt.Errorf("signature verification failed for all the signatures associated with %s: %w", artifactRef, lastErr)
+}
+
+// signatureFile is a signature envelope read from a signature file or from
+// a signature bundle.
+type signatureFile struct {
+	path      string
+	mediaType string
+	envelope  []byte
+
+	// bundledCRLs is the revocation data captured in the signature bundle.
+	bundledCRLs []metadata.BundledCRL
+}
+
+// readSignatureFile reads the signature envelope from the signature file or
+// the signature bundle at signaturePath.
+func readSignatureFile(signaturePath string) (*signatureFile, error) {
+	content, err := os.ReadFile(signaturePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature file: %w", err)
+	}
+	if isSignatureBundle(content) {
+		bundle, err := parseSignatureBundle(content)
+		if err != nil {
+			return nil, err
+		}
+		bundledCRLs, err := bundle.CRLs()
+		if err != nil {
+			return nil, err
+		}
+		return &signatureFile{
+			path:        signaturePath,
+			mediaType:   bundle.Signature.MediaType,
+			envelope:    bundle.Signature.Envelope,
+			bundledCRLs: bundledCRLs,
+		}, nil
 	}
-	_, outcome, err := notation.VerifyBlob(ctx, blobVerifier, blobFile, signatureBytes, verifyBlobOpts)
-	outcomes := []*notation.VerificationOutcome{outcome}
-	err = verify.ComposeBlobVerificationFailurePrintout(outcomes, cmdOpts.blobPath, err)
+	signatureMediaType, err := parseSignatureMediaType(signaturePath, content)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	displayHandler.OnVerifySucceeded(outcomes, cmdOpts.blobPath)
-	return displayHandler.Render()
+	return &signatureFile{
+		path:      signaturePath,
+		mediaType: signatureMediaType,
+		envelope:  content,
+	}, nil
+}
+
+// discoverSignatures returns the signature files and signature bundles of
+// the blob in signatureDirectory that follow the naming convention of
+// `notation blob sign`, for all supported signature formats.
+func discoverSignatures(signatureDirectory, blobPath string) ([]string, error) {
+	var candidates []string
+	for _, format := range []string{envelope.JWS, envelope.COSE} {
+		candidates = append(candidates, signatureFilepath(signatureDirectory, blobPath, format))
+	}
+	for _, format := range []string{envelope.JWS, envelope.COSE} {
+		candidates = append(candidates, bundleFilepath(signatureDirectory, blobPath, format))
+	}
+	var signaturePaths []string
+	for _, signaturePath := range candidates {
+		if _, err := os.Stat(signaturePath); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		signaturePaths = append(signaturePaths, signaturePath)
+	}
+	if len(signaturePaths) == 0 {
+		return nil, fmt.Errorf("no signature file found for %s in directory %s. Use --signature to specify the signature file", blobPath, signatureDirectory)
+	}
+	return signaturePaths, nil
+}
+
+// verifyBlobManifest checks every file in the directory root against
+// manifestJSON, whose signature has been verified by the caller.
+// It returns the number of files in the manifest.
+func verifyBlobManifest(manifestJSON []byte, root string, exclude ...string) (int, error) {
+	manifest, err := parseBlobManifest(manifestJSON)
+	if err != nil {
+		return 0, err
+	}
+	diff, err := manifest.Compare(root, exclude...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check directory %s against the manifest: %w", root, err)
+	}
+	if !diff.IsEmpty() {
+		return 0, fmt.Errorf("signature verification succeeded but the content of %s does not match the signed manifest:\n%s", root, strings.TrimSuffix(diff.String(), "\n"))
+	}
+	return len(manifest.Files), nil
 }
 
 // parseSignatureMediaType returns the media type of the signature file.
 // `application/jose+json` and `application/cose` are supported.
-func parseSignatureMediaType(signaturePath string) (string, error) {
+//
+// The signature format is taken from the file name if it follows the
+// convention `{blob file name}.{jws|cose}.sig`. Otherwise, it is detected
+// from the content of the signature.
+func parseSignatureMediaType(signaturePath string, signatureBytes []byte) (string, error) {
 	signatureFileName := filepath.Base(signaturePath)
-	if strings.ToLower(filepath.Ext(signatureFileName)) != ".sig" {
-		return "", fmt.Errorf("invalid signature filename %s. The file extension must be .sig", signatureFileName)
+	detectedMediaType, detectErr := envelope.DetectMediaType(signatureBytes)
+	mediaType, ok := signatureMediaTypeFromFilename(signatureFileName)
+	if !ok {
+		if detectErr != nil {
+			return "", fmt.Errorf("failed to determine the format of signature file %s: %w", signatureFileName, detectErr)
+		}
+		return detectedMediaType, nil
+	}
+	if detectErr == nil && detectedMediaType != mediaType {
+		return "", fmt.Errorf("signature file %s is named as a signature of media type %s, but its content is a signature of media type %s", signatureFileName, mediaType, detectedMediaType)
 	}
+	return mediaType, nil
+}
+
+// signatureMediaTypeFromFilename returns the media type of the signature
+// format carried by the file name, if any.
+func signatureMediaTypeFromFilename(signatureFileName string) (string, bool) {
 	sigFilenameArr := strings.Split(signatureFileName, ".")
 
-	// a valid signature file name has at least 3 parts.
+	// a signature file name carrying the format has at least 3 parts.
 	// for example, `myFile.jws.sig`
-	if len(sigFilenameArr) < 3 {
-		return "", fmt.Errorf("invalid signature filename %s. A valid signature file name must contain signature format and .sig file extension", signatureFileName)
+	if len(sigFilenameArr) < 3 || strings.ToLower(sigFilenameArr[len(sigFilenameArr)-1]) != "sig" {
+		return "", false
+	}
+	mediaType, err := envelope.GetEnvelopeMediaType(strings.ToLower(sigFilenameArr[len(sigFilenameArr)-2]))
+	if err != nil {
+		return "", false
 	}
-	sigFormat := sigFilenameArr[len(sigFilenameArr)-2]
-	return envelope.GetEnvelopeMediaType(strings.ToLower(sigFormat))
+	return mediaType, true
 }
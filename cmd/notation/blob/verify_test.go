@@ -14,7 +14,9 @@
 package blob
 
 import (
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -71,3 +73,225 @@ func TestVerifyCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestVerifyCommand_Recursive(t *testing.T) {
+	opts := &blobVerifyOpts{}
+	command := verifyCommand(opts)
+	expected := &blobVerifyOpts{
+		blobPath:      "directory_path",
+		signaturePath: "sig_path",
+		recursive:     true,
+	}
+	if err := command.ParseFlags([]string{
+		expected.blobPath,
+		"--signature", expected.signaturePath,
+		"--recursive"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := command.Args(command, command.Flags().Args()); err != nil {
+		t.Fatalf("Parse args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect blob verify opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestVerifyCommand_RecursiveSignatureDirectory(t *testing.T) {
+	opts := &blobVerifyOpts{}
+	command := verifyCommand(opts)
+	expected := &blobVerifyOpts{
+		blobPath:           "directory_path",
+		signatureDirectory: "sig_dir",
+		recursive:          true,
+	}
+	if err := command.ParseFlags([]string{
+		expected.blobPath,
+		"--signature-directory", expected.signatureDirectory,
+		"--recursive"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := command.ValidateFlagGroups(); err != nil {
+		t.Fatalf("ValidateFlagGroups failed: %v", err)
+	}
+	if err := command.Args(command, command.Flags().Args()); err != nil {
+		t.Fatalf("Parse args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect blob verify opts: %v, got: %v", expected, opts)
+	}
+
+	t.Run("with signature", func(t *testing.T) {
+		command := verifyCommand(nil)
+		if err := command.ParseFlags([]string{"directory_path", "--signature", "sig_path", "--recursive"}); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		if err := command.ValidateFlagGroups(); err != nil {
+			t.Fatalf("ValidateFlagGroups failed: %v", err)
+		}
+	})
+}
+
+func TestVerifyCommand_SignatureDirectory(t *testing.T) {
+	opts := &blobVerifyOpts{}
+	command := verifyCommand(opts)
+	expected := &blobVerifyOpts{
+		blobPath:           "blob_path",
+		signatureDirectory: "sig_dir",
+	}
+	if err := command.ParseFlags([]string{
+		expected.blobPath,
+		"--signature-directory", expected.signatureDirectory}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := command.Args(command, command.Flags().Args()); err != nil {
+		t.Fatalf("Parse args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect blob verify opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestVerifyCommand_From(t *testing.T) {
+	opts := &blobVerifyOpts{}
+	command := verifyCommand(opts)
+	expected := &blobVerifyOpts{
+		fromReference: "localhost:5000/test@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+	}
+	if err := command.ParseFlags([]string{
+		"--from", expected.fromReference}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := command.Args(command, command.Flags().Args()); err != nil {
+		t.Fatalf("Parse args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect blob verify opts: %v, got: %v", expected, opts)
+	}
+
+	t.Run("with blob path", func(t *testing.T) {
+		command := verifyCommand(nil)
+		if err := command.ParseFlags([]string{"blob_path", "--from", expected.fromReference}); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		if err := command.Args(command, command.Flags().Args()); err == nil {
+			t.Fatal("Parse Args expected error, but ok")
+		}
+	})
+
+	t.Run("with signature", func(t *testing.T) {
+		command := verifyCommand(nil)
+		if err := command.ParseFlags([]string{"--from", expected.fromReference, "--signature", "sig_path"}); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		if err := command.ValidateFlagGroups(); err == nil {
+			t.Fatal("ValidateFlagGroups expected error, but ok")
+		}
+	})
+
+	t.Run("with recursive", func(t *testing.T) {
+		command := verifyCommand(nil)
+		if err := command.ParseFlags([]string{"--from", expected.fromReference, "--recursive"}); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		if err := command.ValidateFlagGroups(); err == nil {
+			t.Fatal("ValidateFlagGroups expected error, but ok")
+		}
+	})
+}
+
+func TestDiscoverSignatures(t *testing.T) {
+	dir := t.TempDir()
+	blobPath := filepath.Join(dir, "blob")
+	writeTestFiles(t, dir, map[string]string{
+		"blob":          "content",
+		"blob.jws.sig":  "jws",
+		"blob.cose.sig": "cose",
+	})
+
+	signaturePaths, err := discoverSignatures(dir, blobPath)
+	if err != nil {
+		t.Fatalf("discoverSignatures() error = %v", err)
+	}
+	expected := []string{
+		filepath.Join(dir, "blob.jws.sig"),
+		filepath.Join(dir, "blob.cose.sig"),
+	}
+	if !reflect.DeepEqual(expected, signaturePaths) {
+		t.Fatalf("expected signature paths %v, got %v", expected, signaturePaths)
+	}
+
+	t.Run("no signature found", func(t *testing.T) {
+		expectedErrMsg := "no signature file found for " + blobPath
+		_, err := discoverSignatures(t.TempDir(), blobPath)
+		if err == nil || !strings.HasPrefix(err.Error(), expectedErrMsg) {
+			t.Fatalf("expected error starting with %q, but got %v", expectedErrMsg, err)
+		}
+	})
+}
+
+func TestParseSignatureMediaType(t *testing.T) {
+	jwsBytes := []byte(`{"payload":"eyJ9","protected":"eyJ9","header":{},"signature":"c2ln"}`)
+	coseBytes := []byte{0xd2, 0x84, 0x40, 0xa0, 0x40, 0x40}
+	tests := []struct {
+		name           string
+		signaturePath  string
+		signatureBytes []byte
+		want           string
+		wantErr        bool
+	}{
+		{
+			name:           "format from file name",
+			signaturePath:  "blob.jws.sig",
+			signatureBytes: []byte("not detectable"),
+			want:           "application/jose+json",
+		},
+		{
+			name:           "format from file name is case insensitive",
+			signaturePath:  filepath.Join("dir", "blob.COSE.SIG"),
+			signatureBytes: coseBytes,
+			want:           "application/cose",
+		},
+		{
+			name:           "format detected without format in file name",
+			signaturePath:  "blob.sig",
+			signatureBytes: coseBytes,
+			want:           "application/cose",
+		},
+		{
+			name:           "format detected with unknown format in file name",
+			signaturePath:  "blob.unknown.sig",
+			signatureBytes: jwsBytes,
+			want:           "application/jose+json",
+		},
+		{
+			name:           "format detected without .sig extension",
+			signaturePath:  "blob.jws.invalid",
+			signatureBytes: jwsBytes,
+			want:           "application/jose+json",
+		},
+		{
+			name:           "file name conflicts with content",
+			signaturePath:  "blob.cose.sig",
+			signatureBytes: jwsBytes,
+			wantErr:        true,
+		},
+		{
+			name:           "format cannot be determined",
+			signaturePath:  "blob.sig",
+			signatureBytes: []byte("not detectable"),
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSignatureMediaType(tt.signaturePath, tt.signatureBytes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSignatureMediaType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseSignatureMediaType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,17 +14,38 @@
 package cert
 
 import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
 	"github.com/spf13/cobra"
 )
 
+// maxSignatures is the maximum number of signatures examined by
+// `notation cert add --from-reference`.
+const maxSignatures = 100
+
 type certAddOpts struct {
-	storeType  string
-	namedStore string
-	path       []string
+	flag.LoggingFlagOpts
+	flag.SecureFlagOpts
+	storeType     string
+	namedStore    string
+	path          []string
+	reference     string
+	signaturePath string
+	split         bool
+	rootsOnly     bool
+	confirmed     bool
 }
 
 func certAddCommand(opts *certAddOpts) *cobra.Command {
@@ -32,9 +53,18 @@ func certAddCommand(opts *certAddOpts) *cobra.Command {
 		opts = &certAddOpts{}
 	}
 	command := &cobra.Command{
-		Use:   "add --type <type> --store <name> [flags] <cert_path>...",
+		Use:   "add --type <type> --store <name> [flags] (<cert_path>... | --from-reference <reference> | --from-signature <signature_path>)",
 		Short: "Add certificates to the trust store.",
 		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.rootsOnly && !opts.split {
+				return errors.New("--roots-only can only be used with --split")
+			}
+			if opts.reference != "" || opts.signaturePath != "" {
+				if len(args) != 0 {
+					return errors.New("cannot add certificate files when --from-reference or --from-signature is set. use --help flag for more details")
+				}
+				return nil
+			}
 			if len(args) == 0 {
 				return errors.New("missing certificate path")
 			}
@@ -51,15 +81,41 @@ Example - Add a certificate to the "signingAuthority" type of a named store "wab
 
 Example - Add a certificate to the "tsa" type of a named store "timestamp":
   notation cert add --type tsa --store timestamp wabbit-networks-timestamp.pem
+
+Example - Add the certificates of a PKCS #7 bundle "vendor.p7b" to the "ca" type of a named store "vendor" as a PEM file "vendor.pem":
+  notation cert add --type ca --store vendor vendor.p7b
+
+Example - Add the self-signed root certificates of a CA bundle to the "ca" type of a named store "acme-rockets", each as its own file:
+  notation cert add --type ca --store acme-rockets --split --roots-only ca-bundle.pem
+
+Example - Add the root certificate of the signatures of an artifact to the "ca" type of a named store "wabbit-networks":
+  notation cert add --type ca --store wabbit-networks --from-reference <registry>/<repository>@<digest>
+
+Example - Add the root certificate of the timestamp countersignature of a blob signature to the "tsa" type of a named store "timestamp", without prompt for confirmation:
+  notation cert add --type tsa --store timestamp --from-signature blob.jws.sig -y
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.reference != "" || opts.signaturePath != "" {
+				return addRootCerts(cmd.Context(), opts)
+			}
+			if opts.split {
+				return addSplitCerts(opts)
+			}
 			return addCerts(opts)
 		},
 	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.SecureFlagOpts.ApplyFlags(command.Flags())
 	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority, tsa")
 	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store")
+	command.Flags().StringVar(&opts.reference, "from-reference", "", "add the root certificates of the signatures of the artifact in the format of <registry>/<repository>:<tag> or <registry>/<repository>@<digest>")
+	command.Flags().StringVar(&opts.signaturePath, "from-signature", "", "add the root certificate of the signature file or the signature bundle of a signed blob")
+	command.Flags().BoolVar(&opts.split, "split", false, "add each certificate in the certificate files as its own file, named by its subject and SHA-256 fingerprint")
+	command.Flags().BoolVar(&opts.rootsOnly, "roots-only", false, "with --split, add only the self-signed root certificates")
+	command.Flags().BoolVarP(&opts.confirmed, "yes", "y", false, "do not prompt for confirmation")
 	command.MarkFlagRequired("type")
 	command.MarkFlagRequired("store")
+	command.MarkFlagsMutuallyExclusive("from-reference", "from-signature", "split")
 	return command
 }
 
@@ -106,3 +162,167 @@ func addCerts(opts *certAddOpts) error {
 
 	return nil
 }
+
+// addSplitCerts adds each certificate in the certificate files, or only the
+// self-signed root certificates if opts.rootsOnly is set, to the trust store
+// as its own file.
+func addSplitCerts(opts *certAddOpts) error {
+	storeType := opts.storeType
+	if !truststore.IsValidStoreType(storeType) {
+		return fmt.Errorf("unsupported store type: %s", storeType)
+	}
+	namedStore := opts.namedStore
+	if !truststore.IsValidFileName(namedStore) {
+		return errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
+	}
+	var success []string
+	var failure []string
+	var errorSlice []error
+	var skipped int
+	for _, p := range opts.path {
+		certs, _, err := truststore.ReadCertificateFile(p)
+		if err == nil && len(certs) == 0 {
+			err = errors.New("no valid certificate found in the file")
+		}
+		if err != nil {
+			failure = append(failure, p)
+			errorSlice = append(errorSlice, err)
+			continue
+		}
+		var added bool
+		for _, cert := range certs {
+			if opts.rootsOnly && !certchain.IsSelfSigned(cert) {
+				skipped++
+				continue
+			}
+			added = true
+			fileName, err := truststore.AddSplitCert(cert, storeType, namedStore)
+			if err != nil {
+				failure = append(failure, fmt.Sprintf("%s in %s", cert.Subject, p))
+				errorSlice = append(errorSlice, err)
+				continue
+			}
+			success = append(success, fmt.Sprintf("%s from %s", fileName, p))
+		}
+		if !added {
+			failure = append(failure, p)
+			errorSlice = append(errorSlice, errors.New("no self-signed root certificate found in the file"))
+		}
+	}
+
+	//write out
+	if len(success) != 0 {
+		fmt.Printf("Successfully added following certificates to named store %s of type %s:\n", namedStore, storeType)
+		for _, s := range success {
+			fmt.Println(s)
+		}
+	}
+	if skipped != 0 {
+		fmt.Printf("Skipped %d certificate(s) that are not self-signed root certificates\n", skipped)
+	}
+	if len(failure) != 0 {
+		errStr := fmt.Sprintf("Failed to add following certificates to named store %s of type %s:\n", namedStore, storeType)
+		for ind := range failure {
+			errStr = errStr + fmt.Sprintf("%s, with error %q\n", failure[ind], errorSlice[ind])
+		}
+		return errors.New(errStr)
+	}
+	return nil
+}
+
+// addRootCerts adds the root certificates of the signing certificate chains,
+// or of the timestamp countersignatures for the "tsa" store type, of the
+// signatures of an artifact or a blob to the trust store. Each root
+// certificate is shown for confirmation before it is added.
+func addRootCerts(ctx context.Context, opts *certAddOpts) error {
+	storeType := opts.storeType
+	if !truststore.IsValidStoreType(storeType) {
+		return fmt.Errorf("unsupported store type: %s", storeType)
+	}
+	namedStore := opts.namedStore
+	if !truststore.IsValidFileName(namedStore) {
+		return errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
+	}
+
+	// collect signatures
+	var signatures []*certchain.Signature
+	if opts.reference != "" {
+		ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+		var err error
+		signatures, _, err = certchain.FetchReference(ctx, &opts.SecureFlagOpts, opts.reference, maxSignatures)
+		if err != nil {
+			return err
+		}
+	} else {
+		sig, err := certchain.ReadFile(opts.signaturePath)
+		if err != nil {
+			return err
+		}
+		signatures = []*certchain.Signature{sig}
+	}
+
+	// collect root certificates
+	var roots []*x509.Certificate
+	for _, sig := range signatures {
+		var sigRoots []*x509.Certificate
+		if storeType == "tsa" {
+			sigRoots = sig.TimestampRoots()
+			if len(sigRoots) == 0 {
+				fmt.Fprintf(os.Stderr, "Warning: signature %s has no root certificate of a timestamp countersignature\n", sig.Source)
+				continue
+			}
+		} else {
+			root, err := sig.Root()
+			if err != nil {
+				return err
+			}
+			if scheme := schemeOfStoreType(storeType); sig.SigningScheme != scheme {
+				fmt.Fprintf(os.Stderr, "Warning: signature %s is of signing scheme %s, but the certificates of store type %s are used for signing scheme %s\n", sig.Source, sig.SigningScheme, storeType, scheme)
+			}
+			sigRoots = []*x509.Certificate{root}
+		}
+		for _, root := range sigRoots {
+			if !slices.ContainsFunc(roots, root.Equal) {
+				roots = append(roots, root)
+			}
+		}
+	}
+	if len(roots) == 0 {
+		return errors.New("no root certificate found in the signatures")
+	}
+
+	// confirm and add
+	tempDir, err := os.MkdirTemp("", "notation-cert-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+	for _, root := range roots {
+		fingerprint := certchain.Fingerprint(root)
+		fmt.Printf("Root certificate:\n  subject: %s\n  issuer: %s\n  expiry: %s\n  SHA-256 fingerprint: %s\n", root.Subject, root.Issuer, root.NotAfter.Format("Mon Jan _2 15:04:05 2006"), fingerprint)
+		confirmed, err := display.AskForConfirmation(os.Stdin, fmt.Sprintf("Do you want to add the certificate to named store %s of type %s?", namedStore, storeType), opts.confirmed)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			continue
+		}
+		certPath := filepath.Join(tempDir, fingerprint+".crt")
+		if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.Raw}), 0600); err != nil {
+			return err
+		}
+		if err := truststore.AddCert(certPath, storeType, namedStore, true); err != nil {
+			return fmt.Errorf("failed to add certificate %s: %w", fingerprint, err)
+		}
+	}
+	return nil
+}
+
+// schemeOfStoreType returns the signing scheme verified with the trust store
+// type storeType.
+func schemeOfStoreType(storeType string) signature.SigningScheme {
+	if storeType == "signingAuthority" {
+		return signature.SigningSchemeX509SigningAuthority
+	}
+	return signature.SigningSchemeX509
+}
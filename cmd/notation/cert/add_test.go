@@ -49,3 +49,57 @@ func TestCertAddCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestCertAddCommand_FromSignature(t *testing.T) {
+	opts := &certAddOpts{}
+	cmd := certAddCommand(opts)
+	if err := cmd.ParseFlags([]string{
+		"--from-signature", "blob.jws.sig",
+		"-t", "ca",
+		"-s", "test"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if opts.signaturePath != "blob.jws.sig" || len(opts.path) != 0 {
+		t.Fatalf("unexpected cert add opts: %+v", opts)
+	}
+
+	// certificate paths cannot be used with --from-signature
+	if err := cmd.Args(cmd, []string{"path"}); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestCertAddCommand_Split(t *testing.T) {
+	opts := &certAddOpts{}
+	cmd := certAddCommand(opts)
+	expected := &certAddOpts{
+		storeType:  "ca",
+		namedStore: "test",
+		path:       []string{"bundle.p7b"},
+		split:      true,
+		rootsOnly:  true,
+	}
+	if err := cmd.ParseFlags([]string{
+		"bundle.p7b",
+		"-t", "ca",
+		"-s", "test",
+		"--split",
+		"--roots-only"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert add opts: %v, got: %v", expected, opts)
+	}
+
+	// --roots-only cannot be used without --split
+	opts.split = false
+	if err := cmd.Args(cmd, []string{"bundle.p7b"}); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
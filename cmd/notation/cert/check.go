@@ -0,0 +1,231 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+	notationgoTruststore "github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/v2/internal/layered"
+	"github.com/spf13/cobra"
+)
+
+type certCheckOpts struct {
+	flag.LoggingFlagOpts
+	outputFormat    flag.OutputFormatFlagOpts
+	printer         *output.Printer
+	expiryThreshold time.Duration
+}
+
+func certCheckCommand(opts *certCheckOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certCheckOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "check [flags]",
+		Short: "Check the health of the certificates in the trust store.",
+		Long: `Check the health of the certificates in the trust store
+
+All the trust stores are checked for:
+  - files that are not valid certificate files, including files where some of the certificates cannot be parsed
+  - certificates that are expired or expire within the expiry threshold
+  - leaf certificates in trust stores of type "ca"
+  - trust stores referenced by no statement of the OCI or the blob trust policy configuration, including the system level statements
+
+The command fails if any issue is found, so that it can be used for monitoring.
+
+Example - Check the trust stores:
+  notation cert check
+
+Example - Check the trust stores and output as JSON:
+  notation cert check -o json
+
+Example - Report the certificates expiring in 90 days:
+  notation cert check --expiry-threshold 2160h
+`,
+		Args: cobra.ExactArgs(0),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.outputFormat.Validate(cmd); err != nil {
+				return err
+			}
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkCerts(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.outputFormat.ApplyFlags(command.Flags(), output.FormatText, output.FormatJSON)
+	command.Flags().DurationVar(&opts.expiryThreshold, "expiry-threshold", policy.DefaultExpiryThreshold, "period before the expiry of a certificate in which it is reported as expiring")
+	return command
+}
+
+func checkCerts(ctx context.Context, opts *certCheckOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	// initialize display handler
+	displayHandler, err := display.NewCertCheckHandler(opts.printer, opts.outputFormat.CurrentFormat)
+	if err != nil {
+		return err
+	}
+
+	stores, err := listTrustStoreFiles()
+	if err != nil {
+		return err
+	}
+	checkOpts := policy.CheckOptions{
+		ExpiryThreshold: opts.expiryThreshold,
+	}
+	statements, err := loadStatements()
+	if err != nil {
+		// the trust policy configurations are checked by `notation policy
+		// lint`, the trust stores can still be checked
+		opts.printer.PrintErrorf("Warning: skipped checking if the trust stores are referenced by trust policy statements: %v\n", err)
+		checkOpts.SkipReferences = true
+	}
+
+	findings := policy.CheckTrustStores(stores, statements, checkOpts)
+	displayHandler.OnTrustStoresChecked(len(stores), findings)
+	if err := displayHandler.Render(); err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		errorCount, warningCount := policy.CountFindings(findings)
+		return fmt.Errorf("found %d error(s) and %d warning(s) in the trust stores", errorCount, warningCount)
+	}
+	return nil
+}
+
+// listTrustStoreFiles lists the files of all the trust stores, whether they
+// are valid certificate files or not.
+func listTrustStoreFiles() ([]policy.TrustStoreFiles, error) {
+	var stores []policy.TrustStoreFiles
+	for _, t := range notationgoTruststore.Types {
+		typePath, err := dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509", string(t))
+		if err := truststore.CheckNonErrNotExistError(err); err != nil {
+			return nil, err
+		}
+		paths, err := truststore.ListFiles(typePath, 1)
+		if err := truststore.CheckNonErrNotExistError(err); err != nil {
+			return nil, fmt.Errorf("failed to list trust stores of type %s, with error: %w", t, err)
+		}
+		for _, path := range paths {
+			storePath := filepath.Dir(path)
+			if storePath == typePath {
+				// not in a named store
+				continue
+			}
+			name := string(t) + ":" + filepath.Base(storePath)
+			if len(stores) == 0 || stores[len(stores)-1].Name != name {
+				stores = append(stores, policy.TrustStoreFiles{Name: name})
+			}
+			stores[len(stores)-1].Paths = append(stores[len(stores)-1].Paths, path)
+		}
+	}
+	return stores, nil
+}
+
+// loadStatements loads the statements of the effective OCI and blob trust
+// policy configurations merged from the system level and the user level
+// configurations. A configuration that exists at neither level has no
+// statements.
+func loadStatements() ([]policy.Statement, error) {
+	var statements []policy.Statement
+	exists, err := configExists(dir.PathOCITrustPolicy, dir.PathTrustPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		systemDoc, err := layered.LoadSystemOCIDocument()
+		if err != nil {
+			return nil, err
+		}
+		exists = systemDoc != nil
+	}
+	if exists {
+		doc, _, err := layered.LoadOCIDocument()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OCI trust policy configuration: %w", err)
+		}
+		statements = append(statements, policy.OCIStatements(doc)...)
+	}
+	exists, err = configExists(dir.PathBlobTrustPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		systemDoc, err := layered.LoadSystemBlobDocument()
+		if err != nil {
+			return nil, err
+		}
+		exists = systemDoc != nil
+	}
+	if exists {
+		doc, _, err := layered.LoadBlobDocument()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load blob trust policy configuration: %w", err)
+		}
+		statements = append(statements, policy.BlobStatements(doc)...)
+	}
+	return statements, nil
+}
+
+// loadSystemStatements loads the statements of the system level OCI and blob
+// trust policy configurations, which cannot be changed by users.
+func loadSystemStatements() ([]policy.Statement, error) {
+	var statements []policy.Statement
+	ociDoc, err := layered.LoadSystemOCIDocument()
+	if err != nil {
+		return nil, err
+	}
+	if ociDoc != nil {
+		statements = append(statements, policy.OCIStatements(ociDoc)...)
+	}
+	blobDoc, err := layered.LoadSystemBlobDocument()
+	if err != nil {
+		return nil, err
+	}
+	if blobDoc != nil {
+		statements = append(statements, policy.BlobStatements(blobDoc)...)
+	}
+	return statements, nil
+}
+
+// configExists reports whether any of the configuration files exists.
+func configExists(paths ...string) (bool, error) {
+	for _, p := range paths {
+		path, err := dir.ConfigFS().SysPath(p)
+		if err != nil {
+			return false, err
+		}
+		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,136 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/notaryproject/notation/v2/internal/layered"
+	"github.com/spf13/pflag"
+)
+
+func TestCertCheckCommand(t *testing.T) {
+	opts := &certCheckOpts{}
+	cmd := certCheckCommand(opts)
+	format := flag.OutputFormatFlagOpts{}
+	format.ApplyFlags(&pflag.FlagSet{}, output.FormatText, output.FormatJSON)
+	format.CurrentFormat = output.FormatJSON
+	expected := &certCheckOpts{
+		outputFormat:    format,
+		expiryThreshold: 90 * 24 * time.Hour,
+	}
+	if err := cmd.ParseFlags([]string{
+		"--expiry-threshold", "2160h",
+		"-o", "json"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert check opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestListTrustStoreFiles(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+
+	for _, file := range []string{
+		"truststore/x509/ca/acme/root.crt",
+		"truststore/x509/ca/acme/junk.txt",
+		"truststore/x509/ca/wabbit/root.pem",
+		"truststore/x509/ca/stray.crt",
+		"truststore/x509/tsa/timestamp/tsa.crt",
+	} {
+		path := filepath.Join(dir.UserConfigDir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stores, err := listTrustStoreFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, store := range stores {
+		for _, path := range store.Paths {
+			got = append(got, store.Name+" "+filepath.Base(path))
+		}
+	}
+	expected := []string{
+		"ca:acme junk.txt",
+		"ca:acme root.crt",
+		"ca:wabbit root.pem",
+		"tsa:timestamp tsa.crt",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected trust store files %v, but got %v", expected, got)
+	}
+}
+
+func TestLoadStatements(t *testing.T) {
+	oldDir, oldSystemDir := dir.UserConfigDir, layered.SystemConfigDir
+	defer func() {
+		dir.UserConfigDir, layered.SystemConfigDir = oldDir, oldSystemDir
+	}()
+	dir.UserConfigDir = t.TempDir()
+	layered.SystemConfigDir = t.TempDir()
+
+	// no configuration
+	statements, err := loadStatements()
+	if err != nil {
+		t.Fatalf("loadStatements() error = %v", err)
+	}
+	if len(statements) != 0 {
+		t.Fatalf("expected no statements, but got %v", statements)
+	}
+
+	// user level OCI and system level blob trust policy configurations
+	if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathOCITrustPolicy), []byte(testOCIPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(layered.SystemConfigDir, dir.PathBlobTrustPolicy), []byte(testBlobPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	statements, err = loadStatements()
+	if err != nil {
+		t.Fatalf("loadStatements() error = %v", err)
+	}
+	var names []string
+	for _, statement := range statements {
+		names = append(names, statement.Name)
+	}
+	expected := []string{"oci", "blob", "other"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected statements %v, but got %v", expected, names)
+	}
+	if referring := policy.ReferringStatements(statements, "ca:other"); !reflect.DeepEqual(referring, []string{"other"}) {
+		t.Fatalf("expected ca:other referenced by the system statement, but got %v", referring)
+	}
+}
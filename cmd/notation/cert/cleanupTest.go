@@ -38,7 +38,7 @@ func certCleanupTestCommand(opts *certCleanupTestOpts) *cobra.Command {
 	}
 	command := &cobra.Command{
 		Use:   "cleanup-test [flags] <common_name>",
-		Short: `Clean up a test RSA key and its corresponding certificate that were generated using the "generate-test" command.`,
+		Short: `Clean up a test key and its corresponding certificate that were generated using the "generate-test" or "generate-test-pki" command.`,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
 				return errors.New("missing certificate common name")
@@ -49,7 +49,7 @@ func certCleanupTestCommand(opts *certCleanupTestOpts) *cobra.Command {
 			opts.name = args[0]
 			return nil
 		},
-		Long: `Clean up a test RSA key and its corresponding certificate that were generated using the "generate-test" command.
+		Long: `Clean up a test key and its corresponding certificate that were generated using the "generate-test" or "generate-test-pki" command.
 
 Example - Clean up a test key and corresponding certificate named "wabbit-networks.io":
   notation cert cleanup-test wabbit-networks.io
@@ -68,13 +68,24 @@ func cleanupTestCert(opts *certCleanupTestOpts) error {
 	certPath, _ := dir.ConfigFS().SysPath(relativeCertPath) // err is always nil
 	certFileName := filepath.Base(certPath)
 	keyPath, _ := dir.ConfigFS().SysPath(relativeKeyPath) // err is always nil
+
+	// a test PKI generated by the generate-test-pki command has a directory
+	// holding the other certificates and keys
+	pkiDir, _ := dir.ConfigFS().SysPath(dir.LocalKeysDir, name+"-pki") // err is always nil
+	_, err := os.Stat(pkiDir)
+	isPKI := err == nil
 	prompt := fmt.Sprintf(`The test key %s and its corresponding certificate will be cleaned up with the following changes:
 - Delete certificate %s.crt from trust store %s of type ca
 - Remove key %s from the key list
 - Delete key file: %s
 - Delete certificate file: %s
-
-Are you sure you want to continue?`, name, name, name, name, keyPath, certPath)
+`, name, name, name, name, keyPath, certPath)
+	if isPKI {
+		prompt += fmt.Sprintf(`- Delete certificate %s.crt from trust store %s of type tsa, if present
+- Delete test PKI directory: %s
+`, name, name, pkiDir)
+	}
+	prompt += "\nAre you sure you want to continue?"
 	confirmed, err := display.AskForConfirmation(os.Stdin, prompt, opts.confirmed)
 	if err != nil {
 		return err
@@ -127,6 +138,19 @@ Are you sure you want to continue?`, name, name, name, name, keyPath, certPath)
 	} else {
 		fmt.Printf("Successfully deleted certificate file: %s\n", certPath)
 	}
+
+	// 4. delete the timestamping trust store and the directory of a test PKI
+	// generated by the generate-test-pki command
+	if isPKI {
+		err = truststore.DeleteCert("tsa", name, certFileName, true)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete certificate %s from trust store %s of type tsa: %w", certFileName, name, err)
+		}
+		if err := os.RemoveAll(pkiDir); err != nil {
+			return fmt.Errorf("failed to delete test PKI directory %s: %w", pkiDir, err)
+		}
+		fmt.Printf("Successfully deleted test PKI directory: %s\n", pkiDir)
+	}
 	fmt.Println("Cleanup completed successfully")
 	return nil
 }
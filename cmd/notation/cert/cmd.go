@@ -29,7 +29,14 @@ func Cmd() *cobra.Command {
 		certListCommand(nil),
 		certShowCommand(nil),
 		certDeleteCommand(nil),
+		certCheckCommand(nil),
+		certRevocationCheckCommand(nil),
+		certSyncCommand(nil),
+		certCopyCommand(nil),
+		certMoveCommand(nil),
+		certStoreCommand(),
 		certGenerateTestCommand(nil),
+		certGenerateTestPKICommand(nil),
 		certCleanupTestCommand(nil),
 	)
 
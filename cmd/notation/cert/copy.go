@@ -0,0 +1,115 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
+	"github.com/spf13/cobra"
+)
+
+type certCopyOpts struct {
+	src       string
+	dst       string
+	fileNames []string
+	all       bool
+}
+
+func certCopyCommand(opts *certCopyOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certCopyOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "copy [flags] <src_type>:<src_store> <dst_type>:<dst_store> (--all | <cert_fileName>...)",
+		Short: "Copy certificates from a named trust store to another.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := parseTransferArgs("copy", args, opts.all); err != nil {
+				return err
+			}
+			opts.src, opts.dst, opts.fileNames = args[0], args[1], args[2:]
+			return nil
+		},
+		Long: `Copy certificates from a named trust store to another
+
+The trust stores are in the format "<store_type>:<store_name>", as referenced by trust policy statements. The destination trust store is created if it does not exist. Nothing is copied if any of the certificates is already in the destination trust store, or if any of the certificate file names is taken by a different certificate file.
+
+Example - Copy certificate "root.crt" from trust store "acme" of type "ca" to trust store "acme-prod" of type "ca":
+  notation cert copy ca:acme ca:acme-prod root.crt
+
+Example - Copy all certificates from trust store "acme" of type "ca" to trust store "acme" of type "signingAuthority":
+  notation cert copy --all ca:acme signingAuthority:acme
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return copyCerts(opts)
+		},
+	}
+	command.Flags().BoolVarP(&opts.all, "all", "a", false, "copy all certificates in the source trust store")
+	return command
+}
+
+func copyCerts(opts *certCopyOpts) error {
+	srcType, srcStore, err := parseTrustStoreName(opts.src)
+	if err != nil {
+		return err
+	}
+	dstType, dstStore, err := parseTrustStoreName(opts.dst)
+	if err != nil {
+		return err
+	}
+	copied, err := truststore.CopyCerts(srcType, srcStore, dstType, dstStore, opts.fileNames)
+	if err != nil {
+		return fmt.Errorf("failed to copy certificates: %w", err)
+	}
+	for _, name := range copied {
+		fmt.Printf("Successfully copied %s from trust store %s of type %s to trust store %s of type %s\n", name, srcStore, srcType, dstStore, dstType)
+	}
+	return nil
+}
+
+// parseTransferArgs validates the arguments of the copy and the move
+// commands.
+func parseTransferArgs(command string, args []string, all bool) error {
+	if len(args) < 2 {
+		return fmt.Errorf("%s requires the source and the destination trust stores in the format \"<store_type>:<store_name>\"", command)
+	}
+	if all {
+		if len(args) > 2 {
+			return fmt.Errorf("cannot %s certificate files by name when --all flag is set. use --help flag for more details", command)
+		}
+		return nil
+	}
+	if len(args) == 2 {
+		return fmt.Errorf("%s requires either the certificate file names, or --all flag to %s all certificates in the source trust store", command, command)
+	}
+	return nil
+}
+
+// parseTrustStoreName parses a trust store name in the format
+// "<store_type>:<store_name>".
+func parseTrustStoreName(name string) (string, string, error) {
+	storeType, namedStore, ok := strings.Cut(name, ":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid trust store %q, expecting \"<store_type>:<store_name>\"", name)
+	}
+	if !truststore.IsValidStoreType(storeType) {
+		return "", "", fmt.Errorf("invalid trust store %q: unsupported store type: %s", name, storeType)
+	}
+	if !truststore.IsValidFileName(namedStore) {
+		return "", "", errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
+	}
+	return storeType, namedStore, nil
+}
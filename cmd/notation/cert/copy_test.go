@@ -0,0 +1,89 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCertCopyCommand(t *testing.T) {
+	opts := &certCopyOpts{}
+	cmd := certCopyCommand(opts)
+	expected := &certCopyOpts{
+		src:       "ca:acme",
+		dst:       "ca:acme-prod",
+		fileNames: []string{"root.crt", "other.pem"},
+	}
+	if err := cmd.ParseFlags([]string{"ca:acme", "ca:acme-prod", "root.crt", "other.pem"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert copy opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertCopyCommand_All(t *testing.T) {
+	opts := &certCopyOpts{}
+	cmd := certCopyCommand(opts)
+	expected := &certCopyOpts{
+		src:       "ca:acme",
+		dst:       "signingAuthority:acme",
+		fileNames: []string{},
+		all:       true,
+	}
+	if err := cmd.ParseFlags([]string{"--all", "ca:acme", "signingAuthority:acme"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert copy opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertCopyCommand_InvalidArgs(t *testing.T) {
+	for _, args := range [][]string{
+		{"ca:acme"},
+		{"ca:acme", "ca:acme-prod"},
+		{"--all", "ca:acme", "ca:acme-prod", "root.crt"},
+	} {
+		cmd := certCopyCommand(nil)
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+			t.Fatalf("Parse Args of %v expected error, but ok", args)
+		}
+	}
+}
+
+func TestParseTrustStoreName(t *testing.T) {
+	storeType, namedStore, err := parseTrustStoreName("signingAuthority:acme")
+	if err != nil {
+		t.Fatalf("parseTrustStoreName() error = %v", err)
+	}
+	if storeType != "signingAuthority" || namedStore != "acme" {
+		t.Fatalf("unexpected trust store %s:%s", storeType, namedStore)
+	}
+	for _, name := range []string{"acme", "unknown:acme", "ca:", "ca:a/b"} {
+		if _, _, err := parseTrustStoreName(name); err == nil {
+			t.Fatalf("parseTrustStoreName(%q) expected error, but ok", name)
+		}
+	}
+}
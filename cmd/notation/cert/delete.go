@@ -22,11 +22,12 @@ import (
 )
 
 type certDeleteOpts struct {
-	storeType  string
-	namedStore string
-	cert       string
-	all        bool
-	confirmed  bool
+	storeType   string
+	namedStore  string
+	cert        string
+	fingerprint string
+	all         bool
+	confirmed   bool
 }
 
 func certDeleteCommand(opts *certDeleteOpts) *cobra.Command {
@@ -34,7 +35,7 @@ func certDeleteCommand(opts *certDeleteOpts) *cobra.Command {
 		opts = &certDeleteOpts{}
 	}
 	command := &cobra.Command{
-		Use:   "delete --type <type> --store <name> [flags] (--all | <cert_fileName>)",
+		Use:   "delete --type <type> --store <name> [flags] (--all | --fingerprint <sha256_fingerprint> | <cert_fileName>)",
 		Short: "Delete certificates from the trust store.",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if opts.all {
@@ -43,8 +44,14 @@ func certDeleteCommand(opts *certDeleteOpts) *cobra.Command {
 				}
 				return nil
 			}
+			if opts.fingerprint != "" {
+				if len(args) != 0 {
+					return errors.New("cannot delete a single certificate file when --fingerprint flag is set. use --help flag for more details")
+				}
+				return nil
+			}
 			if len(args) == 0 {
-				return errors.New("delete requires either the certificate file name that needs to be deleted or --all flag to delete all certificates in the given named trust store")
+				return errors.New("delete requires either the certificate file name that needs to be deleted, --fingerprint flag to delete a certificate by its SHA-256 fingerprint, or --all flag to delete all certificates in the given named trust store")
 			}
 			opts.cert = args[0]
 			return nil
@@ -57,6 +64,9 @@ Example - Delete all certificates with "ca" type from the trust store "acme-rock
 Example - Delete certificate "cert1.pem" with "signingAuthority" type from trust store wabbit-networks:
   notation cert delete --type signingAuthority --store wabbit-networks cert1.pem
 
+Example - Delete the certificate of SHA-256 fingerprint "<sha256_fingerprint>" with "ca" type from the trust store "acme-rockets", including its duplicates stored under other file names:
+  notation cert delete --type ca --store acme-rockets --fingerprint <sha256_fingerprint>
+
 Example - Delete all certificates with "ca" type from the trust store "acme-rockets", without prompt for confirmation:
   notation cert delete --type ca --store acme-rockets -y --all 
 
@@ -70,9 +80,11 @@ Example - Delete certificate "wabbit-networks-timestamp.pem" with "tsa" type fro
 	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority, tsa")
 	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store")
 	command.Flags().BoolVarP(&opts.all, "all", "a", false, "delete all certificates in the named store")
+	command.Flags().StringVar(&opts.fingerprint, "fingerprint", "", "delete the certificate of the SHA-256 fingerprint in the named store, including its duplicates")
 	command.Flags().BoolVarP(&opts.confirmed, "yes", "y", false, "do not prompt for confirmation")
 	command.MarkFlagRequired("type")
 	command.MarkFlagRequired("store")
+	command.MarkFlagsMutuallyExclusive("all", "fingerprint")
 	return command
 }
 
@@ -97,6 +109,16 @@ func deleteCerts(opts *certDeleteOpts) error {
 		return nil
 	}
 
+	if opts.fingerprint != "" {
+		// Delete certificate files of the fingerprint under
+		// storeType/namedStore
+		err := truststore.DeleteCertByFingerprint(storeType, namedStore, opts.fingerprint, opts.confirmed)
+		if err != nil {
+			return fmt.Errorf("failed to delete the certificate: %w", err)
+		}
+		return nil
+	}
+
 	// Delete a certain certificate with path storeType/namedStore/cert
 	cert := opts.cert
 	if cert == "" {
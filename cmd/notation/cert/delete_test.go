@@ -51,3 +51,39 @@ func TestCertDeleteCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestCertDeleteCommand_Fingerprint(t *testing.T) {
+	opts := &certDeleteOpts{}
+	cmd := certDeleteCommand(opts)
+	expected := &certDeleteOpts{
+		storeType:   "ca",
+		namedStore:  "test",
+		fingerprint: "abc",
+	}
+	if err := cmd.ParseFlags([]string{
+		"--fingerprint", "abc",
+		"-t", "ca",
+		"-s", "test"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert delete opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertDeleteCommand_FingerprintWithFileName(t *testing.T) {
+	cmd := certDeleteCommand(nil)
+	if err := cmd.ParseFlags([]string{
+		"test.crt",
+		"--fingerprint", "abc",
+		"-t", "ca",
+		"-s", "test"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
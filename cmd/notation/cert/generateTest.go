@@ -14,15 +14,14 @@
 package cert
 
 import (
-	"crypto/rand"
-	"crypto/rsa"
+	"crypto"
 	"crypto/x509"
-	"encoding/pem"
+	"crypto/x509/pkix"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/notaryproject/notation-core-go/testhelper"
 	"github.com/notaryproject/notation-go/config"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
@@ -42,9 +41,16 @@ var (
 )
 
 type certGenerateTestOpts struct {
-	name      string
-	bits      int
-	isDefault bool
+	name               string
+	keyType            string
+	bits               int
+	curve              string
+	validity           time.Duration
+	organization       string
+	organizationalUnit string
+	country            string
+	state              string
+	isDefault          bool
 }
 
 func certGenerateTestCommand(opts *certGenerateTestOpts) *cobra.Command {
@@ -53,7 +59,7 @@ func certGenerateTestCommand(opts *certGenerateTestOpts) *cobra.Command {
 	}
 	command := &cobra.Command{
 		Use:   "generate-test [flags] <common_name>",
-		Short: "Generate a test RSA key and a corresponding self-signed certificate.",
+		Short: "Generate a test RSA or ECDSA key and a corresponding self-signed certificate.",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
 				return errors.New("missing certificate common_name")
@@ -61,20 +67,35 @@ func certGenerateTestCommand(opts *certGenerateTestOpts) *cobra.Command {
 			opts.name = args[0]
 			return nil
 		},
-		Long: `Generate a test RSA key and a corresponding self-signed certificate
+		Long: `Generate a test RSA or ECDSA key and a corresponding self-signed certificate
+
+The subject of the certificate has the common name <common_name>, the locality "Seattle", and the organization, organizational unit, country and state given by the flags. Note that trusted identities in trust policies require the organization, country and state to be present.
 
 Example - Generate a test RSA key and a corresponding self-signed certificate named "wabbit-networks.io":
   notation cert generate-test "wabbit-networks.io"
 
 Example - Generate a test RSA key and a corresponding self-signed certificate, set RSA key as a default signing key:
   notation cert generate-test --default "wabbit-networks.io"
+
+Example - Generate a test ECDSA P-384 key and a corresponding self-signed certificate valid for 1 hour:
+  notation cert generate-test --key-type ecdsa --curve P-384 --validity 1h "wabbit-networks.io"
+
+Example - Generate a test RSA key and a corresponding self-signed certificate with a custom subject:
+  notation cert generate-test --organization "Wabbit Networks" --organizational-unit Security --country DE --state BE "wabbit-networks.io"
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return generateTestCert(opts)
 		},
 	}
 
-	command.Flags().IntVarP(&opts.bits, "bits", "b", 2048, "RSA key bits")
+	command.Flags().StringVar(&opts.keyType, "key-type", keyTypeRSA, fmt.Sprintf("key type, options: %s, %s", keyTypeRSA, keyTypeECDSA))
+	command.Flags().IntVarP(&opts.bits, "bits", "b", 2048, "RSA key bits, options: 2048, 3072, 4096")
+	command.Flags().StringVar(&opts.curve, "curve", "P-256", "ECDSA curve, options: P-256, P-384, P-521")
+	command.Flags().DurationVar(&opts.validity, "validity", 24*time.Hour, "validity period of the certificate")
+	command.Flags().StringVar(&opts.organization, "organization", "Notary", "organization (O) of the certificate subject")
+	command.Flags().StringVar(&opts.organizationalUnit, "organizational-unit", "", "organizational unit (OU) of the certificate subject")
+	command.Flags().StringVar(&opts.country, "country", "US", "country (C) of the certificate subject")
+	command.Flags().StringVar(&opts.state, "state", "WA", "state or province (ST) of the certificate subject")
 	setKeyDefaultFlag(command.Flags(), &opts.isDefault)
 	return command
 }
@@ -85,20 +106,34 @@ func generateTestCert(opts *certGenerateTestOpts) error {
 	if !truststore.IsValidFileName(name) {
 		return errors.New("name needs to follow [a-zA-Z0-9_.-]+ format")
 	}
+	if opts.validity <= 0 {
+		return fmt.Errorf("validity %s must be a positive duration", opts.validity)
+	}
 
-	// generate RSA private key
-	bits := opts.bits
-	fmt.Println("generating RSA Key with", bits, "bits")
-	key, keyBytes, err := generateTestKey(bits)
+	// generate private key
+	if opts.keyType == keyTypeECDSA {
+		fmt.Println("generating ECDSA Key with curve", strings.ToUpper(opts.curve))
+	} else {
+		fmt.Println("generating RSA Key with", opts.bits, "bits")
+	}
+	key, err := generateKey(opts.keyType, opts.bits, opts.curve)
 	if err != nil {
 		return err
 	}
 
-	rsaCertTuple, certBytes, err := generateSelfSignedCert(key, name)
+	certTuple, err := generateSelfSignedCert(key, opts, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Println("generated certificate expiring on", certTuple.cert.NotAfter.Format(time.RFC3339))
+	keyBytes, err := certTuple.keyPEM()
+	if err != nil {
+		return err
+	}
+	certBytes, err := chainPEM(certTuple)()
 	if err != nil {
 		return err
 	}
-	fmt.Println("generated certificate expiring on", rsaCertTuple.Cert.NotAfter.Format(time.RFC3339))
 
 	// write private key
 	relativeKeyPath, relativeCertPath := dir.LocalKeyPath(name)
@@ -143,25 +178,33 @@ func generateTestCert(opts *certGenerateTestOpts) error {
 	return nil
 }
 
-func generateTestKey(bits int) (*rsa.PrivateKey, []byte, error) {
-	key, err := rsa.GenerateKey(rand.Reader, bits)
-	if err != nil {
-		return nil, nil, err
+// generateSelfSignedCert generates a self-signed non-CA code signing
+// certificate for privateKey, valid from now.
+func generateSelfSignedCert(privateKey crypto.Signer, opts *certGenerateTestOpts, now time.Time) (*pkiCert, error) {
+	subject := pkix.Name{
+		CommonName: opts.name,
+		Locality:   []string{"Seattle"},
 	}
-	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
-	if err != nil {
-		return nil, nil, err
+	if opts.organization != "" {
+		subject.Organization = []string{opts.organization}
 	}
-	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
-	return key, keyPEM, nil
-}
-
-func generateCertPEM(rsaCertTuple *testhelper.RSACertTuple) []byte {
-	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rsaCertTuple.Cert.Raw})
-}
-
-// generateTestCert generates a self-signed non-CA certificate
-func generateSelfSignedCert(privateKey *rsa.PrivateKey, name string) (testhelper.RSACertTuple, []byte, error) {
-	rsaCertTuple := testhelper.GetRSASelfSignedCertTupleWithPK(privateKey, name)
-	return rsaCertTuple, generateCertPEM(&rsaCertTuple), nil
+	if opts.organizationalUnit != "" {
+		subject.OrganizationalUnit = []string{opts.organizationalUnit}
+	}
+	if opts.country != "" {
+		subject.Country = []string{opts.country}
+	}
+	if opts.state != "" {
+		subject.Province = []string{opts.state}
+	}
+	template := &x509.Certificate{
+		Subject:     subject,
+		NotBefore:   now,
+		NotAfter:    now.Add(opts.validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+	return issueCert(template, nil, func() (crypto.Signer, error) {
+		return privateKey, nil
+	})
 }
@@ -0,0 +1,453 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/v2/internal/osutil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	keyTypeRSA   = "rsa"
+	keyTypeECDSA = "ecdsa"
+)
+
+// oidExtKeyUsage is the object identifier of the extended key usage
+// extension, and oidExtKeyUsageTimeStamping of the time stamping purpose.
+var (
+	oidExtKeyUsage             = asn1.ObjectIdentifier{2, 5, 29, 37}
+	oidExtKeyUsageTimeStamping = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 8}
+)
+
+type certGenerateTestPKIOpts struct {
+	name      string
+	keyType   string
+	bits      int
+	curve     string
+	validity  time.Duration
+	tsa       bool
+	crlURL    string
+	isDefault bool
+}
+
+// testPKI is a test PKI with a root, an intermediate and a code signing leaf
+// certificate, and optionally a timestamping certificate and the CRLs.
+type testPKI struct {
+	root            *pkiCert
+	intermediate    *pkiCert
+	leaf            *pkiCert
+	tsa             *pkiCert
+	rootCRL         []byte
+	intermediateCRL []byte
+}
+
+// pkiFile is a file of the test PKI to be written.
+type pkiFile struct {
+	path    string
+	content func() ([]byte, error)
+	perm    os.FileMode
+}
+
+// pkiCert is a certificate with its private key.
+type pkiCert struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func certGenerateTestPKICommand(opts *certGenerateTestPKIOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certGenerateTestPKIOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "generate-test-pki [flags] <common_name>",
+		Short: "Generate a test PKI with a root, an intermediate and a code signing certificate.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("missing certificate common_name")
+			}
+			opts.name = args[0]
+			return nil
+		},
+		Long: `Generate a test PKI with a root, an intermediate and a code signing certificate
+
+A root CA certificate, an intermediate CA certificate and a code signing certificate are generated with their keys. The key of the code signing certificate is added to the key list with the certificate chain, and the root certificate is added to the trust store of type "ca" with the same name, as with "notation cert generate-test". The other certificates and keys are written to the "localkeys/<common_name>-pki" directory of the Notation configuration directory.
+
+Use "--tsa" to also generate a timestamping certificate issued by the intermediate CA, whose root certificate is added to the trust store of type "tsa" with the same name. Use "--crl-url" to add CRL distribution points under the URL to the certificates, and to generate the CRLs "root.crl" and "intermediate.crl" with no revoked certificate, so that the CRLs can be served from the "<common_name>-pki" directory by a local HTTP server.
+
+Example - Generate a test RSA PKI named "wabbit-networks.io":
+  notation cert generate-test-pki "wabbit-networks.io"
+
+Example - Generate a test ECDSA P-384 PKI valid for 30 days, and set the code signing key as the default signing key:
+  notation cert generate-test-pki --key-type ecdsa --curve P-384 --validity 720h --default "wabbit-networks.io"
+
+Example - Generate a test PKI with a timestamping certificate and the CRLs served at "http://localhost:10086":
+  notation cert generate-test-pki --tsa --crl-url http://localhost:10086 "wabbit-networks.io"
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return generateTestPKI(opts)
+		},
+	}
+	command.Flags().StringVar(&opts.keyType, "key-type", keyTypeRSA, fmt.Sprintf("key type, options: %s, %s", keyTypeRSA, keyTypeECDSA))
+	command.Flags().IntVarP(&opts.bits, "bits", "b", 2048, "RSA key bits, options: 2048, 3072, 4096")
+	command.Flags().StringVar(&opts.curve, "curve", "P-256", "ECDSA curve, options: P-256, P-384, P-521")
+	command.Flags().DurationVar(&opts.validity, "validity", 365*24*time.Hour, "validity period of the certificates and the CRLs")
+	command.Flags().BoolVar(&opts.tsa, "tsa", false, "generate a timestamping certificate")
+	command.Flags().StringVar(&opts.crlURL, "crl-url", "", "base URL of the CRL distribution points, such as http://localhost:10086")
+	setKeyDefaultFlag(command.Flags(), &opts.isDefault)
+	return command
+}
+
+func generateTestPKI(opts *certGenerateTestPKIOpts) error {
+	// initialize
+	name := opts.name
+	if !truststore.IsValidFileName(name) {
+		return errors.New("name needs to follow [a-zA-Z0-9_.-]+ format")
+	}
+	if opts.validity <= 0 {
+		return fmt.Errorf("validity %s must be a positive duration", opts.validity)
+	}
+	if opts.crlURL != "" {
+		u, err := url.Parse(opts.crlURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid CRL URL %q: an HTTP or HTTPS URL is required", opts.crlURL)
+		}
+	}
+
+	// generate the PKI
+	fmt.Println("generating test PKI with", describeKey(opts.keyType, opts.bits, opts.curve), "keys")
+	pki, err := newTestPKI(opts, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Println("generated certificates expiring on", pki.leaf.cert.NotAfter.Format(time.RFC3339))
+
+	// write the code signing key and certificate chain
+	relativeKeyPath, relativeCertPath := dir.LocalKeyPath(name)
+	configFS := dir.ConfigFS()
+	keyPath, err := configFS.SysPath(relativeKeyPath)
+	if err != nil {
+		return err
+	}
+	certPath, err := configFS.SysPath(relativeCertPath)
+	if err != nil {
+		return err
+	}
+	pkiDir, err := configFS.SysPath(dir.LocalKeysDir, name+"-pki")
+	if err != nil {
+		return err
+	}
+	files := []pkiFile{
+		{keyPath, pki.leaf.keyPEM, 0600},
+		{certPath, chainPEM(pki.leaf, pki.intermediate, pki.root), 0644},
+		{filepath.Join(pkiDir, "root.key"), pki.root.keyPEM, 0600},
+		{filepath.Join(pkiDir, "root.crt"), chainPEM(pki.root), 0644},
+		{filepath.Join(pkiDir, "intermediate.key"), pki.intermediate.keyPEM, 0600},
+		{filepath.Join(pkiDir, "intermediate.crt"), chainPEM(pki.intermediate), 0644},
+	}
+	if pki.tsa != nil {
+		files = append(files,
+			pkiFile{filepath.Join(pkiDir, "tsa.key"), pki.tsa.keyPEM, 0600},
+			pkiFile{filepath.Join(pkiDir, "tsa.crt"), chainPEM(pki.tsa, pki.intermediate, pki.root), 0644},
+		)
+	}
+	if pki.rootCRL != nil {
+		files = append(files,
+			pkiFile{filepath.Join(pkiDir, "root.crl"), rawContent(pki.rootCRL), 0644},
+			pkiFile{filepath.Join(pkiDir, "intermediate.crl"), rawContent(pki.intermediateCRL), 0644},
+		)
+	}
+	for _, file := range files {
+		content, err := file.content()
+		if err != nil {
+			return err
+		}
+		if err := osutil.WriteFileWithPermission(file.path, content, file.perm, false); err != nil {
+			return fmt.Errorf("failed to write %s: %v", file.path, err)
+		}
+		fmt.Println("wrote:", file.path)
+	}
+
+	// update signingkeys.json config
+	exec := func(s *config.SigningKeys) error {
+		return s.Add(name, keyPath, certPath, opts.isDefault)
+	}
+	if err := config.LoadExecSaveSigningKeys(exec); err != nil {
+		return err
+	}
+
+	// Add the root certificate to the trust stores
+	storeTypes := []string{"ca"}
+	if pki.tsa != nil {
+		storeTypes = append(storeTypes, "tsa")
+	}
+	if err := addTestRoot(pki.root.cert, name, storeTypes); err != nil {
+		return err
+	}
+
+	// write out
+	fmt.Printf("%s: added to the key list\n", name)
+	if opts.isDefault {
+		fmt.Printf("%s: marked as default signing key\n", name)
+	}
+	if pki.rootCRL != nil {
+		fmt.Printf("serve the CRLs at %s from directory %s\n", opts.crlURL, pkiDir)
+	}
+	return nil
+}
+
+// newTestPKI generates a test PKI valid from now.
+func newTestPKI(opts *certGenerateTestPKIOpts, now time.Time) (*testPKI, error) {
+	newKey := func() (crypto.Signer, error) {
+		return generateKey(opts.keyType, opts.bits, opts.curve)
+	}
+	subject := func(commonName string) pkix.Name {
+		return pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{"Notary"},
+			Locality:     []string{"Seattle"},
+			Province:     []string{"WA"},
+			Country:      []string{"US"},
+		}
+	}
+	template := func(commonName string) *x509.Certificate {
+		return &x509.Certificate{
+			Subject:               subject(commonName),
+			NotBefore:             now,
+			NotAfter:              now.Add(opts.validity),
+			BasicConstraintsValid: true,
+		}
+	}
+	var crlURL func(string) []string
+	if opts.crlURL != "" {
+		baseURL := strings.TrimSuffix(opts.crlURL, "/")
+		crlURL = func(fileName string) []string {
+			return []string{baseURL + "/" + fileName}
+		}
+	} else {
+		crlURL = func(string) []string { return nil }
+	}
+
+	pki := &testPKI{}
+	var err error
+
+	// root CA
+	rootTemplate := template(opts.name + " Root CA")
+	rootTemplate.IsCA = true
+	rootTemplate.MaxPathLen = 1
+	rootTemplate.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	if pki.root, err = issueCert(rootTemplate, nil, newKey); err != nil {
+		return nil, fmt.Errorf("failed to generate root certificate: %w", err)
+	}
+
+	// intermediate CA
+	intermediateTemplate := template(opts.name + " Intermediate CA")
+	intermediateTemplate.IsCA = true
+	intermediateTemplate.MaxPathLenZero = true
+	intermediateTemplate.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	intermediateTemplate.CRLDistributionPoints = crlURL("root.crl")
+	if pki.intermediate, err = issueCert(intermediateTemplate, pki.root, newKey); err != nil {
+		return nil, fmt.Errorf("failed to generate intermediate certificate: %w", err)
+	}
+
+	// code signing leaf
+	leafTemplate := template(opts.name)
+	leafTemplate.KeyUsage = x509.KeyUsageDigitalSignature
+	leafTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}
+	leafTemplate.CRLDistributionPoints = crlURL("intermediate.crl")
+	if pki.leaf, err = issueCert(leafTemplate, pki.intermediate, newKey); err != nil {
+		return nil, fmt.Errorf("failed to generate code signing certificate: %w", err)
+	}
+
+	// timestamping leaf, whose extended key usage extension must be critical
+	if opts.tsa {
+		ekuValue, err := asn1.Marshal([]asn1.ObjectIdentifier{oidExtKeyUsageTimeStamping})
+		if err != nil {
+			return nil, err
+		}
+		tsaTemplate := template(opts.name + " TSA")
+		tsaTemplate.KeyUsage = x509.KeyUsageDigitalSignature
+		tsaTemplate.ExtraExtensions = []pkix.Extension{{Id: oidExtKeyUsage, Critical: true, Value: ekuValue}}
+		tsaTemplate.CRLDistributionPoints = crlURL("intermediate.crl")
+		if pki.tsa, err = issueCert(tsaTemplate, pki.intermediate, newKey); err != nil {
+			return nil, fmt.Errorf("failed to generate timestamping certificate: %w", err)
+		}
+	}
+
+	// CRLs
+	if opts.crlURL != "" {
+		if pki.rootCRL, err = issueCRL(pki.root, now, opts.validity); err != nil {
+			return nil, fmt.Errorf("failed to generate CRL of the root CA: %w", err)
+		}
+		if pki.intermediateCRL, err = issueCRL(pki.intermediate, now, opts.validity); err != nil {
+			return nil, fmt.Errorf("failed to generate CRL of the intermediate CA: %w", err)
+		}
+	}
+	return pki, nil
+}
+
+// issueCert generates a key with newKey and issues a certificate of template
+// for the key by issuer, or a self-signed certificate if issuer is nil.
+func issueCert(template *x509.Certificate, issuer *pkiCert, newKey func() (crypto.Signer, error)) (*pkiCert, error) {
+	key, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serialNumber
+	parent, parentKey := template, key
+	if issuer != nil {
+		parent, parentKey = issuer.cert, issuer.key
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &pkiCert{cert: cert, key: key}, nil
+}
+
+// issueCRL issues a DER encoded CRL with no revoked certificate by issuer.
+func issueCRL(issuer *pkiCert, now time.Time, validity time.Duration) ([]byte, error) {
+	return x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: now,
+		NextUpdate: now.Add(validity),
+	}, issuer.cert, issuer.key)
+}
+
+// generateKey generates a private key of keyType, with bits for RSA keys or
+// curve for ECDSA keys.
+func generateKey(keyType string, bits int, curve string) (crypto.Signer, error) {
+	switch keyType {
+	case keyTypeRSA:
+		switch bits {
+		case 2048, 3072, 4096:
+			return rsa.GenerateKey(rand.Reader, bits)
+		}
+		return nil, fmt.Errorf("unsupported RSA key bits %d, options: 2048, 3072, 4096", bits)
+	case keyTypeECDSA:
+		c, err := parseCurve(curve)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.GenerateKey(c, rand.Reader)
+	}
+	return nil, fmt.Errorf("unsupported key type %q, options: %s, %s", keyType, keyTypeRSA, keyTypeECDSA)
+}
+
+// parseCurve returns the ECDSA curve of name.
+func parseCurve(name string) (elliptic.Curve, error) {
+	switch strings.ToUpper(name) {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	}
+	return nil, fmt.Errorf("unsupported ECDSA curve %q, options: P-256, P-384, P-521", name)
+}
+
+// describeKey describes the key of keyType, such as "RSA 2048 bits" or
+// "ECDSA P-256".
+func describeKey(keyType string, bits int, curve string) string {
+	if keyType == keyTypeECDSA {
+		return "ECDSA " + strings.ToUpper(curve)
+	}
+	return fmt.Sprintf("RSA %d bits", bits)
+}
+
+// newSerialNumber returns a random positive serial number of up to 128 bits.
+func newSerialNumber() (*big.Int, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	return serialNumber.Add(serialNumber, big.NewInt(1)), nil
+}
+
+// keyPEM returns the private key in PKCS #8 PEM format.
+func (c *pkiCert) keyPEM() ([]byte, error) {
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(c.key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}), nil
+}
+
+// chainPEM returns a function returning the certificates in PEM format.
+func chainPEM(certs ...*pkiCert) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		var data []byte
+		for _, c := range certs {
+			data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})...)
+		}
+		return data, nil
+	}
+}
+
+// rawContent returns a function returning content.
+func rawContent(content []byte) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		return content, nil
+	}
+}
+
+// addTestRoot adds the root certificate to the named store name of
+// storeTypes, as the certificate file "<name>.crt" so that it is cleaned up
+// by `notation cert cleanup-test`.
+func addTestRoot(root *x509.Certificate, name string, storeTypes []string) error {
+	tempDir, err := os.MkdirTemp("", "notation-cert-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+	rootPath := filepath.Join(tempDir, name+dir.LocalCertificateExtension)
+	if err := os.WriteFile(rootPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.Raw}), 0600); err != nil {
+		return err
+	}
+	for _, storeType := range storeTypes {
+		if err := truststore.AddCert(rootPath, storeType, name, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation-go/dir"
+)
+
+func TestCertGenerateTestPKICommand(t *testing.T) {
+	opts := &certGenerateTestPKIOpts{}
+	cmd := certGenerateTestPKICommand(opts)
+	expected := &certGenerateTestPKIOpts{
+		name:      "name",
+		keyType:   "ecdsa",
+		bits:      2048,
+		curve:     "P-384",
+		validity:  720 * time.Hour,
+		tsa:       true,
+		crlURL:    "http://localhost:10086",
+		isDefault: true,
+	}
+	if err := cmd.ParseFlags([]string{
+		"name",
+		"--key-type", "ecdsa",
+		"--curve", "P-384",
+		"--validity", "720h",
+		"--tsa",
+		"--crl-url", "http://localhost:10086",
+		"--default"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert generate-test-pki opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertGenerateTestPKICommand_MissingArgs(t *testing.T) {
+	cmd := certGenerateTestPKICommand(nil)
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestNewTestPKI(t *testing.T) {
+	now := time.Now()
+	pki, err := newTestPKI(&certGenerateTestPKIOpts{
+		name:     "test",
+		keyType:  keyTypeECDSA,
+		curve:    "P-256",
+		validity: time.Hour,
+		tsa:      true,
+		crlURL:   "http://localhost:10086/",
+	}, now)
+	if err != nil {
+		t.Fatalf("newTestPKI() error = %v", err)
+	}
+
+	chain := []*x509.Certificate{pki.leaf.cert, pki.intermediate.cert, pki.root.cert}
+	if err := corex509.ValidateCodeSigningCertChain(chain, nil); err != nil {
+		t.Fatalf("invalid code signing certificate chain: %v", err)
+	}
+	tsaChain := []*x509.Certificate{pki.tsa.cert, pki.intermediate.cert, pki.root.cert}
+	if err := corex509.ValidateTimestampingCertChain(tsaChain); err != nil {
+		t.Fatalf("invalid timestamping certificate chain: %v", err)
+	}
+	if _, ok := pki.leaf.key.(*ecdsa.PrivateKey); !ok {
+		t.Fatalf("expected ECDSA key, got %T", pki.leaf.key)
+	}
+	if !pki.leaf.cert.NotAfter.Equal(now.Add(time.Hour).Truncate(time.Second)) {
+		t.Fatalf("unexpected expiry %v", pki.leaf.cert.NotAfter)
+	}
+	if !slices.Equal(pki.leaf.cert.CRLDistributionPoints, []string{"http://localhost:10086/intermediate.crl"}) ||
+		!slices.Equal(pki.intermediate.cert.CRLDistributionPoints, []string{"http://localhost:10086/root.crl"}) {
+		t.Fatalf("unexpected CRL distribution points: %v, %v", pki.leaf.cert.CRLDistributionPoints, pki.intermediate.cert.CRLDistributionPoints)
+	}
+
+	for _, tt := range []struct {
+		crl    []byte
+		issuer *x509.Certificate
+	}{
+		{pki.rootCRL, pki.root.cert},
+		{pki.intermediateCRL, pki.intermediate.cert},
+	} {
+		crl, err := x509.ParseRevocationList(tt.crl)
+		if err != nil {
+			t.Fatalf("failed to parse CRL: %v", err)
+		}
+		if err := crl.CheckSignatureFrom(tt.issuer); err != nil {
+			t.Fatalf("invalid CRL signature: %v", err)
+		}
+		if len(crl.RevokedCertificateEntries) != 0 {
+			t.Fatalf("expected no revoked certificate, got %d", len(crl.RevokedCertificateEntries))
+		}
+	}
+}
+
+func TestNewTestPKI_NoTSA(t *testing.T) {
+	pki, err := newTestPKI(&certGenerateTestPKIOpts{
+		name:     "test",
+		keyType:  keyTypeRSA,
+		bits:     2048,
+		validity: time.Hour,
+	}, time.Now())
+	if err != nil {
+		t.Fatalf("newTestPKI() error = %v", err)
+	}
+	if _, ok := pki.leaf.key.(*rsa.PrivateKey); !ok {
+		t.Fatalf("expected RSA key, got %T", pki.leaf.key)
+	}
+	if pki.tsa != nil || pki.rootCRL != nil || pki.intermediateCRL != nil {
+		t.Fatal("expected no timestamping certificate and no CRL")
+	}
+	if len(pki.leaf.cert.CRLDistributionPoints) != 0 {
+		t.Fatalf("expected no CRL distribution point, got %v", pki.leaf.cert.CRLDistributionPoints)
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	for _, tt := range []struct {
+		keyType string
+		bits    int
+		curve   string
+		wantErr bool
+	}{
+		{keyType: keyTypeRSA, bits: 3072},
+		{keyType: keyTypeRSA, bits: 1024, wantErr: true},
+		{keyType: keyTypeECDSA, curve: "P-521"},
+		{keyType: keyTypeECDSA, curve: "P-224", wantErr: true},
+		{keyType: "ed25519", wantErr: true},
+	} {
+		_, err := generateKey(tt.keyType, tt.bits, tt.curve)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("generateKey(%s, %d, %s) error = %v, wantErr %v", tt.keyType, tt.bits, tt.curve, err, tt.wantErr)
+		}
+	}
+}
+
+func TestGenerateTestPKI(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+
+	if err := generateTestPKI(&certGenerateTestPKIOpts{
+		name:     "test",
+		keyType:  keyTypeECDSA,
+		curve:    "P-256",
+		validity: time.Hour,
+		tsa:      true,
+		crlURL:   "http://localhost:10086",
+	}); err != nil {
+		t.Fatalf("generateTestPKI() error = %v", err)
+	}
+	for _, file := range []string{
+		"localkeys/test.key",
+		"localkeys/test.crt",
+		"localkeys/test-pki/root.crt",
+		"localkeys/test-pki/intermediate.key",
+		"localkeys/test-pki/tsa.crt",
+		"localkeys/test-pki/root.crl",
+		"localkeys/test-pki/intermediate.crl",
+		"truststore/x509/ca/test/test.crt",
+		"truststore/x509/tsa/test/test.crt",
+	} {
+		if _, err := os.Stat(filepath.Join(dir.UserConfigDir, filepath.FromSlash(file))); err != nil {
+			t.Fatalf("expected file %s: %v", file, err)
+		}
+	}
+	signingKeys, err := config.LoadSigningKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signingKeys.Get("test"); err != nil {
+		t.Fatalf("expected signing key test: %v", err)
+	}
+
+	if err := cleanupTestCert(&certCleanupTestOpts{name: "test", confirmed: true}); err != nil {
+		t.Fatalf("cleanupTestCert() error = %v", err)
+	}
+	for _, file := range []string{
+		"localkeys/test.key",
+		"localkeys/test-pki",
+		"truststore/x509/ca/test/test.crt",
+		"truststore/x509/tsa/test/test.crt",
+	} {
+		if _, err := os.Stat(filepath.Join(dir.UserConfigDir, filepath.FromSlash(file))); !os.IsNotExist(err) {
+			t.Fatalf("expected file %s to be deleted, got %v", file, err)
+		}
+	}
+}
+
+func TestGenerateTestPKI_InvalidOptions(t *testing.T) {
+	for _, opts := range []*certGenerateTestPKIOpts{
+		{name: "invalid name", keyType: keyTypeRSA, bits: 2048, validity: time.Hour},
+		{name: "test", keyType: keyTypeRSA, bits: 2048, validity: -time.Hour},
+		{name: "test", keyType: keyTypeRSA, bits: 2048, validity: time.Hour, crlURL: "ftp://localhost"},
+	} {
+		if err := generateTestPKI(opts); err == nil {
+			t.Fatalf("generateTestPKI(%+v) expected error, but ok", opts)
+		}
+	}
+}
@@ -14,18 +14,30 @@
 package cert
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
+
+	corex509 "github.com/notaryproject/notation-core-go/x509"
 )
 
 func TestCertGenerateCommand(t *testing.T) {
 	opts := &certGenerateTestOpts{}
 	cmd := certGenerateTestCommand(opts)
 	expected := &certGenerateTestOpts{
-		name:      "name",
-		bits:      2048,
-		isDefault: true,
+		name:         "name",
+		keyType:      "rsa",
+		bits:         2048,
+		curve:        "P-256",
+		validity:     24 * time.Hour,
+		organization: "Notary",
+		country:      "US",
+		state:        "WA",
+		isDefault:    true,
 	}
 	if err := cmd.ParseFlags([]string{
 		"name",
@@ -50,3 +62,81 @@ func TestCertGenerateTestCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestCertGenerateTestCommand_ECDSA(t *testing.T) {
+	opts := &certGenerateTestOpts{}
+	cmd := certGenerateTestCommand(opts)
+	expected := &certGenerateTestOpts{
+		name:               "name",
+		keyType:            "ecdsa",
+		bits:               2048,
+		curve:              "P-521",
+		validity:           time.Hour,
+		organization:       "Wabbit Networks",
+		organizationalUnit: "Security",
+		country:            "DE",
+		state:              "BE",
+	}
+	if err := cmd.ParseFlags([]string{
+		"name",
+		"--key-type", "ecdsa",
+		"--curve", "P-521",
+		"--validity", "1h",
+		"--organization", "Wabbit Networks",
+		"--organizational-unit", "Security",
+		"--country", "DE",
+		"--state", "BE"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert generate-test opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestGenerateSelfSignedCert(t *testing.T) {
+	key, err := generateKey(keyTypeECDSA, 0, "P-384")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	certTuple, err := generateSelfSignedCert(key, &certGenerateTestOpts{
+		name:               "test",
+		validity:           time.Hour,
+		organization:       "Wabbit Networks",
+		organizationalUnit: "Security",
+		country:            "DE",
+		state:              "BE",
+	}, now)
+	if err != nil {
+		t.Fatalf("generateSelfSignedCert() error = %v", err)
+	}
+	cert := certTuple.cert
+	if err := corex509.ValidateCodeSigningCertChain([]*x509.Certificate{cert}, nil); err != nil {
+		t.Fatalf("invalid code signing certificate: %v", err)
+	}
+	if got, expected := cert.Subject.String(), "CN=test,OU=Security,O=Wabbit Networks,L=Seattle,ST=BE,C=DE"; got != expected {
+		t.Fatalf("expected subject %q, got %q", expected, got)
+	}
+	if !cert.NotAfter.Equal(now.Add(time.Hour).Truncate(time.Second)) {
+		t.Fatalf("unexpected expiry %v", cert.NotAfter)
+	}
+	publicKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok || publicKey.Curve != elliptic.P384() {
+		t.Fatalf("expected ECDSA P-384 public key, got %T", cert.PublicKey)
+	}
+}
+
+func TestGenerateTestCert_InvalidOptions(t *testing.T) {
+	for _, opts := range []*certGenerateTestOpts{
+		{name: "test", keyType: keyTypeRSA, bits: 2048, validity: 0},
+		{name: "test", keyType: keyTypeECDSA, curve: "P-192", validity: time.Hour},
+		{name: "test", keyType: keyTypeRSA, bits: 1024, validity: time.Hour},
+	} {
+		if err := generateTestCert(opts); err == nil {
+			t.Fatalf("generateTestCert(%+v) expected error, but ok", opts)
+		}
+	}
+}
@@ -19,11 +19,15 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"text/tabwriter"
+	"strings"
 
+	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/log"
 	notationgoTruststore "github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
 	"github.com/spf13/cobra"
@@ -31,8 +35,10 @@ import (
 
 type certListOpts struct {
 	flag.LoggingFlagOpts
-	storeType  string
-	namedStore string
+	outputFormat flag.OutputFormatFlagOpts
+	printer      *output.Printer
+	storeType    string
+	namedStore   string
 }
 
 func certListCommand(opts *certListOpts) *cobra.Command {
@@ -59,12 +65,23 @@ Example - List all certificate files from trust store "wabbit-networks" of type
 
 Example - List all certificate files from trust store of type "tsa"
   notation cert ls --type tsa
+
+Example - List all certificate files stored in the trust store with the details of their certificates in JSON
+  notation cert ls -o json
 `,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.outputFormat.Validate(cmd); err != nil {
+				return err
+			}
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return listCerts(cmd.Context(), opts)
 		},
 	}
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.outputFormat.ApplyFlags(command.Flags(), output.FormatText, output.FormatJSON)
 	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority, tsa")
 	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store")
 	return command
@@ -73,100 +90,123 @@ Example - List all certificate files from trust store of type "tsa"
 func listCerts(ctx context.Context, opts *certListOpts) error {
 	// set log level
 	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
-	logger := log.GetLogger(ctx)
 
-	namedStore := opts.namedStore
-	storeType := opts.storeType
+	// initialize display handler
+	displayHandler, err := display.NewCertListHandler(opts.printer, opts.outputFormat.CurrentFormat)
+	if err != nil {
+		return err
+	}
+
+	certPaths, err := listCertPaths(ctx, opts.storeType, opts.namedStore)
+	if err != nil {
+		return err
+	}
+	for _, certPath := range certPaths {
+		certs, err := corex509.ReadCertificateFile(certPath)
+		storeDir := filepath.Dir(certPath)
+		displayHandler.OnCertificateListed(metadata.CertificateFile{
+			StoreType:    filepath.Base(filepath.Dir(storeDir)),
+			StoreName:    filepath.Base(storeDir),
+			Path:         certPath,
+			Certificates: certs,
+			Err:          err,
+		})
+	}
+	if err := displayHandler.Render(); err != nil {
+		return err
+	}
+	printDuplicates(os.Stderr, truststore.FindDuplicates(certPaths))
+	return nil
+}
+
+// listCertPaths lists the paths of the certificate files in the trust stores
+// of storeType and namedStore. Empty values match any store type or named
+// store. Nothing is listed if store type is invalid or there's no certificate
+// yet.
+func listCertPaths(ctx context.Context, storeType, namedStore string) ([]string, error) {
+	logger := log.GetLogger(ctx)
 	configFS := dir.ConfigFS()
 
-	// List all certificates under truststore/x509, display empty if there's
-	// no certificate yet
+	// List all certificates under truststore/x509
 	if namedStore == "" && storeType == "" {
 		var certPaths []string
 		for _, t := range notationgoTruststore.Types {
 			path, err := configFS.SysPath(dir.TrustStoreDir, "x509", string(t))
 			if err := truststore.CheckNonErrNotExistError(err); err != nil {
-				return err
+				return nil, err
 			}
 			certs, err := truststore.ListCerts(path, 1)
 			if err := truststore.CheckNonErrNotExistError(err); err != nil {
 				logger.Debugln("Failed to complete list at path:", path)
-				return fmt.Errorf("failed to list all certificates stored in the trust store, with error: %s", err.Error())
+				return nil, fmt.Errorf("failed to list all certificates stored in the trust store, with error: %s", err.Error())
 			}
 			certPaths = append(certPaths, certs...)
 		}
-		return printCertMap(os.Stdout, certPaths)
+		return certPaths, nil
 	}
 
-	// List all certificates under truststore/x509/storeType/namedStore,
-	// display empty if store type is invalid or there's no certificate yet
+	// List all certificates under truststore/x509/storeType/namedStore
 	if namedStore != "" && storeType != "" {
 		if !truststore.IsValidStoreType(storeType) {
-			return nil
+			return nil, nil
 		}
 		path, err := configFS.SysPath(dir.TrustStoreDir, "x509", storeType, namedStore)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
-			return err
+			return nil, err
 		}
 		certPaths, err := truststore.ListCerts(path, 0)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
 			logger.Debugln("Failed to complete list at path:", path)
-			return fmt.Errorf("failed to list all certificates stored in the named store %s of type %s, with error: %s", namedStore, storeType, err.Error())
+			return nil, fmt.Errorf("failed to list all certificates stored in the named store %s of type %s, with error: %s", namedStore, storeType, err.Error())
 		}
-		return printCertMap(os.Stdout, certPaths)
+		return certPaths, nil
 	}
 
-	// List all certificates under x509/storeType, display empty if store type
-	// is invalid or there's no certificate yet
+	// List all certificates under x509/storeType
 	if storeType != "" {
 		if !truststore.IsValidStoreType(storeType) {
-			return nil
+			return nil, nil
 		}
 		path, err := configFS.SysPath(dir.TrustStoreDir, "x509", storeType)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
-			return err
+			return nil, err
 		}
 		certPaths, err := truststore.ListCerts(path, 1)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
 			logger.Debugln("Failed to complete list at path:", path)
-			return fmt.Errorf("failed to list all certificates stored of type %s, with error: %s", storeType, err.Error())
+			return nil, fmt.Errorf("failed to list all certificates stored of type %s, with error: %s", storeType, err.Error())
 		}
-		return printCertMap(os.Stdout, certPaths)
+		return certPaths, nil
 	}
 
-	// List all certificates under named store namedStore, display empty if
-	// there's no certificate yet
+	// List all certificates under named store namedStore
 	var certPaths []string
 	for _, t := range notationgoTruststore.Types {
 		path, err := configFS.SysPath(dir.TrustStoreDir, "x509", string(t), namedStore)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
-			return err
+			return nil, err
 		}
 		certs, err := truststore.ListCerts(path, 0)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
 			logger.Debugln("Failed to complete list at path:", path)
-			return fmt.Errorf("failed to list all certificates stored in the named store %s, with error: %s", namedStore, err.Error())
+			return nil, fmt.Errorf("failed to list all certificates stored in the named store %s, with error: %s", namedStore, err.Error())
 		}
 		certPaths = append(certPaths, certs...)
 	}
-	return printCertMap(os.Stdout, certPaths)
+	return certPaths, nil
 }
 
-// printCertMap lists certificate files in the trust store given array of cert
-// paths
-func printCertMap(w io.Writer, certPaths []string) error {
-	if len(certPaths) == 0 {
-		return nil
-	}
-	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(tw, "STORE TYPE\tSTORE NAME\tCERTIFICATE\t")
-	for _, cert := range certPaths {
-		fileName := filepath.Base(cert)
-		dir := filepath.Dir(cert)
-		namedStore := filepath.Base(dir)
-		dir = filepath.Dir(dir)
-		storeType := filepath.Base(dir)
-		fmt.Fprintf(tw, "%s\t%s\t%s\t\n", storeType, namedStore, fileName)
+// printDuplicates prints a warning for each certificate stored more than once
+// in a trust store
+func printDuplicates(w io.Writer, duplicates []truststore.Duplicate) {
+	for _, duplicate := range duplicates {
+		storeDir := filepath.Dir(duplicate.Paths[0])
+		namedStore := filepath.Base(storeDir)
+		storeType := filepath.Base(filepath.Dir(storeDir))
+		fileNames := make([]string, 0, len(duplicate.Paths))
+		for _, path := range duplicate.Paths {
+			fileNames = append(fileNames, filepath.Base(path))
+		}
+		fmt.Fprintf(w, "Warning: certificate with SHA-256 fingerprint %s is stored more than once in trust store %s of type %s: %s\n", duplicate.Fingerprint, namedStore, storeType, strings.Join(fileNames, ", "))
 	}
-	return tw.Flush()
 }
@@ -14,23 +14,52 @@
 package cert
 
 import (
+	"bytes"
+	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
+	"github.com/spf13/pflag"
 )
 
 func TestCertListCommand(t *testing.T) {
 	opts := &certListOpts{}
 	cmd := certListCommand(opts)
+	format := flag.OutputFormatFlagOpts{}
+	format.ApplyFlags(&pflag.FlagSet{}, output.FormatText, output.FormatJSON)
+	format.CurrentFormat = output.FormatJSON
 	expected := &certListOpts{
-		storeType:  "ca",
-		namedStore: "test",
+		outputFormat: format,
+		storeType:    "ca",
+		namedStore:   "test",
 	}
 	if err := cmd.ParseFlags([]string{
 		"-t", "ca",
-		"-s", "test"}); err != nil {
+		"-s", "test",
+		"-o", "json"}); err != nil {
 		t.Fatalf("Parse Flag failed: %v", err)
 	}
 	if !reflect.DeepEqual(*expected, *opts) {
 		t.Fatalf("Expect cert list opts: %v, got: %v", expected, opts)
 	}
 }
+
+func TestPrintDuplicates(t *testing.T) {
+	var buf bytes.Buffer
+	printDuplicates(&buf, []truststore.Duplicate{
+		{
+			Fingerprint: "abc",
+			Paths: []string{
+				filepath.Join("truststore", "x509", "ca", "test", "a.crt"),
+				filepath.Join("truststore", "x509", "ca", "test", "b.crt"),
+			},
+		},
+	})
+	expected := "Warning: certificate with SHA-256 fingerprint abc is stored more than once in trust store test of type ca: a.crt, b.crt\n"
+	if got := buf.String(); got != expected {
+		t.Fatalf("expected %q, but got %q", expected, got)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
+	"github.com/spf13/cobra"
+)
+
+type certMoveOpts struct {
+	src       string
+	dst       string
+	fileNames []string
+	all       bool
+	confirmed bool
+}
+
+func certMoveCommand(opts *certMoveOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certMoveOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "move [flags] <src_type>:<src_store> <dst_type>:<dst_store> (--all | <cert_fileName>...)",
+		Short: "Move certificates from a named trust store to another.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := parseTransferArgs("move", args, opts.all); err != nil {
+				return err
+			}
+			opts.src, opts.dst, opts.fileNames = args[0], args[1], args[2:]
+			return nil
+		},
+		Long: `Move certificates from a named trust store to another
+
+The trust stores are in the format "<store_type>:<store_name>", as referenced by trust policy statements. The destination trust store is created if it does not exist, and the source trust store is deleted once empty. Nothing is moved if any of the certificates is already in the destination trust store, or if any of the certificate file names is taken by a different certificate file.
+
+Example - Move certificate "root.crt" from trust store "acme" of type "ca" to trust store "acme" of type "signingAuthority":
+  notation cert move ca:acme signingAuthority:acme root.crt
+
+Example - Move all certificates from trust store "acme" of type "ca" to trust store "acme-prod" of type "ca", without prompt for confirmation:
+  notation cert move --all -y ca:acme ca:acme-prod
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return moveCerts(opts)
+		},
+	}
+	command.Flags().BoolVarP(&opts.all, "all", "a", false, "move all certificates in the source trust store")
+	command.Flags().BoolVarP(&opts.confirmed, "yes", "y", false, "do not prompt for confirmation")
+	return command
+}
+
+func moveCerts(opts *certMoveOpts) error {
+	srcType, srcStore, err := parseTrustStoreName(opts.src)
+	if err != nil {
+		return err
+	}
+	dstType, dstStore, err := parseTrustStoreName(opts.dst)
+	if err != nil {
+		return err
+	}
+	files := "all certificates"
+	if len(opts.fileNames) > 0 {
+		files = strings.Join(opts.fileNames, ", ")
+	}
+	prompt := fmt.Sprintf("Are you sure you want to move %s in %q of type %q to %q of type %q?", files, srcStore, srcType, dstStore, dstType)
+	confirmed, err := display.AskForConfirmation(os.Stdin, prompt, opts.confirmed)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+	moved, err := truststore.MoveCerts(srcType, srcStore, dstType, dstStore, opts.fileNames)
+	for _, name := range moved {
+		fmt.Printf("Successfully moved %s from trust store %s of type %s to trust store %s of type %s\n", name, srcStore, srcType, dstStore, dstType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move certificates: %w", err)
+	}
+
+	// the trust policy statements referencing the source trust store break
+	// once it is deleted
+	storePath, err := dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509", srcType, srcStore)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(storePath); errors.Is(err, fs.ErrNotExist) {
+		warnTrustStoreReferences(opts.src, "")
+	}
+	return nil
+}
+
+// warnTrustStoreReferences prints a warning if the trust store name in the
+// format "<store_type>:<store_name>", which no longer exists, is referenced by
+// the user level or the system level trust policy statements. Failures to
+// load the trust policy configurations are ignored, as they are reported by
+// `notation policy lint`.
+func warnTrustStoreReferences(name, hint string) {
+	var systemReferring []string
+	if statements, err := loadSystemStatements(); err == nil {
+		systemReferring = policy.ReferringStatements(statements, name)
+	}
+	if statements, err := loadStatements(); err == nil {
+		// the effective statements include the system level ones
+		var referring []string
+		for _, statement := range policy.ReferringStatements(statements, name) {
+			if !slices.Contains(systemReferring, statement) {
+				referring = append(referring, statement)
+			}
+		}
+		if len(referring) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: trust store %s no longer exists, but is referenced by trust policy statement(s): %s\n", name, strings.Join(referring, ", "))
+			if hint != "" {
+				fmt.Fprintf(os.Stderr, "  hint: %s\n", hint)
+			}
+		}
+	}
+	if len(systemReferring) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: trust store %s no longer exists, but is referenced by system trust policy statement(s): %s\n", name, strings.Join(systemReferring, ", "))
+		fmt.Fprintln(os.Stderr, "  hint: the system trust policy configurations can only be updated by the administrators of the host")
+	}
+}
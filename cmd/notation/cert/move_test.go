@@ -0,0 +1,74 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+func TestCertMoveCommand(t *testing.T) {
+	opts := &certMoveOpts{}
+	cmd := certMoveCommand(opts)
+	expected := &certMoveOpts{
+		src:       "ca:acme",
+		dst:       "signingAuthority:acme",
+		fileNames: []string{"root.crt"},
+		confirmed: true,
+	}
+	if err := cmd.ParseFlags([]string{"ca:acme", "signingAuthority:acme", "root.crt", "-y"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert move opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestMoveCerts(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+	srcPath := filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "acme")
+	if err := os.MkdirAll(srcPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join("..", "internal", "truststore", "testdata", "self-signed.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(srcPath, "root.crt"), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := moveCerts(&certMoveOpts{src: "ca:acme", dst: "signingAuthority:acme", all: true, confirmed: true}); err != nil {
+		t.Fatalf("moveCerts() error = %v", err)
+	}
+	if _, err := os.Stat(srcPath); !os.IsNotExist(err) {
+		t.Fatalf("expected the source trust store to be deleted, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir.UserConfigDir, "truststore", "x509", "signingAuthority", "acme", "root.crt")); err != nil {
+		t.Fatalf("expected root.crt in the destination trust store: %v", err)
+	}
+	if err := moveCerts(&certMoveOpts{src: "ca:acme", dst: "ca:other", all: true, confirmed: true}); err == nil {
+		t.Fatal("moveCerts() expected error, but ok")
+	}
+}
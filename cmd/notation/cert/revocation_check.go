@@ -0,0 +1,271 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
+	clirev "github.com/notaryproject/notation/v2/internal/revocation"
+	"github.com/spf13/cobra"
+)
+
+const (
+	purposeCodeSigning  = "codeSigning"
+	purposeTimestamping = "timestamping"
+)
+
+type certRevocationCheckOpts struct {
+	flag.LoggingFlagOpts
+	flag.SecureFlagOpts
+	outputFormat  flag.OutputFormatFlagOpts
+	printer       *output.Printer
+	storeType     string
+	namedStore    string
+	path          string
+	reference     string
+	signaturePath string
+	purpose       string
+}
+
+// certificateChain is a certificate chain to be checked for revocation.
+type certificateChain struct {
+	source string
+	certs  []*x509.Certificate
+	err    error
+}
+
+func certRevocationCheckCommand(opts *certRevocationCheckOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certRevocationCheckOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "revocation-check [flags] (<chain_path> | --type <type> --store <name> <cert_fileName> | --from-signature <signature_path> | --from-reference <reference>)",
+		Short: "Check the revocation status of a certificate chain.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.reference != "" || opts.signaturePath != "" {
+				if len(args) != 0 {
+					return errors.New("cannot check certificate files when --from-reference or --from-signature is set. use --help flag for more details")
+				}
+				return nil
+			}
+			if len(args) == 0 {
+				if opts.storeType != "" {
+					return errors.New("missing certificate file name")
+				}
+				return errors.New("missing certificate chain path")
+			}
+			if len(args) > 1 {
+				return errors.New("revocation-check only supports single certificate file")
+			}
+			opts.path = args[0]
+			return nil
+		},
+		Long: `Check the revocation status of a certificate chain
+
+The certificate chain is read from a certificate file, a certificate file in the trust store, or the signatures of a signed artifact or blob, and each certificate in the chain is checked against the OCSP responders and the CRLs it specifies. The certificates in a file must be ordered starting from the leaf certificate. The CRLs are cached in the same way as in signature verification.
+
+The signing certificate chain is checked for purpose "codeSigning", and the certificate chain of the timestamp countersignature for purpose "timestamping". The purpose defaults to "timestamping" for certificates of the "tsa" trust store type, and to "codeSigning" otherwise.
+
+The command fails if any certificate chain is revoked, its revocation status is unknown, or it cannot be checked.
+
+Example - Check the revocation status of a signing certificate chain:
+  notation cert revocation-check chain.pem
+
+Example - Check the revocation status of a certificate in the "signingAuthority" type of a named store "wabbit-networks":
+  notation cert revocation-check --type signingAuthority --store wabbit-networks wabbit-networks.pem
+
+Example - Check the revocation status of the certificate chains of the timestamp countersignatures of the signatures of an artifact in JSON:
+  notation cert revocation-check --from-reference <registry>/<repository>@<digest> --purpose timestamping -o json
+
+Example - Check the revocation status of the signing certificate chain of a blob signature:
+  notation cert revocation-check --from-signature blob.jws.sig
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.outputFormat.Validate(cmd); err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("purpose") && opts.storeType == "tsa" {
+				opts.purpose = purposeTimestamping
+			}
+			if opts.purpose != purposeCodeSigning && opts.purpose != purposeTimestamping {
+				return fmt.Errorf("unsupported purpose %q, options: %s, %s", opts.purpose, purposeCodeSigning, purposeTimestamping)
+			}
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkRevocation(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.SecureFlagOpts.ApplyFlags(command.Flags())
+	opts.outputFormat.ApplyFlags(command.Flags(), output.FormatText, output.FormatJSON)
+	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type of the certificate file, options: ca, signingAuthority, tsa")
+	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store of the certificate file")
+	command.Flags().StringVar(&opts.reference, "from-reference", "", "check the certificate chains of the signatures of the artifact in the format of <registry>/<repository>:<tag> or <registry>/<repository>@<digest>")
+	command.Flags().StringVar(&opts.signaturePath, "from-signature", "", "check the certificate chain of the signature file or the signature bundle of a signed blob")
+	command.Flags().StringVar(&opts.purpose, "purpose", purposeCodeSigning, fmt.Sprintf("purpose of the certificate chain, options: %s, %s", purposeCodeSigning, purposeTimestamping))
+	command.MarkFlagsRequiredTogether("type", "store")
+	command.MarkFlagsMutuallyExclusive("from-reference", "from-signature", "type")
+	return command
+}
+
+func checkRevocation(ctx context.Context, opts *certRevocationCheckOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	// initialize display handler
+	displayHandler, err := display.NewRevocationCheckHandler(opts.printer, opts.outputFormat.CurrentFormat)
+	if err != nil {
+		return err
+	}
+
+	chains, err := collectChains(ctx, opts)
+	if err != nil {
+		return err
+	}
+	var failed int
+	for _, chain := range chains {
+		check := checkChain(ctx, chain, opts.purpose)
+		if check.Err != nil {
+			failed++
+		} else if chainResult := clirev.ChainResult(check.Results); chainResult == result.ResultRevoked || chainResult == result.ResultUnknown {
+			failed++
+		}
+		displayHandler.OnRevocationChecked(check)
+	}
+	if err := displayHandler.Render(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d certificate chain(s) are revoked, of unknown revocation status, or failed to be checked", failed, len(chains))
+	}
+	return nil
+}
+
+// collectChains collects the certificate chains to be checked for
+// revocation.
+func collectChains(ctx context.Context, opts *certRevocationCheckOpts) ([]certificateChain, error) {
+	if opts.reference != "" || opts.signaturePath != "" {
+		var signatures []*certchain.Signature
+		if opts.reference != "" {
+			var err error
+			signatures, _, err = certchain.FetchReference(ctx, &opts.SecureFlagOpts, opts.reference, maxSignatures)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			sig, err := certchain.ReadFile(opts.signaturePath)
+			if err != nil {
+				return nil, err
+			}
+			signatures = []*certchain.Signature{sig}
+		}
+		chains := make([]certificateChain, 0, len(signatures))
+		for _, sig := range signatures {
+			chain := certificateChain{source: sig.Source}
+			if opts.purpose == purposeTimestamping {
+				chain.certs, chain.err = sig.TimestampChain()
+			} else {
+				chain.certs = sig.CertificateChain
+			}
+			chains = append(chains, chain)
+		}
+		return chains, nil
+	}
+
+	path := opts.path
+	if opts.storeType != "" {
+		if !truststore.IsValidStoreType(opts.storeType) {
+			return nil, fmt.Errorf("unsupported store type: %s", opts.storeType)
+		}
+		if !truststore.IsValidFileName(opts.namedStore) {
+			return nil, errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
+		}
+		var err error
+		path, err = dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509", opts.storeType, opts.namedStore, opts.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate %s: %w", opts.path, err)
+		}
+	}
+	certs, err := corex509.ReadCertificateFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate %s: %w", opts.path, err)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("failed to read certificate %s: no valid certificate found in the file", opts.path)
+	}
+	return []certificateChain{{source: path, certs: certs}}, nil
+}
+
+// checkChain checks the revocation status of the certificate chain for
+// chainPurpose. A self-signed CA certificate alone, such as a root
+// certificate in the trust store, has no issuer to check its revocation
+// status against, so it is non-revokable.
+func checkChain(ctx context.Context, chain certificateChain, chainPurpose string) metadata.RevocationCheck {
+	check := metadata.RevocationCheck{
+		Source:       chain.source,
+		Purpose:      chainPurpose,
+		Certificates: chain.certs,
+		Err:          chain.err,
+	}
+	if check.Err != nil {
+		return check
+	}
+	if len(chain.certs) == 1 && chain.certs[0].IsCA && certchain.IsSelfSigned(chain.certs[0]) {
+		check.Results = []*result.CertRevocationResult{{
+			Result: result.ResultNonRevokable,
+		}}
+		return check
+	}
+
+	revocationPurpose := purpose.CodeSigning
+	if chainPurpose == purposeTimestamping {
+		revocationPurpose = purpose.Timestamping
+	}
+	validator, recorder, err := clirev.NewRevocationValidatorWithCacheRecorder(ctx, revocationPurpose)
+	if err != nil {
+		check.Err = err
+		return check
+	}
+	check.Results, check.Err = validator.ValidateContext(ctx, revocation.ValidateContextOptions{
+		CertChain: chain.certs,
+	})
+	if check.Err != nil {
+		return check
+	}
+	check.CRLCacheHits = make(map[string]bool)
+	for _, certResult := range check.Results {
+		for _, serverResult := range certResult.ServerResults {
+			if serverResult.RevocationMethod == result.RevocationMethodCRL {
+				check.CRLCacheHits[serverResult.Server] = recorder.Hit(serverResult.Server)
+			}
+		}
+	}
+	return check
+}
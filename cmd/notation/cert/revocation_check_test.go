@@ -0,0 +1,113 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/spf13/pflag"
+)
+
+func TestCertRevocationCheckCommand(t *testing.T) {
+	opts := &certRevocationCheckOpts{}
+	cmd := certRevocationCheckCommand(opts)
+	format := flag.OutputFormatFlagOpts{}
+	format.ApplyFlags(&pflag.FlagSet{}, output.FormatText, output.FormatJSON)
+	format.CurrentFormat = output.FormatJSON
+	expected := &certRevocationCheckOpts{
+		outputFormat: format,
+		storeType:    "tsa",
+		namedStore:   "timestamp",
+		path:         "tsa.crt",
+		purpose:      purposeTimestamping,
+	}
+	if err := cmd.ParseFlags([]string{
+		"-t", "tsa",
+		"-s", "timestamp",
+		"-o", "json",
+		"tsa.crt"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if err := cmd.PreRunE(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("PreRunE failed: %v", err)
+	}
+	opts.printer = nil
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert revocation-check opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertRevocationCheckCommand_Invalid(t *testing.T) {
+	cmd := certRevocationCheckCommand(nil)
+	if err := cmd.ParseFlags([]string{"--from-signature", "blob.sig", "chain.pem"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("expected error when both a certificate file and --from-signature are set")
+	}
+
+	cmd = certRevocationCheckCommand(nil)
+	if err := cmd.ParseFlags([]string{"--purpose", "serverAuth", "chain.pem"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.PreRunE(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("expected error for an unsupported purpose")
+	}
+}
+
+func TestCheckChain(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := checkChain(context.Background(), certificateChain{source: "root.crt", certs: []*x509.Certificate{root}}, purposeCodeSigning)
+	if check.Err != nil {
+		t.Fatalf("checkChain() error = %v", check.Err)
+	}
+	if len(check.Results) != 1 || check.Results[0].Result != result.ResultNonRevokable {
+		t.Fatalf("expected a root certificate to be non-revokable, got %+v", check.Results)
+	}
+}
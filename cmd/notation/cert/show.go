@@ -21,6 +21,9 @@ import (
 	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
 	"github.com/spf13/cobra"
@@ -28,9 +31,11 @@ import (
 
 type certShowOpts struct {
 	flag.LoggingFlagOpts
-	storeType  string
-	namedStore string
-	cert       string
+	outputFormat flag.OutputFormatFlagOpts
+	printer      *output.Printer
+	storeType    string
+	namedStore   string
+	cert         string
 }
 
 func certShowCommand(opts *certShowOpts) *cobra.Command {
@@ -60,12 +65,23 @@ Example - Show details of certificate "cert2.pem" with type "signingAuthority" f
 
 Example - Show details of certificate "wabbit-networks-timestamp.pem" with type "tsa" from trust store "timestamp":
   notation cert show --type tsa --store timestamp wabbit-networks-timestamp.pem
+
+Example - Show details of certificate "cert1.pem" with type "ca" from trust store "acme-rockets" in JSON:
+  notation cert show --type ca --store acme-rockets -o json cert1.pem
 `,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.outputFormat.Validate(cmd); err != nil {
+				return err
+			}
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return showCerts(cmd.Context(), opts)
 		},
 	}
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.outputFormat.ApplyFlags(command.Flags(), output.FormatText, output.FormatJSON)
 	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority, tsa")
 	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store")
 	command.MarkFlagRequired("type")
@@ -78,6 +94,12 @@ func showCerts(ctx context.Context, opts *certShowOpts) error {
 	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
 	logger := log.GetLogger(ctx)
 
+	// initialize display handler
+	displayHandler, err := display.NewCertShowHandler(opts.printer, opts.outputFormat.CurrentFormat)
+	if err != nil {
+		return err
+	}
+
 	storeType := opts.storeType
 	if storeType == "" {
 		return errors.New("store type cannot be empty")
@@ -108,7 +130,11 @@ func showCerts(ctx context.Context, opts *certShowOpts) error {
 	}
 
 	//write out
-	truststore.ShowCerts(certs)
-
-	return nil
+	displayHandler.OnCertificateLoaded(metadata.CertificateFile{
+		StoreType:    storeType,
+		StoreName:    namedStore,
+		Path:         path,
+		Certificates: certs,
+	})
+	return displayHandler.Render()
 }
@@ -16,15 +16,23 @@ package cert
 import (
 	"reflect"
 	"testing"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/spf13/pflag"
 )
 
 func TestCertShowCommand(t *testing.T) {
 	opts := &certShowOpts{}
 	cmd := certShowCommand(opts)
+	format := flag.OutputFormatFlagOpts{}
+	format.ApplyFlags(&pflag.FlagSet{}, output.FormatText, output.FormatJSON)
+	format.CurrentFormat = output.FormatText
 	expected := &certShowOpts{
-		storeType:  "ca",
-		namedStore: "test",
-		cert:       "test.crt",
+		outputFormat: format,
+		storeType:    "ca",
+		namedStore:   "test",
+		cert:         "test.crt",
 	}
 	if err := cmd.ParseFlags([]string{
 		"test.crt",
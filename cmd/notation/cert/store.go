@@ -0,0 +1,243 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/v2/internal/osutil"
+	"github.com/spf13/cobra"
+)
+
+type certStoreRenameOpts struct {
+	store        string
+	newName      string
+	updatePolicy bool
+	confirmed    bool
+}
+
+// policyUpdate is an update of a trust policy configuration renaming the
+// references to a trust store.
+type policyUpdate struct {
+	kind       string
+	path       string
+	original   []byte
+	updated    []byte
+	statements []string
+}
+
+func certStoreCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "store",
+		Short: "Manage named trust stores.",
+		Long:  "Manage named trust stores",
+	}
+	command.AddCommand(certStoreRenameCommand(nil))
+	return command
+}
+
+func certStoreRenameCommand(opts *certStoreRenameOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certStoreRenameOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "rename [flags] <store_type>:<store_name> <new_store_name>",
+		Short: "Rename a named trust store.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("rename requires the trust store in the format \"<store_type>:<store_name>\" and its new name")
+			}
+			opts.store, opts.newName = args[0], args[1]
+			return nil
+		},
+		Long: `Rename a named trust store
+
+The trust policy statements referencing the trust store are not updated unless "--update-policy" is set, in which case the references in both the OCI and the blob trust policy configurations are updated along with the trust store. If any of the updates fails, the trust store and the trust policy configurations are left unchanged. The system level trust policy configurations are never updated, so "--update-policy" fails if a system level statement references the trust store.
+
+Example - Rename trust store "acme" of type "ca" to "acme-prod":
+  notation cert store rename ca:acme acme-prod
+
+Example - Rename trust store "acme" of type "ca" to "acme-prod", and update the trust policy statements referencing "ca:acme" to reference "ca:acme-prod":
+  notation cert store rename --update-policy ca:acme acme-prod
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return renameStore(opts)
+		},
+	}
+	command.Flags().BoolVar(&opts.updatePolicy, "update-policy", false, "update the references to the trust store in the OCI and the blob trust policy configurations")
+	command.Flags().BoolVarP(&opts.confirmed, "yes", "y", false, "do not prompt for confirmation")
+	return command
+}
+
+func renameStore(opts *certStoreRenameOpts) error {
+	storeType, oldName, err := parseTrustStoreName(opts.store)
+	if err != nil {
+		return err
+	}
+	newName := opts.newName
+	if !truststore.IsValidFileName(newName) {
+		return errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
+	}
+	oldRef, newRef := storeType+":"+oldName, storeType+":"+newName
+
+	// prepare the trust policy updates before any change
+	var updates []policyUpdate
+	if opts.updatePolicy {
+		if updates, err = preparePolicyUpdates(oldRef, newRef); err != nil {
+			return err
+		}
+	}
+	prompt := fmt.Sprintf("Are you sure you want to rename %q of type %q to %q?", oldName, storeType, newName)
+	for _, update := range updates {
+		prompt += fmt.Sprintf("\nThe reference to %s will be updated in %s trust policy statement(s): %s", oldRef, update.kind, strings.Join(update.statements, ", "))
+	}
+	confirmed, err := display.AskForConfirmation(os.Stdin, prompt, opts.confirmed)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	// rename the trust store and update the trust policy configurations,
+	// rolling back on failure
+	if err := truststore.RenameStore(storeType, oldName, newName); err != nil {
+		return fmt.Errorf("failed to rename the trust store: %w", err)
+	}
+	if err := applyPolicyUpdates(updates); err != nil {
+		if rollbackErr := truststore.RenameStore(storeType, newName, oldName); rollbackErr != nil {
+			return fmt.Errorf("%w, and failed to restore trust store %s of type %s: %v", err, oldName, storeType, rollbackErr)
+		}
+		return err
+	}
+
+	// write out
+	fmt.Printf("Successfully renamed trust store %s of type %s to %s\n", oldName, storeType, newName)
+	for _, update := range updates {
+		fmt.Printf("Updated the reference to %s in %s trust policy statement(s): %s\n", oldRef, update.kind, strings.Join(update.statements, ", "))
+	}
+	if !opts.updatePolicy {
+		warnTrustStoreReferences(oldRef, `use "--update-policy" to update the references when renaming a trust store`)
+	}
+	return nil
+}
+
+// preparePolicyUpdates prepares the updates of the OCI and the blob trust
+// policy configurations renaming the references to the trust store oldRef to
+// newRef. Configurations that do not exist or do not reference oldRef are not
+// updated. It fails if a system level statement references oldRef, as the
+// system level configurations cannot be updated.
+func preparePolicyUpdates(oldRef, newRef string) ([]policyUpdate, error) {
+	systemStatements, err := loadSystemStatements()
+	if err != nil {
+		return nil, err
+	}
+	if referring := policy.ReferringStatements(systemStatements, oldRef); len(referring) > 0 {
+		return nil, fmt.Errorf("trust store %s is referenced by system trust policy statement(s): %s, which cannot be updated. Ask the administrators of the host to update the system trust policy configurations", oldRef, strings.Join(referring, ", "))
+	}
+
+	var updates []policyUpdate
+
+	// the old OCI trust policy file is used if the new one does not exist
+	for _, policyPath := range []string{dir.PathOCITrustPolicy, dir.PathTrustPolicy} {
+		path, data, err := readPolicyFile(policyPath)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			continue
+		}
+		var doc trustpolicy.OCIDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("malformed OCI trust policy configuration: %w", err)
+		}
+		statements := policy.RenameOCITrustStore(&doc, oldRef, newRef)
+		if len(statements) > 0 {
+			if err := doc.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid OCI trust policy configuration: %w", err)
+			}
+			updated, err := json.MarshalIndent(&doc, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal OCI trust policy: %w", err)
+			}
+			updates = append(updates, policyUpdate{kind: "OCI", path: path, original: data, updated: updated, statements: statements})
+		}
+		break
+	}
+
+	path, data, err := readPolicyFile(dir.PathBlobTrustPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		var doc trustpolicy.BlobDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("malformed blob trust policy configuration: %w", err)
+		}
+		statements := policy.RenameBlobTrustStore(&doc, oldRef, newRef)
+		if len(statements) > 0 {
+			if err := doc.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid blob trust policy configuration: %w", err)
+			}
+			updated, err := json.MarshalIndent(&doc, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal blob trust policy: %w", err)
+			}
+			updates = append(updates, policyUpdate{kind: "blob", path: path, original: data, updated: updated, statements: statements})
+		}
+	}
+	return updates, nil
+}
+
+// applyPolicyUpdates writes the updated trust policy configurations. The
+// configurations already written are restored if any of the writes fails.
+func applyPolicyUpdates(updates []policyUpdate) error {
+	for i, update := range updates {
+		if err := osutil.WriteFileAtomic(update.path, update.updated); err != nil {
+			for _, written := range updates[:i] {
+				osutil.WriteFileAtomic(written.path, written.original)
+			}
+			return fmt.Errorf("failed to write %s trust policy configuration: %w", update.kind, err)
+		}
+	}
+	return nil
+}
+
+// readPolicyFile reads the trust policy configuration at the path relative to
+// the configuration directory, and returns its system path and content. The
+// content is nil if the configuration does not exist.
+func readPolicyFile(policyPath string) (string, []byte, error) {
+	path, err := dir.ConfigFS().SysPath(policyPath)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return path, nil, nil
+		}
+		return "", nil, err
+	}
+	return path, data, nil
+}
@@ -0,0 +1,218 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/internal/layered"
+)
+
+const (
+	testOCIPolicy = `{
+  "version": "1.0",
+  "trustPolicies": [
+    {
+      "name": "oci",
+      "registryScopes": ["*"],
+      "signatureVerification": {"level": "strict"},
+      "trustStores": ["ca:acme", "tsa:timestamp"],
+      "trustedIdentities": ["*"]
+    }
+  ]
+}`
+	testBlobPolicy = `{
+  "version": "1.0",
+  "trustPolicies": [
+    {
+      "name": "blob",
+      "signatureVerification": {"level": "strict"},
+      "trustStores": ["ca:acme"],
+      "trustedIdentities": ["*"]
+    },
+    {
+      "name": "other",
+      "signatureVerification": {"level": "strict"},
+      "trustStores": ["ca:other"],
+      "trustedIdentities": ["*"]
+    }
+  ]
+}`
+)
+
+func TestCertStoreRenameCommand(t *testing.T) {
+	opts := &certStoreRenameOpts{}
+	cmd := certStoreRenameCommand(opts)
+	expected := &certStoreRenameOpts{
+		store:        "ca:acme",
+		newName:      "acme-prod",
+		updatePolicy: true,
+		confirmed:    true,
+	}
+	if err := cmd.ParseFlags([]string{"ca:acme", "acme-prod", "--update-policy", "-y"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert store rename opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertStoreRenameCommand_MissingArgs(t *testing.T) {
+	cmd := certStoreRenameCommand(nil)
+	if err := cmd.ParseFlags([]string{"ca:acme"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+// setupRenameTest sets up the trust store "ca:acme" and the trust policy
+// configurations referencing it.
+func setupRenameTest(t *testing.T) {
+	t.Helper()
+	oldDir, oldSystemDir := dir.UserConfigDir, layered.SystemConfigDir
+	t.Cleanup(func() {
+		dir.UserConfigDir, layered.SystemConfigDir = oldDir, oldSystemDir
+	})
+	dir.UserConfigDir = t.TempDir()
+	layered.SystemConfigDir = t.TempDir()
+	storePath := filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "acme")
+	if err := os.MkdirAll(storePath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join("..", "internal", "truststore", "testdata", "self-signed.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range map[string][]byte{
+		filepath.Join(storePath, "root.crt"):                      data,
+		filepath.Join(dir.UserConfigDir, dir.PathOCITrustPolicy):  []byte(testOCIPolicy),
+		filepath.Join(dir.UserConfigDir, dir.PathBlobTrustPolicy): []byte(testBlobPolicy),
+	} {
+		if err := os.WriteFile(path, content, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRenameStore(t *testing.T) {
+	t.Run("update policy", func(t *testing.T) {
+		setupRenameTest(t)
+		if err := renameStore(&certStoreRenameOpts{store: "ca:acme", newName: "acme-prod", updatePolicy: true, confirmed: true}); err != nil {
+			t.Fatalf("renameStore() error = %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "acme-prod", "root.crt")); err != nil {
+			t.Fatalf("expected renamed trust store: %v", err)
+		}
+		ociDoc, err := trustpolicy.LoadOCIDocument()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ociDoc.TrustPolicies[0].TrustStores; !slices.Equal(got, []string{"ca:acme-prod", "tsa:timestamp"}) {
+			t.Fatalf("unexpected OCI trust stores %v", got)
+		}
+		blobDoc, err := trustpolicy.LoadBlobDocument()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := blobDoc.TrustPolicies[0].TrustStores; !slices.Equal(got, []string{"ca:acme-prod"}) {
+			t.Fatalf("unexpected blob trust stores %v", got)
+		}
+		if got := blobDoc.TrustPolicies[1].TrustStores; !slices.Equal(got, []string{"ca:other"}) {
+			t.Fatalf("unexpected blob trust stores %v", got)
+		}
+	})
+
+	t.Run("without updating policy", func(t *testing.T) {
+		setupRenameTest(t)
+		if err := renameStore(&certStoreRenameOpts{store: "ca:acme", newName: "acme-prod", confirmed: true}); err != nil {
+			t.Fatalf("renameStore() error = %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir.UserConfigDir, dir.PathOCITrustPolicy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != testOCIPolicy {
+			t.Fatalf("expected OCI trust policy to be unchanged, got %s", data)
+		}
+	})
+
+	t.Run("invalid trust policy", func(t *testing.T) {
+		setupRenameTest(t)
+		policyPath := filepath.Join(dir.UserConfigDir, dir.PathBlobTrustPolicy)
+		if err := os.WriteFile(policyPath, []byte("{"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := renameStore(&certStoreRenameOpts{store: "ca:acme", newName: "acme-prod", updatePolicy: true, confirmed: true}); err == nil {
+			t.Fatal("renameStore() expected error, but ok")
+		}
+		if _, err := os.Stat(filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "acme")); err != nil {
+			t.Fatalf("expected the trust store to be unchanged: %v", err)
+		}
+	})
+
+	t.Run("referenced by system policy", func(t *testing.T) {
+		setupRenameTest(t)
+		if err := os.WriteFile(filepath.Join(layered.SystemConfigDir, dir.PathBlobTrustPolicy), []byte(testBlobPolicy), 0600); err != nil {
+			t.Fatal(err)
+		}
+		err := renameStore(&certStoreRenameOpts{store: "ca:acme", newName: "acme-prod", updatePolicy: true, confirmed: true})
+		if err == nil || !strings.Contains(err.Error(), "referenced by system trust policy statement(s): blob") {
+			t.Fatalf("renameStore() expected system policy error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "acme")); err != nil {
+			t.Fatalf("expected the trust store to be unchanged: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir.UserConfigDir, dir.PathOCITrustPolicy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != testOCIPolicy {
+			t.Fatalf("expected OCI trust policy to be unchanged, got %s", data)
+		}
+	})
+}
+
+func TestApplyPolicyUpdates_Rollback(t *testing.T) {
+	tempDir := t.TempDir()
+	writtenPath := filepath.Join(tempDir, "written.json")
+	if err := os.WriteFile(writtenPath, []byte("original"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	err := applyPolicyUpdates([]policyUpdate{
+		{kind: "OCI", path: writtenPath, original: []byte("original"), updated: []byte("updated")},
+		{kind: "blob", path: filepath.Join(writtenPath, "blob.json"), updated: []byte("updated")},
+	})
+	if err == nil {
+		t.Fatal("applyPolicyUpdates() expected error, but ok")
+	}
+	data, err := os.ReadFile(writtenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "original" {
+		t.Fatalf("expected the written configuration to be restored, got %q", data)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
+	"github.com/spf13/cobra"
+)
+
+type certSyncOpts struct {
+	sourceDir string
+	dryRun    bool
+	prune     bool
+	confirmed bool
+}
+
+func certSyncCommand(opts *certSyncOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certSyncOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "sync [flags] <dir>",
+		Short: "Sync the trust store with the certificates in a directory.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("missing directory to sync the trust store with")
+			}
+			if len(args) > 1 {
+				return errors.New("sync only supports single directory")
+			}
+			opts.sourceDir = args[0]
+			return nil
+		},
+		Long: `Sync the trust store with the certificates in a directory
+
+The directory is laid out in the same way as the trust store, as "<type>/<store>/<file>", for example, "ca/acme-rockets/root.pem", "signingAuthority/wabbit-networks/cert.pem" or "tsa/timestamp/tsa.crt". Directories of other names, subdirectories of the named stores, and hidden files such as ".gitkeep" are ignored. Every other file in the named stores must be a valid certificate file.
+
+The certificate files that are not in the trust store or are of different content are added or updated. With "--prune", the certificate files in the trust store that are not in the directory are deleted, so that the trust store contains exactly the certificates in the directory. The changes are shown for confirmation, and then applied all at once.
+
+Example - Sync the trust store with a directory, adding and updating certificate files:
+  notation cert sync ./truststore
+
+Example - Show the changes to make the trust store contain exactly the certificates in a directory, without applying them:
+  notation cert sync --prune --dry-run ./truststore
+
+Example - Make the trust store contain exactly the certificates in a directory, without prompt for confirmation:
+  notation cert sync --prune -y ./truststore
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return syncCerts(opts)
+		},
+	}
+	command.Flags().BoolVar(&opts.dryRun, "dry-run", false, "show the changes without applying them")
+	command.Flags().BoolVar(&opts.prune, "prune", false, "delete the certificate files in the trust store that are not in the directory")
+	command.Flags().BoolVarP(&opts.confirmed, "yes", "y", false, "do not prompt for confirmation")
+	return command
+}
+
+func syncCerts(opts *certSyncOpts) error {
+	info, err := os.Stat(opts.sourceDir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", opts.sourceDir)
+	}
+	changes, err := truststore.PlanSync(opts.sourceDir, opts.prune)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Println("The trust store is in sync with", opts.sourceDir)
+		return nil
+	}
+
+	// show the changes
+	counts := make(map[truststore.SyncAction]int)
+	fmt.Println("Trust store changes:")
+	for _, change := range changes {
+		counts[change.Action]++
+		fmt.Printf("  %-6s %s\n", change.Action, change.Path())
+	}
+	summary := fmt.Sprintf("%d to add, %d to update, %d to delete", counts[truststore.SyncAdd], counts[truststore.SyncUpdate], counts[truststore.SyncDelete])
+	if opts.dryRun {
+		fmt.Printf("Dry run: %s\n", summary)
+		return nil
+	}
+	confirmed, err := display.AskForConfirmation(os.Stdin, fmt.Sprintf("Do you want to apply the changes (%s)?", summary), opts.confirmed)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	// apply
+	if err := truststore.ApplySync(changes); err != nil {
+		return fmt.Errorf("failed to sync the trust store, no change is applied: %w", err)
+	}
+	fmt.Printf("Successfully synced the trust store with %s: %d added, %d updated, %d deleted\n", opts.sourceDir, counts[truststore.SyncAdd], counts[truststore.SyncUpdate], counts[truststore.SyncDelete])
+	return nil
+}
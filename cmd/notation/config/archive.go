@@ -0,0 +1,198 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	// archiveManifestPath is the path of the checksum manifest in the
+	// configuration archive.
+	archiveManifestPath = "manifest.json"
+
+	// archiveVersion is the version of the configuration archive.
+	archiveVersion = "1.0"
+
+	// mediaTypeSigningKeys is the media type of the plugin signing keys in
+	// the configuration archive.
+	mediaTypeSigningKeys = "application/vnd.cncf.notary.signingkeys.v1+json"
+
+	// maxArchiveEntries is the maximum number of entries in the configuration
+	// archive.
+	maxArchiveEntries = 10000
+)
+
+// archiveManifest is the checksum manifest of the configuration archive.
+type archiveManifest struct {
+	// Version is the version of the configuration archive.
+	Version string `json:"version"`
+
+	// Files are the files in the configuration archive.
+	Files []archiveFile `json:"files"`
+}
+
+// archiveFile is a file listed in the checksum manifest.
+type archiveFile struct {
+	Path      string        `json:"path"`
+	MediaType string        `json:"mediaType"`
+	Digest    digest.Digest `json:"digest"`
+	Size      int64         `json:"size"`
+}
+
+// writeArchive writes files and their checksum manifest to w as a gzip
+// compressed tar archive.
+func writeArchive(w io.Writer, files []policy.BundleFile) error {
+	manifest := archiveManifest{
+		Version: archiveVersion,
+		Files:   make([]archiveFile, 0, len(files)),
+	}
+	for _, file := range files {
+		manifest.Files = append(manifest.Files, archiveFile{
+			Path:      file.Path,
+			MediaType: file.MediaType,
+			Digest:    digest.FromBytes(file.Content),
+			Size:      int64(len(file.Content)),
+		})
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	modTime := time.Now()
+	writeEntry := func(name string, content []byte) error {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(content)),
+			ModTime:  modTime,
+		}); err != nil {
+			return err
+		}
+		_, err := tarWriter.Write(content)
+		return err
+	}
+	if err := writeEntry(archiveManifestPath, manifestJSON); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := writeEntry(file.Path, file.Content); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// readArchive reads the files of the configuration archive from r, and
+// verifies them against the checksum manifest. Files not listed in the
+// checksum manifest are rejected.
+func readArchive(r io.Reader) ([]policy.BundleFile, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration archive: %w", err)
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+
+	contents := make(map[string][]byte)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("unsupported entry %s in the configuration archive: only regular files are supported", header.Name)
+		}
+		if !fs.ValidPath(header.Name) || header.Name == "." {
+			return nil, fmt.Errorf("invalid path %s in the configuration archive", header.Name)
+		}
+		if _, ok := contents[header.Name]; ok {
+			return nil, fmt.Errorf("duplicate entry %s in the configuration archive", header.Name)
+		}
+		if len(contents) >= maxArchiveEntries {
+			return nil, fmt.Errorf("the configuration archive exceeds the maximum number of %d entries", maxArchiveEntries)
+		}
+		if header.Size > policy.MaxBundleFileSize {
+			return nil, fmt.Errorf("entry %s in the configuration archive exceeds the maximum size of %d bytes", header.Name, policy.MaxBundleFileSize)
+		}
+		content, err := io.ReadAll(io.LimitReader(tarReader, policy.MaxBundleFileSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from the configuration archive: %w", header.Name, err)
+		}
+		contents[header.Name] = content
+	}
+
+	// verify against the checksum manifest
+	manifestJSON, ok := contents[archiveManifestPath]
+	if !ok {
+		return nil, fmt.Errorf("the configuration archive has no %s", archiveManifestPath)
+	}
+	delete(contents, archiveManifestPath)
+	var manifest archiveManifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s of the configuration archive: %w", archiveManifestPath, err)
+	}
+	if manifest.Version != archiveVersion {
+		return nil, fmt.Errorf("unsupported configuration archive version %q", manifest.Version)
+	}
+	files := make([]policy.BundleFile, 0, len(manifest.Files))
+	for _, file := range manifest.Files {
+		content, ok := contents[file.Path]
+		if !ok {
+			return nil, fmt.Errorf("file %s listed in %s is missing in the configuration archive", file.Path, archiveManifestPath)
+		}
+		delete(contents, file.Path)
+		if int64(len(content)) != file.Size {
+			return nil, fmt.Errorf("size mismatch of %s: expected %d bytes, got %d bytes", file.Path, file.Size, len(content))
+		}
+		if err := file.Digest.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid digest of %s: %w", file.Path, err)
+		}
+		if actual := file.Digest.Algorithm().FromBytes(content); actual != file.Digest {
+			return nil, fmt.Errorf("checksum mismatch of %s: expected %s, got %s", file.Path, file.Digest, actual)
+		}
+		files = append(files, policy.BundleFile{
+			Path:      file.Path,
+			MediaType: file.MediaType,
+			Content:   content,
+		})
+	}
+	for name := range contents {
+		return nil, fmt.Errorf("file %s is not listed in %s of the configuration archive", name, archiveManifestPath)
+	}
+	return files, nil
+}
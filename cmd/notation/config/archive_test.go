@@ -0,0 +1,164 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	files := []policy.BundleFile{
+		{Path: "trustpolicy.oci.json", MediaType: policy.MediaTypeOCITrustPolicy, Content: []byte(`{"version":"1.0"}`)},
+		{Path: "truststore/x509/ca/test/test.crt", MediaType: policy.MediaTypeCertificate, Content: []byte("cert")},
+	}
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, files); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readArchive(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, files) {
+		t.Fatalf("readArchive() = %v, want %v", got, files)
+	}
+}
+
+// rewriteArchive rewrites the entries of a configuration archive with edit.
+func rewriteArchive(t *testing.T, archive []byte, edit func(name string, content []byte) (string, []byte), extra map[string][]byte) []byte {
+	t.Helper()
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	write := func(name string, content []byte) {
+		if err := tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0600, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			break
+		}
+		var content bytes.Buffer
+		if _, err := content.ReadFrom(tarReader); err != nil {
+			t.Fatal(err)
+		}
+		name, data := edit(header.Name, content.Bytes())
+		write(name, data)
+	}
+	for name, content := range extra {
+		write(name, content)
+	}
+	tarWriter.Close()
+	gzipWriter.Close()
+	return buf.Bytes()
+}
+
+func TestReadArchiveInvalid(t *testing.T) {
+	files := []policy.BundleFile{
+		{Path: "trustpolicy.oci.json", MediaType: policy.MediaTypeOCITrustPolicy, Content: []byte(`{"version":"1.0"}`)},
+	}
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, files); err != nil {
+		t.Fatal(err)
+	}
+	archive := buf.Bytes()
+	keep := func(name string, content []byte) (string, []byte) { return name, content }
+
+	tests := []struct {
+		name    string
+		archive []byte
+		wantErr string
+	}{
+		{
+			name: "checksum mismatch",
+			archive: rewriteArchive(t, archive, func(name string, content []byte) (string, []byte) {
+				if name == "trustpolicy.oci.json" {
+					return name, []byte(`{"version":"2.0"}`)
+				}
+				return name, content
+			}, nil),
+			wantErr: "checksum mismatch of trustpolicy.oci.json",
+		},
+		{
+			name: "size mismatch",
+			archive: rewriteArchive(t, archive, func(name string, content []byte) (string, []byte) {
+				if name == "trustpolicy.oci.json" {
+					return name, []byte(`{}`)
+				}
+				return name, content
+			}, nil),
+			wantErr: "size mismatch of trustpolicy.oci.json",
+		},
+		{
+			name:    "unlisted file",
+			archive: rewriteArchive(t, archive, keep, map[string][]byte{"trustpolicy.blob.json": []byte("{}")}),
+			wantErr: "file trustpolicy.blob.json is not listed in manifest.json",
+		},
+		{
+			name:    "path traversal",
+			archive: rewriteArchive(t, archive, keep, map[string][]byte{"../trustpolicy.blob.json": []byte("{}")}),
+			wantErr: "invalid path ../trustpolicy.blob.json",
+		},
+		{
+			name: "missing file",
+			archive: rewriteArchive(t, archive, func(name string, content []byte) (string, []byte) {
+				if name == "trustpolicy.oci.json" {
+					return "trustpolicy.json", content
+				}
+				return name, content
+			}, nil),
+			wantErr: "file trustpolicy.oci.json listed in manifest.json is missing",
+		},
+		{
+			name: "missing manifest",
+			archive: rewriteArchive(t, archive, func(name string, content []byte) (string, []byte) {
+				if name == archiveManifestPath {
+					return "manifest.bak", content
+				}
+				return name, content
+			}, nil),
+			wantErr: "the configuration archive has no manifest.json",
+		},
+		{
+			name:    "not an archive",
+			archive: []byte("not an archive"),
+			wantErr: "failed to read configuration archive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readArchive(bytes.NewReader(tt.archive))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("readArchive() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+// changeKind is the kind of a change to the configuration.
+type changeKind string
+
+const (
+	changeAdd    changeKind = "+"
+	changeUpdate changeKind = "~"
+	changeDelete changeKind = "-"
+)
+
+// change is a change to the configuration made by importing a configuration
+// archive.
+type change struct {
+	Kind   changeKind
+	Target string
+}
+
+// String returns the change in the format of "<kind> <target>".
+func (c change) String() string {
+	return string(c.Kind) + " " + c.Target
+}
+
+// fileChanges returns the changes of installing files into the configuration
+// directory root. Unchanged files are omitted.
+func fileChanges(root string, files []policy.BundleFile) ([]change, error) {
+	var changes []change
+	compare := func(relPath string, content []byte) error {
+		existing, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(relPath)))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			changes = append(changes, change{Kind: changeAdd, Target: relPath})
+		case err != nil:
+			return err
+		case !bytes.Equal(existing, content):
+			changes = append(changes, change{Kind: changeUpdate, Target: relPath})
+		}
+		return nil
+	}
+
+	for _, target := range policy.InstalledPaths(files) {
+		if !strings.HasPrefix(target, dir.TrustStoreDir+"/") {
+			for _, file := range files {
+				if file.Path == target {
+					if err := compare(file.Path, file.Content); err != nil {
+						return nil, err
+					}
+				}
+			}
+			continue
+		}
+
+		// the trust store is replaced as a whole
+		var newNames []string
+		for _, file := range files {
+			if path.Dir(file.Path) == target {
+				newNames = append(newNames, path.Base(file.Path))
+				if err := compare(file.Path, file.Content); err != nil {
+					return nil, err
+				}
+			}
+		}
+		entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(target)))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !slices.Contains(newNames, entry.Name()) {
+				changes = append(changes, change{Kind: changeDelete, Target: path.Join(target, entry.Name())})
+			}
+		}
+	}
+
+	// the old trust policy is removed when installing the OCI trust policy
+	if slices.ContainsFunc(files, func(file policy.BundleFile) bool { return file.Path == dir.PathOCITrustPolicy }) {
+		if _, err := os.Lstat(filepath.Join(root, dir.PathTrustPolicy)); err == nil {
+			changes = append(changes, change{Kind: changeDelete, Target: dir.PathTrustPolicy})
+		}
+	}
+	return changes, nil
+}
+
+// pluginSigningKeys returns the signing keys of signingKeys backed by
+// plugins. Local signing keys are host specific and excluded.
+func pluginSigningKeys(signingKeys *config.SigningKeys) []config.KeySuite {
+	var keys []config.KeySuite
+	for _, key := range signingKeys.Keys {
+		if key.ExternalKey != nil && key.X509KeyPair == nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// validatePluginSigningKeys validates that keys are uniquely named signing
+// keys backed by plugins.
+func validatePluginSigningKeys(keys []config.KeySuite) error {
+	names := make(map[string]bool)
+	for _, key := range keys {
+		if key.Name == "" {
+			return errors.New("signing key name cannot be empty")
+		}
+		if names[key.Name] {
+			return fmt.Errorf("duplicate signing key %q", key.Name)
+		}
+		names[key.Name] = true
+		if key.X509KeyPair != nil || key.ExternalKey == nil || key.ExternalKey.ID == "" || key.ExternalKey.PluginName == "" {
+			return fmt.Errorf("signing key %q is not a plugin signing key with a key id and a plugin name", key.Name)
+		}
+	}
+	return nil
+}
+
+// mergeSigningKeys merges the plugin signing keys into signingKeys and
+// returns the changes. Unchanged keys are omitted. A local signing key of the
+// same name as a plugin signing key is not overwritten.
+func mergeSigningKeys(signingKeys *config.SigningKeys, keys []config.KeySuite) ([]change, error) {
+	var changes []change
+	for _, key := range keys {
+		target := fmt.Sprintf("signing key %q (plugin %q)", key.Name, key.PluginName)
+		idx := slices.IndexFunc(signingKeys.Keys, func(existing config.KeySuite) bool { return existing.Name == key.Name })
+		switch {
+		case idx < 0:
+			signingKeys.Keys = append(signingKeys.Keys, key)
+			changes = append(changes, change{Kind: changeAdd, Target: target})
+		case signingKeys.Keys[idx].ExternalKey == nil:
+			return nil, fmt.Errorf("signing key %q conflicts with the local signing key of the same name", key.Name)
+		case !reflect.DeepEqual(signingKeys.Keys[idx], key):
+			signingKeys.Keys[idx] = key
+			changes = append(changes, change{Kind: changeUpdate, Target: target})
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,128 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+func TestFileChanges(t *testing.T) {
+	root := t.TempDir()
+	writeFile := func(relPath, content string) {
+		t.Helper()
+		path := filepath.Join(root, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("trustpolicy.json", "old")
+	writeFile("trustpolicy.blob.json", "blob")
+	writeFile("truststore/x509/ca/test/a.crt", "a")
+	writeFile("truststore/x509/ca/test/b.crt", "b")
+	writeFile("truststore/x509/ca/other/c.crt", "c")
+
+	files := []policy.BundleFile{
+		{Path: "trustpolicy.oci.json", MediaType: policy.MediaTypeOCITrustPolicy, Content: []byte("oci")},
+		{Path: "trustpolicy.blob.json", MediaType: policy.MediaTypeBlobTrustPolicy, Content: []byte("blob")},
+		{Path: "truststore/x509/ca/test/a.crt", MediaType: policy.MediaTypeCertificate, Content: []byte("a2")},
+		{Path: "truststore/x509/signingAuthority/test/d.crt", MediaType: policy.MediaTypeCertificate, Content: []byte("d")},
+	}
+	got, err := fileChanges(root, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []change{
+		{Kind: changeAdd, Target: "trustpolicy.oci.json"},
+		{Kind: changeUpdate, Target: "truststore/x509/ca/test/a.crt"},
+		{Kind: changeDelete, Target: "truststore/x509/ca/test/b.crt"},
+		{Kind: changeAdd, Target: "truststore/x509/signingAuthority/test/d.crt"},
+		{Kind: changeDelete, Target: "trustpolicy.json"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("fileChanges() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeSigningKeys(t *testing.T) {
+	pluginKey := func(name, id string) config.KeySuite {
+		return config.KeySuite{
+			Name:        name,
+			ExternalKey: &config.ExternalKey{ID: id, PluginName: "test-plugin"},
+		}
+	}
+
+	t.Run("add and update", func(t *testing.T) {
+		signingKeys := &config.SigningKeys{
+			Keys: []config.KeySuite{pluginKey("same", "1"), pluginKey("updated", "1")},
+		}
+		changes, err := mergeSigningKeys(signingKeys, []config.KeySuite{
+			pluginKey("same", "1"), pluginKey("updated", "2"), pluginKey("added", "1"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []change{
+			{Kind: changeUpdate, Target: `signing key "updated" (plugin "test-plugin")`},
+			{Kind: changeAdd, Target: `signing key "added" (plugin "test-plugin")`},
+		}
+		if !reflect.DeepEqual(changes, want) {
+			t.Fatalf("mergeSigningKeys() = %v, want %v", changes, want)
+		}
+		if len(signingKeys.Keys) != 3 || signingKeys.Keys[1].ID != "2" {
+			t.Fatalf("unexpected signing keys: %+v", signingKeys.Keys)
+		}
+	})
+
+	t.Run("conflict with local key", func(t *testing.T) {
+		signingKeys := &config.SigningKeys{
+			Keys: []config.KeySuite{{Name: "local", X509KeyPair: &config.X509KeyPair{KeyPath: "k", CertificatePath: "c"}}},
+		}
+		if _, err := mergeSigningKeys(signingKeys, []config.KeySuite{pluginKey("local", "1")}); err == nil {
+			t.Fatal("expected error for conflicting local signing key")
+		}
+	})
+}
+
+func TestValidatePluginSigningKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []config.KeySuite
+		wantErr bool
+	}{
+		{name: "valid", keys: []config.KeySuite{{Name: "a", ExternalKey: &config.ExternalKey{ID: "1", PluginName: "p"}}}},
+		{name: "empty name", keys: []config.KeySuite{{ExternalKey: &config.ExternalKey{ID: "1", PluginName: "p"}}}, wantErr: true},
+		{name: "duplicate", keys: []config.KeySuite{
+			{Name: "a", ExternalKey: &config.ExternalKey{ID: "1", PluginName: "p"}},
+			{Name: "a", ExternalKey: &config.ExternalKey{ID: "2", PluginName: "p"}},
+		}, wantErr: true},
+		{name: "local key", keys: []config.KeySuite{{Name: "a", X509KeyPair: &config.X509KeyPair{KeyPath: "k", CertificatePath: "c"}}}, wantErr: true},
+		{name: "missing plugin name", keys: []config.KeySuite{{Name: "a", ExternalKey: &config.ExternalKey{ID: "1"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePluginSigningKeys(tt.keys); (err != nil) != tt.wantErr {
+				t.Fatalf("validatePluginSigningKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
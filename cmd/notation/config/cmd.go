@@ -0,0 +1,33 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config provides the commands to export and import the Notation
+// configuration.
+package config
+
+import "github.com/spf13/cobra"
+
+func Cmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "config [command]",
+		Short: "Export and import Notation configuration",
+		Long:  "Export and import the trust stores, the trust policy configurations and the plugin signing keys of Notation for provisioning machines.",
+	}
+
+	command.AddCommand(
+		exportCmd(),
+		importCmd(),
+	)
+
+	return command
+}
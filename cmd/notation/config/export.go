@@ -0,0 +1,107 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/notaryproject/notation/v2/internal/osutil"
+	"github.com/spf13/cobra"
+)
+
+type exportOpts struct {
+	archivePath         string
+	includePluginConfig bool
+	force               bool
+}
+
+func exportCmd() *cobra.Command {
+	var opts exportOpts
+	command := &cobra.Command{
+		Use:   "export [flags] <archive_path>",
+		Short: "Export the trust stores and the trust policy configurations to an archive",
+		Long: `Export the trust stores and the trust policy configurations to an archive.
+
+The archive is a gzip compressed tar file holding all trust stores, the OCI and the blob trust policy configurations, and a checksum manifest "manifest.json". Use "notation config import" to import the archive on another machine.
+
+Example - Export the configuration to "notation-config.tar.gz":
+  notation config export notation-config.tar.gz
+
+Example - Export the configuration with the signing keys backed by plugins:
+  notation config export --include-plugin-config notation-config.tar.gz
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing archive path: use `notation config export --help` to see what parameters are required")
+			}
+			opts.archivePath = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(&opts)
+		},
+	}
+	command.Flags().BoolVar(&opts.includePluginConfig, "include-plugin-config", false, "include the signing keys backed by plugins. Local signing keys are never exported")
+	command.Flags().BoolVar(&opts.force, "force", false, "override the existing archive file")
+	return command
+}
+
+func runExport(opts *exportOpts) error {
+	trustStores, err := policy.ListTrustStores(dir.ConfigFS())
+	if err != nil {
+		return err
+	}
+	files, err := policy.CollectBundle(dir.ConfigFS(), trustStores)
+	if err != nil {
+		return fmt.Errorf("failed to export configuration: %w", err)
+	}
+	if opts.includePluginConfig {
+		signingKeys, err := config.LoadSigningKeys()
+		if err != nil {
+			return fmt.Errorf("failed to load signing keys: %w", err)
+		}
+		if keys := pluginSigningKeys(signingKeys); len(keys) > 0 {
+			content, err := json.MarshalIndent(config.SigningKeys{Keys: keys}, "", "    ")
+			if err != nil {
+				return err
+			}
+			files = append(files, policy.BundleFile{Path: dir.PathSigningKeys, MediaType: mediaTypeSigningKeys, Content: content})
+		} else {
+			fmt.Fprintln(os.Stderr, "Warning: no signing key backed by a plugin is configured")
+		}
+	}
+
+	var archive bytes.Buffer
+	if err := writeArchive(&archive, files); err != nil {
+		return fmt.Errorf("failed to create configuration archive: %w", err)
+	}
+	if err := osutil.WriteFileWithPermission(opts.archivePath, archive.Bytes(), 0600, opts.force); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("archive %s already exists, use --force to override it", opts.archivePath)
+		}
+		return fmt.Errorf("failed to write configuration archive: %w", err)
+	}
+	for _, file := range files {
+		fmt.Printf("Exported %s\n", file.Path)
+	}
+	fmt.Printf("Successfully exported configuration to %s\n", opts.archivePath)
+	return nil
+}
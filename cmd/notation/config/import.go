@@ -0,0 +1,167 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+type importOpts struct {
+	archivePath string
+	dryRun      bool
+	force       bool
+}
+
+func importCmd() *cobra.Command {
+	var opts importOpts
+	command := &cobra.Command{
+		Use:   "import [flags] <archive_path>",
+		Short: "Import the configuration from an archive created by \"notation config export\"",
+		Long: `Import the configuration from an archive created by "notation config export".
+
+The files in the archive are verified against the checksum manifest before anything is applied. The trust policy configurations in the archive replace the existing ones, and the trust stores in the archive replace the existing trust stores of the same type and name. The signing keys backed by plugins are added to, or updated in, the signing key list.
+
+Example - Show the changes of importing an archive without applying them:
+  notation config import --dry-run notation-config.tar.gz
+
+Example - Import an archive without prompt:
+  notation config import --force notation-config.tar.gz
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing archive path: use `notation config import --help` to see what parameters are required")
+			}
+			opts.archivePath = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(&opts)
+		},
+	}
+	command.Flags().BoolVar(&opts.dryRun, "dry-run", false, "show the changes without applying them")
+	command.Flags().BoolVar(&opts.force, "force", false, "override the existing configuration without prompt")
+	command.MarkFlagsMutuallyExclusive("dry-run", "force")
+	return command
+}
+
+func runImport(opts *importOpts) error {
+	// read and verify the archive
+	archive, err := os.Open(opts.archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open configuration archive: %w", err)
+	}
+	defer archive.Close()
+	files, err := readArchive(archive)
+	if err != nil {
+		return err
+	}
+	var bundleFiles []policy.BundleFile
+	var keys []config.KeySuite
+	for _, file := range files {
+		if file.Path != dir.PathSigningKeys {
+			bundleFiles = append(bundleFiles, file)
+			continue
+		}
+		if file.MediaType != mediaTypeSigningKeys {
+			return fmt.Errorf("file %s in the configuration archive has unexpected media type %q", file.Path, file.MediaType)
+		}
+		var signingKeys config.SigningKeys
+		if err := json.Unmarshal(file.Content, &signingKeys); err != nil {
+			return fmt.Errorf("failed to parse %s of the configuration archive: %w", file.Path, err)
+		}
+		if err := validatePluginSigningKeys(signingKeys.Keys); err != nil {
+			return fmt.Errorf("invalid %s in the configuration archive: %w", file.Path, err)
+		}
+		keys = signingKeys.Keys
+	}
+	if err := policy.ValidateBundle(bundleFiles); err != nil {
+		return err
+	}
+
+	// compute the changes
+	root, err := dir.ConfigFS().SysPath("")
+	if err != nil {
+		return err
+	}
+	changes, err := fileChanges(root, bundleFiles)
+	if err != nil {
+		return fmt.Errorf("failed to compare configuration: %w", err)
+	}
+	var signingKeys *config.SigningKeys
+	var keyChanges []change
+	if len(keys) > 0 {
+		signingKeys, err = config.LoadSigningKeys()
+		if err != nil {
+			return fmt.Errorf("failed to load signing keys: %w", err)
+		}
+		keyChanges, err = mergeSigningKeys(signingKeys, keys)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, keyChanges...)
+	}
+	if len(changes) == 0 {
+		fmt.Println("The configuration is up to date, no changes to apply.")
+		return nil
+	}
+	var added, updated, deleted int
+	for _, c := range changes {
+		fmt.Println(c)
+		switch c.Kind {
+		case changeAdd:
+			added++
+		case changeUpdate:
+			updated++
+		case changeDelete:
+			deleted++
+		}
+	}
+	summary := fmt.Sprintf("%d to add, %d to update, %d to delete", added, updated, deleted)
+	if opts.dryRun {
+		fmt.Printf("Dry run: %s. No changes applied.\n", summary)
+		return nil
+	}
+
+	// optional confirmation
+	if updated+deleted > 0 {
+		confirmed, err := display.AskForConfirmation(os.Stdin, fmt.Sprintf("Importing the configuration archive will make the changes above (%s). Do you want to continue?", summary), opts.force)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	// apply
+	if err := policy.InstallBundle(root, bundleFiles); err != nil {
+		return fmt.Errorf("failed to import configuration: %w", err)
+	}
+	if len(keyChanges) > 0 {
+		if err := signingKeys.Save(); err != nil {
+			return fmt.Errorf("failed to save signing keys: %w", err)
+		}
+	}
+	fmt.Printf("Successfully imported configuration from %s: %d added, %d updated, %d deleted.\n", opts.archivePath, added, updated, deleted)
+	return nil
+}
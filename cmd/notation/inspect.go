@@ -23,6 +23,7 @@ import (
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
 	cmderr "github.com/notaryproject/notation/v2/cmd/notation/internal/errors"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	registryutil "github.com/notaryproject/notation/v2/cmd/notation/internal/registry"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 )
@@ -98,7 +99,7 @@ func runInspect(command *cobra.Command, opts *inspectOpts) error {
 	reference := opts.reference
 	// always use the Referrers API, if not supported, automatically fallback to
 	// the referrers tag schema
-	sigRepo, err := getRemoteRepository(ctx, &opts.SecureFlagOpts, reference, false)
+	sigRepo, err := registryutil.GetRemoteRepository(ctx, &opts.SecureFlagOpts, reference, false)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,284 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certchain extracts the certificate chains from signatures, so that
+// trust configuration can be derived from signed artifacts.
+package certchain
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	notationregistry "github.com/notaryproject/notation-go/registry"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	registryutil "github.com/notaryproject/notation/v2/cmd/notation/internal/registry"
+	"github.com/notaryproject/notation/v2/internal/envelope"
+	"github.com/notaryproject/tspclient-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry"
+)
+
+// Signature is a signature with the certificate chains it carries.
+type Signature struct {
+	// Source is where the signature comes from, which is the digest of the
+	// signature manifest or the path of the signature file.
+	Source string
+
+	// SigningScheme is the signing scheme of the signature.
+	SigningScheme signature.SigningScheme
+
+	// CertificateChain is the signing certificate chain, starting from the
+	// signing certificate.
+	CertificateChain []*x509.Certificate
+
+	// TimestampCertificates are the certificates carried by the timestamp
+	// countersignature, if any.
+	TimestampCertificates []*x509.Certificate
+}
+
+// Parse parses the signature envelope of media type mediaType.
+func Parse(source, mediaType string, envelopeBytes []byte) (*Signature, error) {
+	sigEnv, err := signature.ParseEnvelope(mediaType, envelopeBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature %s: %w", source, err)
+	}
+	envelopeContent, err := sigEnv.Content()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature %s: %w", source, err)
+	}
+	signerInfo := envelopeContent.SignerInfo
+	if len(signerInfo.CertificateChain) == 0 {
+		return nil, fmt.Errorf("signature %s has no certificate chain", source)
+	}
+	sig := &Signature{
+		Source:           source,
+		SigningScheme:    signerInfo.SignedAttributes.SigningScheme,
+		CertificateChain: signerInfo.CertificateChain,
+	}
+	if timestamp := signerInfo.UnsignedAttributes.TimestampSignature; timestamp != nil {
+		signedToken, err := tspclient.ParseSignedToken(timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse timestamp countersignature of signature %s: %w", source, err)
+		}
+		sig.TimestampCertificates = signedToken.Certificates
+	}
+	return sig, nil
+}
+
+// ReadFile reads the signature from a signature file or a signature bundle
+// produced by `notation blob sign`.
+func ReadFile(path string) (*Signature, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature file: %w", err)
+	}
+	var bundle struct {
+		MediaType string `json:"mediaType"`
+		Signature struct {
+			MediaType string `json:"mediaType"`
+			Envelope  []byte `json:"envelope"`
+		} `json:"signature"`
+	}
+	if json.Unmarshal(content, &bundle) == nil && bundle.MediaType == envelope.MediaTypeSignatureBundle {
+		return Parse(path, bundle.Signature.MediaType, bundle.Signature.Envelope)
+	}
+	mediaType, err := envelope.DetectMediaType(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine the format of signature file %s: %w", path, err)
+	}
+	return Parse(path, mediaType, content)
+}
+
+// errMaxSignatures is returned to stop listing signatures.
+var errMaxSignatures = errors.New("maximum number of signatures reached")
+
+// Fetch fetches and parses at most maxSignatures signatures of the artifact
+// described by artifactDesc.
+func Fetch(ctx context.Context, repo notationregistry.Repository, artifactDesc ocispec.Descriptor, maxSignatures int) ([]*Signature, error) {
+	var sigManifestDescs []ocispec.Descriptor
+	err := repo.ListSignatures(ctx, artifactDesc, func(signatureManifests []ocispec.Descriptor) error {
+		for _, sigManifestDesc := range signatureManifests {
+			if len(sigManifestDescs) >= maxSignatures {
+				return errMaxSignatures
+			}
+			sigManifestDescs = append(sigManifestDescs, sigManifestDesc)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errMaxSignatures) {
+		return nil, fmt.Errorf("failed to list the signatures: %w", err)
+	}
+
+	signatures := make([]*Signature, 0, len(sigManifestDescs))
+	for _, sigManifestDesc := range sigManifestDescs {
+		sigBlob, sigDesc, err := repo.FetchSignatureBlob(ctx, sigManifestDesc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch signature %s: %w", sigManifestDesc.Digest, err)
+		}
+		sig, err := Parse(sigManifestDesc.Digest.String(), sigDesc.MediaType, sigBlob)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, sig)
+	}
+	return signatures, nil
+}
+
+// FetchReference resolves the artifact referenced by reference, and fetches
+// and parses at most maxSignatures of its signatures. The reference is
+// returned with the tag resolved to the digest.
+func FetchReference(ctx context.Context, opts *flag.SecureFlagOpts, reference string, maxSignatures int) ([]*Signature, registry.Reference, error) {
+	ref, err := registry.ParseReference(reference)
+	if err != nil {
+		return nil, registry.Reference{}, fmt.Errorf("%q: %w. Expecting <registry>/<repository>:<tag> or <registry>/<repository>@<digest>", reference, err)
+	}
+	if ref.Reference == "" {
+		return nil, registry.Reference{}, fmt.Errorf("%q: invalid reference: no tag or digest. Expecting <registry>/<repository>:<tag> or <registry>/<repository>@<digest>", reference)
+	}
+	repo, err := registryutil.GetRepositoryClient(ctx, opts, ref)
+	if err != nil {
+		return nil, registry.Reference{}, err
+	}
+	manifestDesc, err := repo.Resolve(ctx, ref.Reference)
+	if err != nil {
+		return nil, registry.Reference{}, fmt.Errorf("failed to resolve %s: %w", reference, err)
+	}
+	ref.Reference = manifestDesc.Digest.String()
+	signatures, err := Fetch(ctx, notationregistry.NewRepository(repo), manifestDesc, maxSignatures)
+	if err != nil {
+		return nil, registry.Reference{}, err
+	}
+	if len(signatures) == 0 {
+		return nil, registry.Reference{}, fmt.Errorf("no signature is associated with %s", ref)
+	}
+	return signatures, ref, nil
+}
+
+// Root returns the root certificate of the signing certificate chain, which
+// is the self-signed certificate at the end of the chain.
+func (s *Signature) Root() (*x509.Certificate, error) {
+	root := s.CertificateChain[len(s.CertificateChain)-1]
+	if !IsSelfSigned(root) {
+		return nil, fmt.Errorf("the certificate chain of signature %s does not end with a self-signed root certificate", s.Source)
+	}
+	return root, nil
+}
+
+// TimestampRoots returns the self-signed certificates carried by the
+// timestamp countersignature.
+func (s *Signature) TimestampRoots() []*x509.Certificate {
+	var roots []*x509.Certificate
+	for _, cert := range s.TimestampCertificates {
+		if IsSelfSigned(cert) {
+			roots = append(roots, cert)
+		}
+	}
+	return roots
+}
+
+// TimestampChain returns the certificate chain of the timestamp
+// countersignature, starting from the timestamping certificate. The
+// certificates carried by the countersignature are not ordered, so the chain
+// is built by following the issuers of the certificate that issues no other
+// certificate.
+func (s *Signature) TimestampChain() ([]*x509.Certificate, error) {
+	certs := s.TimestampCertificates
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("signature %s has no timestamp countersignature", s.Source)
+	}
+	var leaf *x509.Certificate
+	for _, cert := range certs {
+		if !issuesAny(cert, certs) {
+			if leaf != nil {
+				return nil, fmt.Errorf("the timestamp countersignature of signature %s carries more than one certificate chain", s.Source)
+			}
+			leaf = cert
+		}
+	}
+	if leaf == nil {
+		return nil, fmt.Errorf("failed to find the timestamping certificate of signature %s", s.Source)
+	}
+	chain := []*x509.Certificate{leaf}
+	for cert := leaf; !IsSelfSigned(cert); {
+		issuer := issuerOf(cert, certs)
+		if issuer == nil || slices.ContainsFunc(chain, issuer.Equal) {
+			break
+		}
+		chain = append(chain, issuer)
+		cert = issuer
+	}
+	return chain, nil
+}
+
+// issuesAny reports whether cert issues any certificate other than itself in
+// certs.
+func issuesAny(cert *x509.Certificate, certs []*x509.Certificate) bool {
+	for _, c := range certs {
+		if !c.Equal(cert) && c.CheckSignatureFrom(cert) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// issuerOf returns the certificate in certs issuing cert, or nil if not
+// found.
+func issuerOf(cert *x509.Certificate, certs []*x509.Certificate) *x509.Certificate {
+	for _, c := range certs {
+		if !c.Equal(cert) && cert.CheckSignatureFrom(c) == nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// IsSelfSigned reports whether cert is issued to itself and signed by its
+// own key. The CA constraints are not checked, so that self-signed leaf
+// certificates, such as the ones generated by `notation cert generate-test`,
+// are roots as well.
+func IsSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawSubject, cert.RawIssuer) && cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// Fingerprint returns the SHA-256 fingerprint of cert in lower case hex.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// Subject returns the subject of cert in the order it is encoded, with the
+// relative distinguished names separated by ", ", which is the format of the
+// "x509.subject" trusted identities, such as "C=US, ST=WA, O=acme-rockets.io".
+func Subject(cert *x509.Certificate) (string, error) {
+	var rdnSequence pkix.RDNSequence
+	if rest, err := asn1.Unmarshal(cert.RawSubject, &rdnSequence); err != nil || len(rest) > 0 {
+		return "", fmt.Errorf("failed to parse the subject of certificate %s", Fingerprint(cert))
+	}
+	rdns := make([]string, 0, len(rdnSequence))
+	for _, rdn := range rdnSequence {
+		rdns = append(rdns, pkix.RDNSequence{rdn}.String())
+	}
+	return strings.Join(rdns, ", "), nil
+}
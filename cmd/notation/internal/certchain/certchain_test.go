@@ -0,0 +1,191 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certchain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/notaryproject/notation/v2/internal/envelope"
+)
+
+// newTestCert creates a certificate of subject signed by parent, or a
+// self-signed certificate if parent is nil.
+func newTestCert(t *testing.T, subject pkix.Name, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign
+		template.ExtKeyUsage = nil
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+// newTestSignature signs a payload with a certificate chain issued by a
+// test root, and returns the JWS envelope with the chain.
+func newTestSignature(t *testing.T) ([]byte, []*x509.Certificate) {
+	t.Helper()
+	root, rootKey := newTestCert(t, pkix.Name{CommonName: "Test Root", Organization: []string{"Notary"}, Country: []string{"US"}}, true, nil, nil)
+	leaf, leafKey := newTestCert(t, pkix.Name{CommonName: "Test Leaf", Organization: []string{"Notary"}, Province: []string{"WA"}, Country: []string{"US"}}, false, root, rootKey)
+	chain := []*x509.Certificate{leaf, root}
+	signer, err := signature.NewLocalSigner(chain, leafKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigEnv, err := signature.NewEnvelope(jws.MediaTypeEnvelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := sigEnv.Sign(&signature.SignRequest{
+		Payload: signature.Payload{
+			ContentType: envelope.MediaTypePayloadV1,
+			Content:     []byte(`{"targetArtifact":{}}`),
+		},
+		Signer:        signer,
+		SigningTime:   time.Now(),
+		SigningScheme: signature.SigningSchemeX509,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig, chain
+}
+
+func TestReadFile(t *testing.T) {
+	sig, chain := newTestSignature(t)
+	dir := t.TempDir()
+	sigPath := filepath.Join(dir, "blob.jws.sig")
+	if err := os.WriteFile(sigPath, sig, 0600); err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := json.Marshal(map[string]any{
+		"mediaType": envelope.MediaTypeSignatureBundle,
+		"signature": map[string]any{
+			"mediaType": jws.MediaTypeEnvelope,
+			"envelope":  sig,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundlePath := filepath.Join(dir, "blob.jws.bundle.json")
+	if err := os.WriteFile(bundlePath, bundle, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{sigPath, bundlePath} {
+		got, err := ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", path, err)
+		}
+		if got.Source != path || got.SigningScheme != signature.SigningSchemeX509 || len(got.CertificateChain) != 2 {
+			t.Fatalf("ReadFile(%s) = %+v", path, got)
+		}
+		root, err := got.Root()
+		if err != nil {
+			t.Fatalf("Root() error = %v", err)
+		}
+		if !root.Equal(chain[1]) {
+			t.Fatal("Root() returned a certificate other than the root")
+		}
+	}
+
+	invalidPath := filepath.Join(dir, "invalid.sig")
+	if err := os.WriteFile(invalidPath, []byte("invalid"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadFile(invalidPath); err == nil {
+		t.Fatal("ReadFile() expected error for an invalid signature file")
+	}
+}
+
+func TestRootNotSelfSigned(t *testing.T) {
+	_, chain := newTestSignature(t)
+	sig := &Signature{Source: "test", CertificateChain: chain[:1]}
+	if _, err := sig.Root(); err == nil {
+		t.Fatal("Root() expected error for a chain without root")
+	}
+}
+
+func TestTimestampChain(t *testing.T) {
+	root, rootKey := newTestCert(t, pkix.Name{CommonName: "Test TSA Root"}, true, nil, nil)
+	intermediate, intermediateKey := newTestCert(t, pkix.Name{CommonName: "Test TSA Intermediate"}, true, root, rootKey)
+	leaf, _ := newTestCert(t, pkix.Name{CommonName: "Test TSA"}, false, intermediate, intermediateKey)
+
+	sig := &Signature{Source: "test", TimestampCertificates: []*x509.Certificate{root, leaf, intermediate}}
+	chain, err := sig.TimestampChain()
+	if err != nil {
+		t.Fatalf("TimestampChain() error = %v", err)
+	}
+	if len(chain) != 3 || !chain[0].Equal(leaf) || !chain[1].Equal(intermediate) || !chain[2].Equal(root) {
+		t.Fatalf("TimestampChain() returned an unordered chain of %d certificates", len(chain))
+	}
+
+	sig = &Signature{Source: "test"}
+	if _, err := sig.TimestampChain(); err == nil {
+		t.Fatal("TimestampChain() expected error for a signature without timestamp countersignature")
+	}
+
+	other, _ := newTestCert(t, pkix.Name{CommonName: "Other TSA"}, false, nil, nil)
+	sig = &Signature{Source: "test", TimestampCertificates: []*x509.Certificate{leaf, other}}
+	if _, err := sig.TimestampChain(); err == nil {
+		t.Fatal("TimestampChain() expected error for more than one certificate chain")
+	}
+}
+
+func TestSubject(t *testing.T) {
+	cert, _ := newTestCert(t, pkix.Name{CommonName: "Test, Leaf", Organization: []string{"Notary"}, Province: []string{"WA"}, Country: []string{"US"}}, false, nil, nil)
+	got, err := Subject(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `C=US, ST=WA, O=Notary, CN=Test\, Leaf`; got != want {
+		t.Fatalf("Subject() = %q, want %q", got, want)
+	}
+}
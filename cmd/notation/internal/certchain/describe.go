@@ -0,0 +1,152 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certchain
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+)
+
+// Positions of a certificate in a certificate chain.
+const (
+	PositionLeaf         = "leaf"
+	PositionIntermediate = "intermediate"
+	PositionRoot         = "root"
+)
+
+// Position returns the position of cert in a certificate chain: a
+// self-signed certificate is a root, any other CA certificate is an
+// intermediate, and the rest are leaves.
+func Position(cert *x509.Certificate) string {
+	switch {
+	case IsSelfSigned(cert):
+		return PositionRoot
+	case cert.IsCA:
+		return PositionIntermediate
+	default:
+		return PositionLeaf
+	}
+}
+
+// PublicKey returns the algorithm and the size in bits of the public key of
+// cert. The size is 0 if the algorithm is not supported.
+func PublicKey(cert *x509.Certificate) (string, int) {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return "RSA", key.N.BitLen()
+	case *ecdsa.PublicKey:
+		return "ECDSA", key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return "Ed25519", ed25519.PublicKeySize * 8
+	}
+	return cert.PublicKeyAlgorithm.String(), 0
+}
+
+// keyUsageNames are the names of the key usages in the order of RFC 5280,
+// section 4.2.1.3.
+var keyUsageNames = []struct {
+	usage x509.KeyUsage
+	name  string
+}{
+	{x509.KeyUsageDigitalSignature, "Digital Signature"},
+	{x509.KeyUsageContentCommitment, "Content Commitment"},
+	{x509.KeyUsageKeyEncipherment, "Key Encipherment"},
+	{x509.KeyUsageDataEncipherment, "Data Encipherment"},
+	{x509.KeyUsageKeyAgreement, "Key Agreement"},
+	{x509.KeyUsageCertSign, "Certificate Sign"},
+	{x509.KeyUsageCRLSign, "CRL Sign"},
+	{x509.KeyUsageEncipherOnly, "Encipher Only"},
+	{x509.KeyUsageDecipherOnly, "Decipher Only"},
+}
+
+// KeyUsages returns the names of the key usages of cert.
+func KeyUsages(cert *x509.Certificate) []string {
+	var usages []string
+	for _, ku := range keyUsageNames {
+		if cert.KeyUsage&ku.usage != 0 {
+			usages = append(usages, ku.name)
+		}
+	}
+	return usages
+}
+
+// extKeyUsageNames are the names of the extended key usages known to the
+// x509 package.
+var extKeyUsageNames = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:                            "Any",
+	x509.ExtKeyUsageServerAuth:                     "Server Authentication",
+	x509.ExtKeyUsageClientAuth:                     "Client Authentication",
+	x509.ExtKeyUsageCodeSigning:                    "Code Signing",
+	x509.ExtKeyUsageEmailProtection:                "Email Protection",
+	x509.ExtKeyUsageIPSECEndSystem:                 "IPSec End System",
+	x509.ExtKeyUsageIPSECTunnel:                    "IPSec Tunnel",
+	x509.ExtKeyUsageIPSECUser:                      "IPSec User",
+	x509.ExtKeyUsageTimeStamping:                   "Time Stamping",
+	x509.ExtKeyUsageOCSPSigning:                    "OCSP Signing",
+	x509.ExtKeyUsageMicrosoftServerGatedCrypto:     "Microsoft Server Gated Crypto",
+	x509.ExtKeyUsageNetscapeServerGatedCrypto:      "Netscape Server Gated Crypto",
+	x509.ExtKeyUsageMicrosoftCommercialCodeSigning: "Microsoft Commercial Code Signing",
+	x509.ExtKeyUsageMicrosoftKernelCodeSigning:     "Microsoft Kernel Code Signing",
+}
+
+// ExtKeyUsages returns the names of the extended key usages of cert.
+// Extended key usages unknown to the x509 package are returned as OIDs.
+func ExtKeyUsages(cert *x509.Certificate) []string {
+	var usages []string
+	for _, eku := range cert.ExtKeyUsage {
+		if name, ok := extKeyUsageNames[eku]; ok {
+			usages = append(usages, name)
+		} else {
+			usages = append(usages, fmt.Sprintf("unknown (%d)", eku))
+		}
+	}
+	for _, oid := range cert.UnknownExtKeyUsage {
+		usages = append(usages, oid.String())
+	}
+	return usages
+}
+
+// SubjectAlternativeNames returns the subject alternative names of cert,
+// each prefixed by its type, e.g. "DNS:example.com".
+func SubjectAlternativeNames(cert *x509.Certificate) []string {
+	var names []string
+	for _, name := range cert.DNSNames {
+		names = append(names, "DNS:"+name)
+	}
+	for _, email := range cert.EmailAddresses {
+		names = append(names, "email:"+email)
+	}
+	for _, ip := range cert.IPAddresses {
+		names = append(names, "IP:"+ip.String())
+	}
+	for _, uri := range cert.URIs {
+		names = append(names, "URI:"+uri.String())
+	}
+	return names
+}
+
+// MaxPathLen returns the maximum path length of the basic constraints of
+// cert, and false if the path length is not constrained.
+func MaxPathLen(cert *x509.Certificate) (int, bool) {
+	if !cert.BasicConstraintsValid || !cert.IsCA {
+		return 0, false
+	}
+	if cert.MaxPathLen > 0 || cert.MaxPathLenZero {
+		return cert.MaxPathLen, true
+	}
+	return 0, false
+}
@@ -0,0 +1,77 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certchain
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"slices"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	root, rootKey := newTestCert(t, pkix.Name{CommonName: "root"}, true, nil, nil)
+	intermediate, intermediateKey := newTestCert(t, pkix.Name{CommonName: "intermediate"}, true, root, rootKey)
+	leaf, _ := newTestCert(t, pkix.Name{CommonName: "leaf"}, false, intermediate, intermediateKey)
+
+	for cert, want := range map[*x509.Certificate]string{
+		root:         PositionRoot,
+		intermediate: PositionIntermediate,
+		leaf:         PositionLeaf,
+	} {
+		if got := Position(cert); got != want {
+			t.Fatalf("expected position %s of %s, but got %s", want, cert.Subject, got)
+		}
+	}
+
+	if algorithm, size := PublicKey(leaf); algorithm != "ECDSA" || size != 256 {
+		t.Fatalf("expected ECDSA 256 public key, but got %s %d", algorithm, size)
+	}
+	if got := KeyUsages(leaf); !slices.Equal(got, []string{"Digital Signature"}) {
+		t.Fatalf("unexpected key usages: %v", got)
+	}
+	if got := KeyUsages(root); !slices.Equal(got, []string{"Certificate Sign"}) {
+		t.Fatalf("unexpected key usages: %v", got)
+	}
+	if got := ExtKeyUsages(leaf); !slices.Equal(got, []string{"Code Signing"}) {
+		t.Fatalf("unexpected extended key usages: %v", got)
+	}
+	if _, ok := MaxPathLen(root); ok {
+		t.Fatal("expected unconstrained path length")
+	}
+}
+
+func TestSubjectAlternativeNames(t *testing.T) {
+	cert := &x509.Certificate{
+		DNSNames:       []string{"example.com"},
+		EmailAddresses: []string{"admin@example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+	}
+	expected := []string{"DNS:example.com", "email:admin@example.com", "IP:10.0.0.1"}
+	if got := SubjectAlternativeNames(cert); !slices.Equal(got, expected) {
+		t.Fatalf("expected %v, but got %v", expected, got)
+	}
+}
+
+func TestMaxPathLen(t *testing.T) {
+	cert := &x509.Certificate{BasicConstraintsValid: true, IsCA: true, MaxPathLenZero: true}
+	if pathLen, ok := MaxPathLen(cert); !ok || pathLen != 0 {
+		t.Fatalf("expected max path length 0, but got %d, %v", pathLen, ok)
+	}
+	cert = &x509.Certificate{BasicConstraintsValid: true, IsCA: true, MaxPathLen: 2}
+	if pathLen, ok := MaxPathLen(cert); !ok || pathLen != 2 {
+		t.Fatalf("expected max path length 2, but got %d, %v", pathLen, ok)
+	}
+}
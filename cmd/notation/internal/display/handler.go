@@ -47,6 +47,30 @@ func NewBlobInspectHandler(printer *output.Printer, format output.Format) (metad
 	return nil, fmt.Errorf("unrecognized output format %s", format)
 }
 
+// NewPolicyLintHandler creates a new metadata PolicyLintHandler based on the
+// output format.
+func NewPolicyLintHandler(printer *output.Printer, format output.Format) (metadata.PolicyLintHandler, error) {
+	switch format {
+	case output.FormatJSON:
+		return json.NewPolicyLintHandler(printer), nil
+	case output.FormatText:
+		return text.NewPolicyLintHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format)
+}
+
+// NewPolicyTestHandler creates a new metadata PolicyTestHandler based on the
+// output format.
+func NewPolicyTestHandler(printer *output.Printer, format output.Format) (metadata.PolicyTestHandler, error) {
+	switch format {
+	case output.FormatJSON:
+		return json.NewPolicyTestHandler(printer), nil
+	case output.FormatTree:
+		return tree.NewPolicyTestHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format)
+}
+
 // NewVerifyHandler creates a new metadata VerifyHandler for printing
 // verification result and warnings.
 func NewVerifyHandler(printer *output.Printer) metadata.VerifyHandler {
@@ -64,3 +88,51 @@ func NewBlobVerifyHandler(printer *output.Printer) metadata.BlobVerifyHandler {
 func NewListHandler(printer *output.Printer) metadata.ListHandler {
 	return tree.NewListHandler(printer)
 }
+
+// NewCertListHandler creates a new metadata CertListHandler based on the
+// output format.
+func NewCertListHandler(printer *output.Printer, format output.Format) (metadata.CertListHandler, error) {
+	switch format {
+	case output.FormatJSON:
+		return json.NewCertListHandler(printer), nil
+	case output.FormatText:
+		return text.NewCertListHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format)
+}
+
+// NewCertShowHandler creates a new metadata CertShowHandler based on the
+// output format.
+func NewCertShowHandler(printer *output.Printer, format output.Format) (metadata.CertShowHandler, error) {
+	switch format {
+	case output.FormatJSON:
+		return json.NewCertShowHandler(printer), nil
+	case output.FormatText:
+		return text.NewCertShowHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format)
+}
+
+// NewCertCheckHandler creates a new metadata CertCheckHandler based on the
+// output format.
+func NewCertCheckHandler(printer *output.Printer, format output.Format) (metadata.CertCheckHandler, error) {
+	switch format {
+	case output.FormatJSON:
+		return json.NewCertCheckHandler(printer), nil
+	case output.FormatText:
+		return text.NewCertCheckHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format)
+}
+
+// NewRevocationCheckHandler creates a new metadata RevocationCheckHandler based
+// on the output format.
+func NewRevocationCheckHandler(printer *output.Printer, format output.Format) (metadata.RevocationCheckHandler, error) {
+	switch format {
+	case output.FormatJSON:
+		return json.NewRevocationCheckHandler(printer), nil
+	case output.FormatText:
+		return text.NewRevocationCheckHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format)
+}
@@ -17,8 +17,13 @@
 package metadata
 
 import (
+	"crypto/x509"
+
+	"github.com/notaryproject/notation-core-go/revocation/result"
 	"github.com/notaryproject/notation-core-go/signature"
 	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -46,6 +51,51 @@ type BlobInspectHandler interface {
 
 	// OnEnvelopeParsed sets the parsed envelope for the handler.
 	OnEnvelopeParsed(signaturePath, signatureMediaType string, envelope signature.Envelope) error
+
+	// OnBundledCRLsParsed sets the CRLs captured in a signature bundle for
+	// the handler.
+	OnBundledCRLsParsed(crls []BundledCRL)
+
+	// OnBlobChecked sets the result of checking a blob against the signed
+	// artifact of the signature for the handler.
+	OnBlobChecked(result BlobCheckResult)
+}
+
+// BlobCheckResult is the result of checking a blob against the digest and
+// the size of the signed artifact.
+type BlobCheckResult struct {
+	// Path is the path to the blob.
+	Path string
+
+	// Digest is the digest of the blob, computed with the digest algorithm
+	// of the signed artifact.
+	Digest digest.Digest
+
+	// Size is the size of the blob in bytes.
+	Size int64
+
+	// DigestMatched is true if the digest of the blob matches the signed
+	// artifact.
+	DigestMatched bool
+
+	// SizeMatched is true if the size of the blob matches the signed
+	// artifact.
+	SizeMatched bool
+}
+
+// Matched returns true if both the digest and the size of the blob match the
+// signed artifact.
+func (r BlobCheckResult) Matched() bool {
+	return r.DigestMatched && r.SizeMatched
+}
+
+// BundledCRL is a CRL captured in a signature bundle.
+type BundledCRL struct {
+	// URL is the CRL distribution point where the CRL was fetched from.
+	URL string
+
+	// CRL is the parsed CRL.
+	CRL *x509.RevocationList
 }
 
 // VerifyHandler is a handler for rendering metadata information of
@@ -73,8 +123,10 @@ type BlobVerifyHandler interface {
 
 	// OnVerifySucceeded sets the successful verification result for the handler.
 	//
-	// outcomes must not be nil or empty.
-	OnVerifySucceeded(outcomes []*notation.VerificationOutcome, blobPath string)
+	// outcomes must not be nil or empty. If signaturePaths is not empty,
+	// signaturePaths[i] is the signature file verified in outcomes[i] and is
+	// reported in the output.
+	OnVerifySucceeded(outcomes []*notation.VerificationOutcome, blobPath string, signaturePaths []string)
 }
 
 // ListHandler is a handler for rendering metadata information of a list of
@@ -89,3 +141,107 @@ type ListHandler interface {
 	// OnSignatureListed adds the signature digest to be rendered.
 	OnSignatureListed(signatureManifest ocispec.Descriptor) error
 }
+
+// PolicyLintHandler is a handler for rendering the findings of linting a
+// trust policy configuration.
+type PolicyLintHandler interface {
+	Renderer
+
+	// OnPolicyLinted sets the path of the linted trust policy configuration
+	// and the findings for the handler.
+	OnPolicyLinted(policyPath string, findings []policy.Finding)
+}
+
+// PolicyTestHandler is a handler for rendering the trust policy statement
+// selected for an artifact.
+type PolicyTestHandler interface {
+	Renderer
+
+	// OnStatementResolved sets the artifact and the statement resolved for
+	// it for the handler.
+	OnStatementResolved(target string, resolution *policy.Resolution)
+}
+
+// CertificateFile is a certificate file in a trust store.
+type CertificateFile struct {
+	// StoreType is the type of the trust store.
+	StoreType string
+
+	// StoreName is the name of the trust store.
+	StoreName string
+
+	// Path is the path to the certificate file.
+	Path string
+
+	// Certificates are the certificates in the file.
+	Certificates []*x509.Certificate
+
+	// Err is the error of reading the certificates in the file, if any.
+	Err error
+}
+
+// CertListHandler is a handler for rendering the certificate files in the
+// trust stores.
+type CertListHandler interface {
+	Renderer
+
+	// OnCertificateListed adds the certificate file to be rendered.
+	OnCertificateListed(file CertificateFile)
+}
+
+// CertShowHandler is a handler for rendering the details of the certificates
+// in a certificate file of a trust store.
+type CertShowHandler interface {
+	Renderer
+
+	// OnCertificateLoaded sets the certificate file to be rendered for the
+	// handler.
+	OnCertificateLoaded(file CertificateFile)
+}
+
+// CertCheckHandler is a handler for rendering the findings of checking the
+// trust stores.
+type CertCheckHandler interface {
+	Renderer
+
+	// OnTrustStoresChecked sets the number of the checked trust stores and
+	// the findings for the handler.
+	OnTrustStoresChecked(storeCount int, findings []policy.Finding)
+}
+
+// RevocationCheck is the result of checking the revocation status of a
+// certificate chain.
+type RevocationCheck struct {
+	// Source is where the certificate chain comes from, such as the path of
+	// the certificate file or of the signature.
+	Source string
+
+	// Purpose is the purpose of the certificate chain, which is either
+	// "codeSigning" or "timestamping".
+	Purpose string
+
+	// Certificates is the certificate chain, starting from the leaf
+	// certificate.
+	Certificates []*x509.Certificate
+
+	// Results are the revocation results of the certificates, in the same
+	// order as Certificates.
+	Results []*result.CertRevocationResult
+
+	// CRLCacheHits records, for each CRL url used, whether the CRL is served
+	// from the CRL cache.
+	CRLCacheHits map[string]bool
+
+	// Err is the error of checking the revocation status, if any.
+	Err error
+}
+
+// RevocationCheckHandler is a handler for rendering the revocation status of
+// certificate chains.
+type RevocationCheckHandler interface {
+	Renderer
+
+	// OnRevocationChecked adds the revocation status check of a certificate
+	// chain to be rendered.
+	OnRevocationChecked(check RevocationCheck)
+}
@@ -15,6 +15,7 @@ package json
 
 import (
 	coresignature "github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
 )
 
@@ -22,8 +23,10 @@ import (
 // rendering it in JSON format. It implements the metadata.BlobInspectHandler
 // interface.
 type BlobInspectHandler struct {
-	printer   *output.Printer
-	signature *signature
+	printer     *output.Printer
+	signature   *signature
+	bundledCRLs []*bundledCRL
+	blobCheck   *blobCheck
 }
 
 // NewBlobInspectHandler creates a BlobInspectHandler to inspect signature and
@@ -45,7 +48,28 @@ func (h *BlobInspectHandler) OnEnvelopeParsed(nodeName, signatureMediaType strin
 	return nil
 }
 
+// OnBundledCRLsParsed sets the CRLs captured in a signature bundle for the
+// handler.
+func (h *BlobInspectHandler) OnBundledCRLsParsed(crls []metadata.BundledCRL) {
+	h.bundledCRLs = getBundledCRLs(crls)
+}
+
+// OnBlobChecked sets the result of checking a blob against the signed
+// artifact for the handler.
+func (h *BlobInspectHandler) OnBlobChecked(result metadata.BlobCheckResult) {
+	h.blobCheck = &blobCheck{
+		Path:          result.Path,
+		Digest:        result.Digest.String(),
+		Size:          result.Size,
+		DigestMatched: result.DigestMatched,
+		SizeMatched:   result.SizeMatched,
+		Matched:       result.Matched(),
+	}
+}
+
 // Render prints out the metadata information in JSON format.
 func (h *BlobInspectHandler) Render() error {
+	h.signature.BundledCRLs = h.bundledCRLs
+	h.signature.BlobCheck = h.blobCheck
 	return output.PrintPrettyJSON(h.printer, h.signature)
 }
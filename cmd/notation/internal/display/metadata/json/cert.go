@@ -0,0 +1,75 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+)
+
+// CertListHandler is a handler for rendering the certificate files in the
+// trust stores in JSON format. It implements the metadata.CertListHandler
+// interface.
+type CertListHandler struct {
+	printer *output.Printer
+
+	output []*certificateFile
+}
+
+// NewCertListHandler creates a CertListHandler to print the certificate
+// files in the trust stores in JSON format.
+func NewCertListHandler(printer *output.Printer) *CertListHandler {
+	return &CertListHandler{
+		printer: printer,
+		output:  []*certificateFile{},
+	}
+}
+
+// OnCertificateListed adds the certificate file to be rendered.
+func (h *CertListHandler) OnCertificateListed(file metadata.CertificateFile) {
+	h.output = append(h.output, newCertificateFile(file))
+}
+
+// Render prints out the certificate files in JSON format.
+func (h *CertListHandler) Render() error {
+	return output.PrintPrettyJSON(h.printer, h.output)
+}
+
+// CertShowHandler is a handler for rendering the details of the certificates
+// in a certificate file in JSON format. It implements the
+// metadata.CertShowHandler interface.
+type CertShowHandler struct {
+	printer *output.Printer
+
+	output *certificateFile
+}
+
+// NewCertShowHandler creates a CertShowHandler to print the details of the
+// certificates in a certificate file in JSON format.
+func NewCertShowHandler(printer *output.Printer) *CertShowHandler {
+	return &CertShowHandler{
+		printer: printer,
+	}
+}
+
+// OnCertificateLoaded sets the certificate file to be rendered for the
+// handler.
+func (h *CertShowHandler) OnCertificateLoaded(file metadata.CertificateFile) {
+	h.output = newCertificateFile(file)
+}
+
+// Render prints out the details of the certificates in JSON format.
+func (h *CertShowHandler) Render() error {
+	return output.PrintPrettyJSON(h.printer, h.output)
+}
@@ -0,0 +1,59 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+type certCheckOutput struct {
+	TrustStores int        `json:"trustStores"`
+	Errors      int        `json:"errors"`
+	Warnings    int        `json:"warnings"`
+	Findings    []*finding `json:"findings"`
+}
+
+// CertCheckHandler is a handler for rendering the findings of checking the
+// trust stores in JSON format. It implements the metadata.CertCheckHandler
+// interface.
+type CertCheckHandler struct {
+	printer *output.Printer
+
+	output certCheckOutput
+}
+
+// NewCertCheckHandler creates a CertCheckHandler to print the findings of
+// checking the trust stores in JSON format.
+func NewCertCheckHandler(printer *output.Printer) *CertCheckHandler {
+	return &CertCheckHandler{
+		printer: printer,
+		output: certCheckOutput{
+			Findings: []*finding{},
+		},
+	}
+}
+
+// OnTrustStoresChecked sets the number of the checked trust stores and the
+// findings for the handler.
+func (h *CertCheckHandler) OnTrustStoresChecked(storeCount int, findings []policy.Finding) {
+	h.output.TrustStores = storeCount
+	h.output.Errors, h.output.Warnings = policy.CountFindings(findings)
+	h.output.Findings = newFindings(findings)
+}
+
+// Render prints out the findings in JSON format.
+func (h *CertCheckHandler) Render() error {
+	return output.PrintPrettyJSON(h.printer, h.output)
+}
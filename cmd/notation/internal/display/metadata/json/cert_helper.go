@@ -0,0 +1,102 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"crypto/x509"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+)
+
+// certificateFile is a certificate file in a trust store for printing in
+// JSON format.
+type certificateFile struct {
+	StoreType    string                `json:"storeType"`
+	StoreName    string                `json:"storeName"`
+	FileName     string                `json:"fileName"`
+	Path         string                `json:"path"`
+	Certificates []*certificateDetails `json:"certificates"`
+	Error        string                `json:"error,omitempty"`
+}
+
+// certificateDetails is the detailed certificate information for printing in
+// JSON format. It extends the certificate information of signatures.
+type certificateDetails struct {
+	*certificate
+	ChainPosition           string            `json:"chainPosition"`
+	SerialNumber            string            `json:"serialNumber"`
+	NotBefore               time.Time         `json:"notBefore"`
+	KeyAlgorithm            string            `json:"keyAlgorithm"`
+	KeySize                 int               `json:"keySize,omitempty"`
+	SubjectAlternativeNames []string          `json:"subjectAlternativeNames,omitempty"`
+	KeyUsage                []string          `json:"keyUsage,omitempty"`
+	ExtendedKeyUsage        []string          `json:"extendedKeyUsage,omitempty"`
+	BasicConstraints        *basicConstraints `json:"basicConstraints,omitempty"`
+	IssuingCertificateURLs  []string          `json:"issuingCertificateURLs,omitempty"`
+	OCSPServers             []string          `json:"ocspServers,omitempty"`
+	CRLDistributionPoints   []string          `json:"crlDistributionPoints,omitempty"`
+}
+
+// basicConstraints is the basic constraints extension of a certificate for
+// printing in JSON format.
+type basicConstraints struct {
+	IsCA          bool `json:"isCA"`
+	MaxPathLength *int `json:"maxPathLength,omitempty"`
+}
+
+func newCertificateFile(file metadata.CertificateFile) *certificateFile {
+	certFile := &certificateFile{
+		StoreType:    file.StoreType,
+		StoreName:    file.StoreName,
+		FileName:     filepath.Base(file.Path),
+		Path:         file.Path,
+		Certificates: []*certificateDetails{},
+	}
+	if file.Err != nil {
+		certFile.Error = file.Err.Error()
+	}
+	for _, cert := range file.Certificates {
+		certFile.Certificates = append(certFile.Certificates, newCertificateDetails(cert))
+	}
+	return certFile
+}
+
+func newCertificateDetails(cert *x509.Certificate) *certificateDetails {
+	keyAlgorithm, keySize := certchain.PublicKey(cert)
+	details := &certificateDetails{
+		certificate:             newCertificate(cert),
+		ChainPosition:           certchain.Position(cert),
+		SerialNumber:            strings.ToLower(cert.SerialNumber.Text(16)),
+		NotBefore:               cert.NotBefore,
+		KeyAlgorithm:            keyAlgorithm,
+		KeySize:                 keySize,
+		SubjectAlternativeNames: certchain.SubjectAlternativeNames(cert),
+		KeyUsage:                certchain.KeyUsages(cert),
+		ExtendedKeyUsage:        certchain.ExtKeyUsages(cert),
+		IssuingCertificateURLs:  cert.IssuingCertificateURL,
+		OCSPServers:             cert.OCSPServer,
+		CRLDistributionPoints:   cert.CRLDistributionPoints,
+	}
+	if cert.BasicConstraintsValid {
+		details.BasicConstraints = &basicConstraints{IsCA: cert.IsCA}
+		if pathLen, ok := certchain.MaxPathLen(cert); ok {
+			details.BasicConstraints.MaxPathLength = &pathLen
+		}
+	}
+	return details
+}
@@ -0,0 +1,152 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+)
+
+func TestNewCertificateFile(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(0x1a2b),
+		Subject:               pkix.Name{CommonName: "leaf"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"example.com"},
+	}
+	parent := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "root"},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := newCertificateFile(metadata.CertificateFile{
+		StoreType:    "signingAuthority",
+		StoreName:    "test",
+		Path:         "/truststore/x509/signingAuthority/test/leaf.pem",
+		Certificates: []*x509.Certificate{cert},
+	})
+	if file.FileName != "leaf.pem" || file.Error != "" || len(file.Certificates) != 1 {
+		t.Fatalf("unexpected certificate file: %+v", file)
+	}
+	details := file.Certificates[0]
+	if details.IssuedTo != "CN=leaf" || details.IssuedBy != "CN=root" {
+		t.Fatalf("unexpected subject and issuer: %s, %s", details.IssuedTo, details.IssuedBy)
+	}
+	if details.ChainPosition != "leaf" || details.SerialNumber != "1a2b" {
+		t.Fatalf("unexpected chain position and serial number: %s, %s", details.ChainPosition, details.SerialNumber)
+	}
+	if details.KeyAlgorithm != "ECDSA" || details.KeySize != 256 {
+		t.Fatalf("unexpected public key: %s %d", details.KeyAlgorithm, details.KeySize)
+	}
+	if !slices.Equal(details.SubjectAlternativeNames, []string{"DNS:example.com"}) ||
+		!slices.Equal(details.KeyUsage, []string{"Digital Signature"}) ||
+		!slices.Equal(details.ExtendedKeyUsage, []string{"Code Signing"}) {
+		t.Fatalf("unexpected extensions: %+v", details)
+	}
+	if details.BasicConstraints == nil || details.BasicConstraints.IsCA || details.BasicConstraints.MaxPathLength != nil {
+		t.Fatalf("unexpected basic constraints: %+v", details.BasicConstraints)
+	}
+
+	file = newCertificateFile(metadata.CertificateFile{
+		Path: "invalid.txt",
+		Err:  errors.New("x509: malformed certificate"),
+	})
+	if file.Error != "x509: malformed certificate" || file.Certificates == nil || len(file.Certificates) != 0 {
+		t.Fatalf("unexpected certificate file: %+v", file)
+	}
+}
+
+func TestNewChainRevocation(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := newChainRevocation(metadata.RevocationCheck{
+		Source:       "chain.pem",
+		Purpose:      "codeSigning",
+		Certificates: []*x509.Certificate{cert},
+		Results: []*result.CertRevocationResult{{
+			Result:           result.ResultOK,
+			RevocationMethod: result.RevocationMethodCRL,
+			ServerResults: []*result.ServerResult{{
+				Server:           "http://example.com/leaf.crl",
+				Result:           result.ResultOK,
+				RevocationMethod: result.RevocationMethodCRL,
+			}},
+		}},
+		CRLCacheHits: map[string]bool{"http://example.com/leaf.crl": true},
+	})
+	if chain.Result != "OK" || chain.Error != "" || len(chain.Certificates) != 1 {
+		t.Fatalf("unexpected chain revocation: %+v", chain)
+	}
+	certRevocation := chain.Certificates[0]
+	if certRevocation.IssuedTo != "CN=leaf" || certRevocation.Result != "OK" || certRevocation.RevocationMethod != "CRL" || len(certRevocation.Servers) != 1 {
+		t.Fatalf("unexpected certificate revocation: %+v", certRevocation)
+	}
+	server := certRevocation.Servers[0]
+	if server.URL != "http://example.com/leaf.crl" || server.CRLCacheHit == nil || !*server.CRLCacheHit {
+		t.Fatalf("unexpected server revocation: %+v", server)
+	}
+
+	chain = newChainRevocation(metadata.RevocationCheck{
+		Source:  "sig.jws",
+		Purpose: "timestamping",
+		Err:     errors.New("no timestamp countersignature"),
+	})
+	if chain.Result != "" || chain.Error != "no timestamp countersignature" || chain.Certificates == nil {
+		t.Fatalf("unexpected chain revocation: %+v", chain)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/internal/revocation"
+)
+
+type revocationCheckOutput struct {
+	Chains []*chainRevocation `json:"chains"`
+}
+
+// chainRevocation is the revocation status of a certificate chain for
+// printing in JSON format.
+type chainRevocation struct {
+	Source       string                   `json:"source"`
+	Purpose      string                   `json:"purpose"`
+	Result       string                   `json:"result,omitempty"`
+	Error        string                   `json:"error,omitempty"`
+	Certificates []*certificateRevocation `json:"certificates"`
+}
+
+// certificateRevocation is the revocation status of a certificate for
+// printing in JSON format.
+type certificateRevocation struct {
+	*certificate
+	Result           string              `json:"result,omitempty"`
+	RevocationMethod string              `json:"revocationMethod,omitempty"`
+	Servers          []*serverRevocation `json:"servers,omitempty"`
+}
+
+// serverRevocation is the revocation status of a certificate returned by an
+// OCSP responder or a CRL for printing in JSON format.
+type serverRevocation struct {
+	URL              string `json:"url"`
+	RevocationMethod string `json:"revocationMethod"`
+	Result           string `json:"result"`
+	Error            string `json:"error,omitempty"`
+	CRLCacheHit      *bool  `json:"crlCacheHit,omitempty"`
+}
+
+// RevocationCheckHandler is a handler for rendering the revocation status of
+// certificate chains in JSON format. It implements the
+// metadata.RevocationCheckHandler interface.
+type RevocationCheckHandler struct {
+	printer *output.Printer
+
+	output revocationCheckOutput
+}
+
+// NewRevocationCheckHandler creates a RevocationCheckHandler to print the
+// revocation status of certificate chains in JSON format.
+func NewRevocationCheckHandler(printer *output.Printer) *RevocationCheckHandler {
+	return &RevocationCheckHandler{
+		printer: printer,
+		output: revocationCheckOutput{
+			Chains: []*chainRevocation{},
+		},
+	}
+}
+
+// OnRevocationChecked adds the revocation status check of a certificate chain
+// to be rendered.
+func (h *RevocationCheckHandler) OnRevocationChecked(check metadata.RevocationCheck) {
+	h.output.Chains = append(h.output.Chains, newChainRevocation(check))
+}
+
+// Render prints out the revocation status of the certificate chains in JSON
+// format.
+func (h *RevocationCheckHandler) Render() error {
+	return output.PrintPrettyJSON(h.printer, h.output)
+}
+
+func newChainRevocation(check metadata.RevocationCheck) *chainRevocation {
+	chain := &chainRevocation{
+		Source:       check.Source,
+		Purpose:      check.Purpose,
+		Certificates: []*certificateRevocation{},
+	}
+	if check.Err != nil {
+		chain.Error = check.Err.Error()
+	} else {
+		chain.Result = revocation.ChainResult(check.Results).String()
+	}
+	for i, cert := range check.Certificates {
+		certRevocation := &certificateRevocation{
+			certificate: newCertificate(cert),
+		}
+		if i < len(check.Results) {
+			certResult := check.Results[i]
+			certRevocation.Result = certResult.Result.String()
+			if certResult.RevocationMethod != result.RevocationMethodUnknown {
+				certRevocation.RevocationMethod = certResult.RevocationMethod.String()
+			}
+			for _, serverResult := range certResult.ServerResults {
+				if serverResult.Server == "" {
+					continue
+				}
+				certRevocation.Servers = append(certRevocation.Servers, newServerRevocation(serverResult, check.CRLCacheHits))
+			}
+		}
+		chain.Certificates = append(chain.Certificates, certRevocation)
+	}
+	return chain
+}
+
+func newServerRevocation(serverResult *result.ServerResult, crlCacheHits map[string]bool) *serverRevocation {
+	server := &serverRevocation{
+		URL:              serverResult.Server,
+		RevocationMethod: serverResult.RevocationMethod.String(),
+		Result:           serverResult.Result.String(),
+	}
+	if serverResult.Error != nil {
+		server.Error = serverResult.Error.Error()
+	}
+	if serverResult.RevocationMethod == result.RevocationMethodCRL {
+		hit := crlCacheHits[serverResult.Server]
+		server.CRLCacheHit = &hit
+	}
+	return server
+}
@@ -23,6 +23,7 @@ import (
 
 	coresignature "github.com/notaryproject/notation-core-go/signature"
 	"github.com/notaryproject/notation-go/plugin/proto"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
 	envelopeutil "github.com/notaryproject/notation/v2/internal/envelope"
 	"github.com/notaryproject/tspclient-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -38,6 +39,8 @@ type signature struct {
 	UnsignedAttributes    map[string]any     `json:"unsignedAttributes"`
 	Certificates          []*certificate     `json:"certificates"`
 	SignedArtifact        ocispec.Descriptor `json:"signedArtifact"`
+	BundledCRLs           []*bundledCRL      `json:"bundledCRLs,omitempty"`
+	BlobCheck             *blobCheck         `json:"blobCheck,omitempty"`
 }
 
 // certificate is the certificate information for printing in JSON format.
@@ -48,6 +51,27 @@ type certificate struct {
 	Expiry            time.Time `json:"expiry"`
 }
 
+// bundledCRL is the information of a CRL captured in a signature bundle for
+// printing in JSON format.
+type bundledCRL struct {
+	URL                 string    `json:"url"`
+	IssuedBy            string    `json:"issuedBy"`
+	ThisUpdate          time.Time `json:"thisUpdate"`
+	NextUpdate          time.Time `json:"nextUpdate"`
+	RevokedCertificates int       `json:"revokedCertificates"`
+}
+
+// blobCheck is the result of checking a blob against the signed artifact for
+// printing in JSON format.
+type blobCheck struct {
+	Path          string `json:"path"`
+	Digest        string `json:"digest"`
+	Size          int64  `json:"size"`
+	DigestMatched bool   `json:"digestMatched"`
+	SizeMatched   bool   `json:"sizeMatched"`
+	Matched       bool   `json:"matched"`
+}
+
 // timestamp is the timestamp information for printing in JSON format.
 type timestamp struct {
 	Timestamp    string         `json:"timestamp,omitempty"`
@@ -118,17 +142,35 @@ func getUnsignedAttributes(envelopeContent *coresignature.EnvelopeContent) map[s
 func getCertificates(certChain []*x509.Certificate) []*certificate {
 	var certificates []*certificate
 	for _, cert := range certChain {
-		hash := sha256.Sum256(cert.Raw)
-		certificates = append(certificates, &certificate{
-			SHA256Fingerprint: strings.ToLower(hex.EncodeToString(hash[:])),
-			IssuedTo:          cert.Subject.String(),
-			IssuedBy:          cert.Issuer.String(),
-			Expiry:            cert.NotAfter,
-		})
+		certificates = append(certificates, newCertificate(cert))
 	}
 	return certificates
 }
 
+func newCertificate(cert *x509.Certificate) *certificate {
+	hash := sha256.Sum256(cert.Raw)
+	return &certificate{
+		SHA256Fingerprint: strings.ToLower(hex.EncodeToString(hash[:])),
+		IssuedTo:          cert.Subject.String(),
+		IssuedBy:          cert.Issuer.String(),
+		Expiry:            cert.NotAfter,
+	}
+}
+
+func getBundledCRLs(crls []metadata.BundledCRL) []*bundledCRL {
+	var bundledCRLs []*bundledCRL
+	for _, crl := range crls {
+		bundledCRLs = append(bundledCRLs, &bundledCRL{
+			URL:                 crl.URL,
+			IssuedBy:            crl.CRL.Issuer.String(),
+			ThisUpdate:          crl.CRL.ThisUpdate,
+			NextUpdate:          crl.CRL.NextUpdate,
+			RevokedCertificates: len(crl.CRL.RevokedCertificateEntries),
+		})
+	}
+	return bundledCRLs
+}
+
 func parseTimestamp(signerInfo coresignature.SignerInfo) *timestamp {
 	signedToken, err := tspclient.ParseSignedToken(signerInfo.UnsignedAttributes.TimestampSignature)
 	if err != nil {
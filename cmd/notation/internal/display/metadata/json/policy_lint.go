@@ -0,0 +1,83 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+type policyLintOutput struct {
+	PolicyPath string     `json:"policyPath"`
+	Errors     int        `json:"errors"`
+	Warnings   int        `json:"warnings"`
+	Findings   []*finding `json:"findings"`
+}
+
+type finding struct {
+	Severity   string `json:"severity"`
+	Rule       string `json:"rule"`
+	Statement  string `json:"statement,omitempty"`
+	TrustStore string `json:"trustStore,omitempty"`
+	Message    string `json:"message"`
+	Hint       string `json:"hint,omitempty"`
+}
+
+// PolicyLintHandler is a handler for rendering the findings of linting a
+// trust policy configuration in JSON format. It implements the
+// metadata.PolicyLintHandler interface.
+type PolicyLintHandler struct {
+	printer *output.Printer
+
+	output policyLintOutput
+}
+
+// NewPolicyLintHandler creates a PolicyLintHandler to print the findings of
+// linting a trust policy configuration in JSON format.
+func NewPolicyLintHandler(printer *output.Printer) *PolicyLintHandler {
+	return &PolicyLintHandler{
+		printer: printer,
+		output: policyLintOutput{
+			Findings: []*finding{},
+		},
+	}
+}
+
+// OnPolicyLinted sets the path of the linted trust policy configuration and
+// the findings for the handler.
+func (h *PolicyLintHandler) OnPolicyLinted(policyPath string, findings []policy.Finding) {
+	h.output.PolicyPath = policyPath
+	h.output.Errors, h.output.Warnings = policy.CountFindings(findings)
+	h.output.Findings = newFindings(findings)
+}
+
+// Render prints out the findings in JSON format.
+func (h *PolicyLintHandler) Render() error {
+	return output.PrintPrettyJSON(h.printer, h.output)
+}
+
+func newFindings(findings []policy.Finding) []*finding {
+	result := []*finding{}
+	for _, f := range findings {
+		result = append(result, &finding{
+			Severity:   string(f.Severity),
+			Rule:       f.Rule,
+			Statement:  f.Statement,
+			TrustStore: f.TrustStore,
+			Message:    f.Message,
+			Hint:       f.Hint,
+		})
+	}
+	return result
+}
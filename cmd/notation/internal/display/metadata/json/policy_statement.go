@@ -0,0 +1,92 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+type policyTestOutput struct {
+	Target            string                                                      `json:"target"`
+	Statement         string                                                      `json:"statement"`
+	Reason            string                                                      `json:"reason"`
+	RegistryScopes    []string                                                    `json:"registryScopes,omitempty"`
+	GlobalPolicy      bool                                                        `json:"globalPolicy,omitempty"`
+	VerificationLevel string                                                      `json:"verificationLevel"`
+	Enforcement       map[trustpolicy.ValidationType]trustpolicy.ValidationAction `json:"enforcement"`
+	Overrides         map[trustpolicy.ValidationType]trustpolicy.ValidationAction `json:"overrides,omitempty"`
+	VerifyTimestamp   string                                                      `json:"verifyTimestamp,omitempty"`
+	TrustedIdentities []string                                                    `json:"trustedIdentities,omitempty"`
+	TrustStores       []*trustStore                                               `json:"trustStores,omitempty"`
+}
+
+type trustStore struct {
+	Name         string         `json:"name"`
+	Certificates []*certificate `json:"certificates,omitempty"`
+	Error        string         `json:"error,omitempty"`
+}
+
+// PolicyTestHandler is a handler for rendering the trust policy statement
+// selected for an artifact in JSON format. It implements the
+// metadata.PolicyTestHandler interface.
+type PolicyTestHandler struct {
+	printer *output.Printer
+
+	output policyTestOutput
+}
+
+// NewPolicyTestHandler creates a PolicyTestHandler to print the trust policy
+// statement selected for an artifact in JSON format.
+func NewPolicyTestHandler(printer *output.Printer) *PolicyTestHandler {
+	return &PolicyTestHandler{
+		printer: printer,
+	}
+}
+
+// OnStatementResolved sets the artifact and the statement resolved for it for
+// the handler.
+func (h *PolicyTestHandler) OnStatementResolved(target string, resolution *policy.Resolution) {
+	statement := resolution.Statement
+	h.output = policyTestOutput{
+		Target:            target,
+		Statement:         statement.Name,
+		Reason:            resolution.Reason,
+		RegistryScopes:    statement.RegistryScopes,
+		GlobalPolicy:      statement.GlobalPolicy,
+		VerificationLevel: resolution.VerificationLevel.Name,
+		Enforcement:       resolution.VerificationLevel.Enforcement,
+		Overrides:         statement.SignatureVerification.Override,
+	}
+	if resolution.VerificationLevel.Name == trustpolicy.LevelSkip.Name {
+		return
+	}
+	h.output.VerifyTimestamp = string(resolution.VerifyTimestamp)
+	h.output.TrustedIdentities = statement.TrustedIdentities
+	for _, store := range resolution.TrustStores {
+		resolved := &trustStore{Name: store.Name}
+		if store.Err != nil {
+			resolved.Error = store.Err.Error()
+		} else {
+			resolved.Certificates = getCertificates(store.Certificates)
+		}
+		h.output.TrustStores = append(h.output.TrustStores, resolved)
+	}
+}
+
+// Render prints out the trust policy statement in JSON format.
+func (h *PolicyTestHandler) Render() error {
+	return output.PrintPrettyJSON(h.printer, h.output)
+}
@@ -14,6 +14,8 @@
 package text
 
 import (
+	"fmt"
+
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
 )
@@ -22,9 +24,10 @@ import (
 // in human-readable format.
 // It implements metadata/BlobVerifyHandler.
 type BlobVerifyHandler struct {
-	printer  *output.Printer
-	outcome  *notation.VerificationOutcome
-	blobPath string
+	printer        *output.Printer
+	outcomes       []*notation.VerificationOutcome
+	blobPath       string
+	signaturePaths []string
 }
 
 // NewBlobVerifyHandler creates a new BlobVerifyHandler.
@@ -36,13 +39,25 @@ func NewBlobVerifyHandler(printer *output.Printer) *BlobVerifyHandler {
 
 // OnVerifySucceeded sets the successful verification result for the handler.
 //
-// outcomes must not be nil or empty.
-func (h *BlobVerifyHandler) OnVerifySucceeded(outcomes []*notation.VerificationOutcome, blobPath string) {
-	h.outcome = outcomes[0]
+// outcomes must not be nil or empty. If signaturePaths is not empty,
+// signaturePaths[i] is the signature file verified in outcomes[i] and is
+// reported in the output.
+func (h *BlobVerifyHandler) OnVerifySucceeded(outcomes []*notation.VerificationOutcome, blobPath string, signaturePaths []string) {
+	h.outcomes = outcomes
 	h.blobPath = blobPath
+	h.signaturePaths = signaturePaths
 }
 
 // Render prints out the verification results in human-readable format.
 func (h *BlobVerifyHandler) Render() error {
-	return printVerificationSuccess(h.printer, h.outcome, h.blobPath, false)
+	for i, outcome := range h.outcomes {
+		artifact := h.blobPath
+		if i < len(h.signaturePaths) {
+			artifact = fmt.Sprintf("%s using signature file %s", h.blobPath, h.signaturePaths[i])
+		}
+		if err := printVerificationSuccess(h.printer, outcome, artifact, false); err != nil {
+			return err
+		}
+	}
+	return nil
 }
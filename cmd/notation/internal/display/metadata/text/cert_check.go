@@ -0,0 +1,54 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+// CertCheckHandler is a handler for rendering the findings of checking the
+// trust stores in human-readable format.
+// It implements metadata/CertCheckHandler.
+type CertCheckHandler struct {
+	printer    *output.Printer
+	storeCount int
+	findings   []policy.Finding
+}
+
+// NewCertCheckHandler creates a new CertCheckHandler.
+func NewCertCheckHandler(printer *output.Printer) *CertCheckHandler {
+	return &CertCheckHandler{
+		printer: printer,
+	}
+}
+
+// OnTrustStoresChecked sets the number of the checked trust stores and the
+// findings for the handler.
+func (h *CertCheckHandler) OnTrustStoresChecked(storeCount int, findings []policy.Finding) {
+	h.storeCount = storeCount
+	h.findings = findings
+}
+
+// Render prints out the findings in human-readable format.
+func (h *CertCheckHandler) Render() error {
+	if len(h.findings) == 0 {
+		return h.printer.Printf("No issues found in %d trust store(s).\n", h.storeCount)
+	}
+	if err := printFindings(h.printer, h.findings); err != nil {
+		return err
+	}
+	errorCount, warningCount := policy.CountFindings(h.findings)
+	return h.printer.Printf("\nFound %d error(s) and %d warning(s) in %d trust store(s).\n", errorCount, warningCount, h.storeCount)
+}
@@ -0,0 +1,57 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"fmt"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+)
+
+// CertListHandler is a handler for rendering the certificate files in the
+// trust stores as a table.
+// It implements metadata/CertListHandler.
+type CertListHandler struct {
+	printer *output.Printer
+	files   []metadata.CertificateFile
+}
+
+// NewCertListHandler creates a new CertListHandler.
+func NewCertListHandler(printer *output.Printer) *CertListHandler {
+	return &CertListHandler{
+		printer: printer,
+	}
+}
+
+// OnCertificateListed adds the certificate file to be rendered.
+func (h *CertListHandler) OnCertificateListed(file metadata.CertificateFile) {
+	h.files = append(h.files, file)
+}
+
+// Render prints out the certificate files as a table. Nothing is printed if
+// there is no certificate file.
+func (h *CertListHandler) Render() error {
+	if len(h.files) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(h.printer, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "STORE TYPE\tSTORE NAME\tCERTIFICATE\t")
+	for _, file := range h.files {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t\n", file.StoreType, file.StoreName, filepath.Base(file.Path))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,95 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"fmt"
+
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/internal/revocation"
+)
+
+// RevocationCheckHandler is a handler for rendering the revocation status of
+// certificate chains in human-readable format.
+// It implements metadata/RevocationCheckHandler.
+type RevocationCheckHandler struct {
+	printer *output.Printer
+	checks  []metadata.RevocationCheck
+}
+
+// NewRevocationCheckHandler creates a new RevocationCheckHandler.
+func NewRevocationCheckHandler(printer *output.Printer) *RevocationCheckHandler {
+	return &RevocationCheckHandler{
+		printer: printer,
+	}
+}
+
+// OnRevocationChecked adds the revocation status check of a certificate chain
+// to be rendered.
+func (h *RevocationCheckHandler) OnRevocationChecked(check metadata.RevocationCheck) {
+	h.checks = append(h.checks, check)
+}
+
+// Render prints out the revocation status of the certificate chains in
+// human-readable format.
+func (h *RevocationCheckHandler) Render() error {
+	for i, check := range h.checks {
+		if i > 0 {
+			h.printer.Println()
+		}
+		h.printCheck(check)
+	}
+	return nil
+}
+
+func (h *RevocationCheckHandler) printCheck(check metadata.RevocationCheck) {
+	if check.Err != nil {
+		h.printer.Printf("Revocation status of %s for %s: failed to check: %v\n", check.Source, check.Purpose, check.Err)
+		return
+	}
+	h.printer.Printf("Revocation status of %s for %s: %s\n", check.Source, check.Purpose, revocation.ChainResult(check.Results))
+	for i, cert := range check.Certificates {
+		h.printer.Printf("  Certificate %d of %d: %s\n", i+1, len(check.Certificates), cert.Subject)
+		h.printer.Printf("    SHA-256 fingerprint: %s\n", certchain.Fingerprint(cert))
+		if i >= len(check.Results) {
+			continue
+		}
+		certResult := check.Results[i]
+		if certResult.RevocationMethod == result.RevocationMethodUnknown {
+			h.printer.Printf("    Result: %s\n", certResult.Result)
+		} else {
+			h.printer.Printf("    Result: %s (%s)\n", certResult.Result, certResult.RevocationMethod)
+		}
+		for _, serverResult := range certResult.ServerResults {
+			if serverResult.Server == "" {
+				continue
+			}
+			line := fmt.Sprintf("    %s %s: %s", serverResult.RevocationMethod, serverResult.Server, serverResult.Result)
+			if serverResult.RevocationMethod == result.RevocationMethodCRL {
+				if check.CRLCacheHits[serverResult.Server] {
+					line += " (CRL cache hit)"
+				} else {
+					line += " (CRL cache miss)"
+				}
+			}
+			if serverResult.Error != nil {
+				line += fmt.Sprintf(", error: %v", serverResult.Error)
+			}
+			h.printer.Println(line)
+		}
+	}
+}
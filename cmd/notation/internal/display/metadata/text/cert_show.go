@@ -0,0 +1,95 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"crypto/x509"
+	"strings"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+)
+
+const certSeparator = "--------------------------------------------------------------------------------"
+
+// CertShowHandler is a handler for rendering the details of the certificates
+// in a certificate file in human-readable format.
+// It implements metadata/CertShowHandler.
+type CertShowHandler struct {
+	printer *output.Printer
+	file    metadata.CertificateFile
+}
+
+// NewCertShowHandler creates a new CertShowHandler.
+func NewCertShowHandler(printer *output.Printer) *CertShowHandler {
+	return &CertShowHandler{
+		printer: printer,
+	}
+}
+
+// OnCertificateLoaded sets the certificate file to be rendered for the
+// handler.
+func (h *CertShowHandler) OnCertificateLoaded(file metadata.CertificateFile) {
+	h.file = file
+}
+
+// Render prints out the details of the certificates in human-readable format.
+func (h *CertShowHandler) Render() error {
+	h.printer.Println("Certificate details")
+	h.printer.Println(certSeparator)
+	for i, cert := range h.file.Certificates {
+		if err := h.printCert(cert); err != nil {
+			return err
+		}
+		if i != len(h.file.Certificates)-1 {
+			h.printer.Println(certSeparator)
+		}
+	}
+	return nil
+}
+
+// printCert prints out the details of a certificate.
+func (h *CertShowHandler) printCert(cert *x509.Certificate) error {
+	h.printer.Println("Issuer:", cert.Issuer)
+	h.printer.Println("Subject:", cert.Subject)
+	h.printer.Println("Valid from:", cert.NotBefore)
+	h.printer.Println("Valid to:", cert.NotAfter)
+	h.printer.Println("IsCA:", cert.IsCA)
+	if pathLen, ok := certchain.MaxPathLen(cert); ok {
+		h.printer.Println("Max path length:", pathLen)
+	}
+	h.printer.Println("Chain position:", certchain.Position(cert))
+	h.printer.Println("Serial number:", strings.ToLower(cert.SerialNumber.Text(16)))
+	algorithm, size := certchain.PublicKey(cert)
+	if size > 0 {
+		h.printer.Printf("Public key: %s %d\n", algorithm, size)
+	} else {
+		h.printer.Println("Public key:", algorithm)
+	}
+	printList(h.printer, "Subject alternative names", certchain.SubjectAlternativeNames(cert))
+	printList(h.printer, "Key usage", certchain.KeyUsages(cert))
+	printList(h.printer, "Extended key usage", certchain.ExtKeyUsages(cert))
+	printList(h.printer, "Issuing certificate URLs", cert.IssuingCertificateURL)
+	printList(h.printer, "OCSP servers", cert.OCSPServer)
+	printList(h.printer, "CRL distribution points", cert.CRLDistributionPoints)
+	return h.printer.Println("SHA256 Thumbprint:", certchain.Fingerprint(cert))
+}
+
+// printList prints out the values with the name, if any.
+func printList(printer *output.Printer, name string, values []string) {
+	if len(values) > 0 {
+		printer.Printf("%s: %s\n", name, strings.Join(values, ", "))
+	}
+}
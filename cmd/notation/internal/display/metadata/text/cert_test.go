@@ -0,0 +1,237 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+func TestCertListHandler_Render(t *testing.T) {
+	t.Run("no certificates", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		h := NewCertListHandler(output.NewPrinter(&buf, &buf))
+		if err := h.Render(); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if got := buf.String(); got != "" {
+			t.Fatalf("expected empty output, got %q", got)
+		}
+	})
+
+	t.Run("with certificates", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		h := NewCertListHandler(output.NewPrinter(&buf, &buf))
+		h.OnCertificateListed(metadata.CertificateFile{
+			StoreType: "ca",
+			StoreName: "test",
+			Path:      filepath.Join("x509", "ca", "test", "root.crt"),
+		})
+		h.OnCertificateListed(metadata.CertificateFile{
+			StoreType: "signingAuthority",
+			StoreName: "test",
+			Path:      filepath.Join("x509", "signingAuthority", "test", "leaf.pem"),
+		})
+		if err := h.Render(); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		expected := `STORE TYPE         STORE NAME   CERTIFICATE   
+ca                 test         root.crt      
+signingAuthority   test         leaf.pem      
+`
+		if got := buf.String(); got != expected {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	})
+}
+
+func TestCertShowHandler_Render(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(0x1a2b),
+		Subject:               pkix.Name{CommonName: "root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+		CRLDistributionPoints: []string{"http://example.com/root.crl"},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := bytes.Buffer{}
+	h := NewCertShowHandler(output.NewPrinter(&buf, &buf))
+	h.OnCertificateLoaded(metadata.CertificateFile{
+		StoreType:    "ca",
+		StoreName:    "test",
+		Path:         "root.crt",
+		Certificates: []*x509.Certificate{cert},
+	})
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	got := buf.String()
+	for _, expected := range []string{
+		"Subject: CN=root\n",
+		"IsCA: true\n",
+		"Max path length: 0\n",
+		"Chain position: root\n",
+		"Serial number: 1a2b\n",
+		"Public key: ECDSA 256\n",
+		"Key usage: Certificate Sign\n",
+		"CRL distribution points: http://example.com/root.crl\n",
+		"SHA256 Thumbprint: ",
+	} {
+		if !strings.Contains(got, expected) {
+			t.Fatalf("expected output to contain %q, got %q", expected, got)
+		}
+	}
+	if strings.Contains(got, "Extended key usage") {
+		t.Fatalf("expected no extended key usage, got %q", got)
+	}
+}
+
+func TestCertCheckHandler_Render(t *testing.T) {
+	t.Run("no findings", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		h := NewCertCheckHandler(output.NewPrinter(&buf, &buf))
+		h.OnTrustStoresChecked(2, nil)
+		if err := h.Render(); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		expected := "No issues found in 2 trust store(s).\n"
+		if got := buf.String(); got != expected {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("with findings", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		h := NewCertCheckHandler(output.NewPrinter(&buf, &buf))
+		h.OnTrustStoresChecked(1, []policy.Finding{
+			{
+				Severity:   policy.SeverityError,
+				Rule:       policy.RuleCertificateExpired,
+				TrustStore: "tsa:timestamp",
+				Message:    "expired",
+				Hint:       "renew",
+			},
+		})
+		if err := h.Render(); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		expected := `error: trust store "tsa:timestamp": expired [certificate-expired]
+  hint: renew
+
+Found 1 error(s) and 0 warning(s) in 1 trust store(s).
+`
+		if got := buf.String(); got != expected {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	})
+}
+
+func TestRevocationCheckHandler_Render(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := bytes.Buffer{}
+	h := NewRevocationCheckHandler(output.NewPrinter(&buf, &buf))
+	h.OnRevocationChecked(metadata.RevocationCheck{
+		Source:       "chain.pem",
+		Purpose:      "codeSigning",
+		Certificates: []*x509.Certificate{cert},
+		Results: []*result.CertRevocationResult{{
+			Result:           result.ResultRevoked,
+			RevocationMethod: result.RevocationMethodOCSPFallbackCRL,
+			ServerResults: []*result.ServerResult{
+				{
+					Server:           "http://example.com/ocsp",
+					Result:           result.ResultUnknown,
+					RevocationMethod: result.RevocationMethodOCSP,
+					Error:            errors.New("timeout"),
+				},
+				{
+					Server:           "http://example.com/leaf.crl",
+					Result:           result.ResultRevoked,
+					RevocationMethod: result.RevocationMethodCRL,
+				},
+			},
+		}},
+		CRLCacheHits: map[string]bool{"http://example.com/leaf.crl": true},
+	})
+	h.OnRevocationChecked(metadata.RevocationCheck{
+		Source:  "sig.jws",
+		Purpose: "timestamping",
+		Err:     errors.New("no timestamp countersignature"),
+	})
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := `Revocation status of chain.pem for codeSigning: Revoked
+  Certificate 1 of 1: CN=leaf
+    SHA-256 fingerprint: ` + certchain.Fingerprint(cert) + `
+    Result: Revoked (OCSPFallbackCRL)
+    OCSP http://example.com/ocsp: Unknown, error: timeout
+    CRL http://example.com/leaf.crl: Revoked (CRL cache hit)
+
+Revocation status of sig.jws for timestamping: failed to check: no timestamp countersignature
+`
+	if got := buf.String(); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
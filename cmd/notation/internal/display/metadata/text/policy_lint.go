@@ -0,0 +1,84 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+// PolicyLintHandler is a handler for rendering the findings of linting a
+// trust policy configuration in human-readable format.
+// It implements metadata/PolicyLintHandler.
+type PolicyLintHandler struct {
+	printer    *output.Printer
+	policyPath string
+	findings   []policy.Finding
+}
+
+// NewPolicyLintHandler creates a new PolicyLintHandler.
+func NewPolicyLintHandler(printer *output.Printer) *PolicyLintHandler {
+	return &PolicyLintHandler{
+		printer: printer,
+	}
+}
+
+// OnPolicyLinted sets the path of the linted trust policy configuration and
+// the findings for the handler.
+func (h *PolicyLintHandler) OnPolicyLinted(policyPath string, findings []policy.Finding) {
+	h.policyPath = policyPath
+	h.findings = findings
+}
+
+// Render prints out the findings in human-readable format.
+func (h *PolicyLintHandler) Render() error {
+	if len(h.findings) == 0 {
+		return h.printer.Printf("No issues found in %s.\n", h.policyPath)
+	}
+	if err := printFindings(h.printer, h.findings); err != nil {
+		return err
+	}
+	errorCount, warningCount := policy.CountFindings(h.findings)
+	return h.printer.Printf("\nFound %d error(s) and %d warning(s) in %s.\n", errorCount, warningCount, h.policyPath)
+}
+
+// printFindings prints out each finding with its hint in human-readable
+// format.
+func printFindings(printer *output.Printer, findings []policy.Finding) error {
+	for _, finding := range findings {
+		var subjects []string
+		if finding.Statement != "" {
+			subjects = append(subjects, fmt.Sprintf("statement %q", finding.Statement))
+		}
+		if finding.TrustStore != "" {
+			subjects = append(subjects, fmt.Sprintf("trust store %q", finding.TrustStore))
+		}
+		subject := ""
+		if len(subjects) > 0 {
+			subject = strings.Join(subjects, ", ") + ": "
+		}
+		if err := printer.Printf("%s: %s%s [%s]\n", finding.Severity, subject, finding.Message, finding.Rule); err != nil {
+			return err
+		}
+		if finding.Hint != "" {
+			if err := printer.Printf("  hint: %s\n", finding.Hint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+func TestPolicyLintHandler_Render(t *testing.T) {
+	t.Run("no findings", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		h := NewPolicyLintHandler(output.NewPrinter(&buf, &buf))
+		h.OnPolicyLinted("trustpolicy.oci.json", nil)
+		if err := h.Render(); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		expected := "No issues found in trustpolicy.oci.json.\n"
+		if got := buf.String(); got != expected {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("with findings", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		h := NewPolicyLintHandler(output.NewPrinter(&buf, &buf))
+		h.OnPolicyLinted("trustpolicy.oci.json", []policy.Finding{
+			{
+				Severity:   policy.SeverityError,
+				Rule:       policy.RuleTrustStoreMissing,
+				Statement:  "test",
+				TrustStore: "ca:test",
+				Message:    "the trust store does not exist",
+				Hint:       "add certificates",
+			},
+			{
+				Severity: policy.SeverityWarning,
+				Rule:     policy.RuleInvalidConfiguration,
+				Message:  "invalid",
+			},
+		})
+		if err := h.Render(); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		expected := `error: statement "test", trust store "ca:test": the trust store does not exist [trust-store-missing]
+  hint: add certificates
+warning: invalid [invalid-configuration]
+
+Found 1 error(s) and 1 warning(s) in trustpolicy.oci.json.
+`
+		if got := buf.String(); got != expected {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	})
+}
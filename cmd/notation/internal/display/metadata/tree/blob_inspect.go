@@ -15,6 +15,7 @@ package tree
 
 import (
 	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
 )
 
@@ -24,6 +25,8 @@ import (
 type BlobInspectHandler struct {
 	printer       *output.Printer
 	signatureNode *node
+	bundledCRLs   []metadata.BundledCRL
+	blobCheck     *metadata.BlobCheckResult
 }
 
 // NewBlobInspectHandler creates a BlobInspectHandler to inspect signature and
@@ -44,7 +47,25 @@ func (h *BlobInspectHandler) OnEnvelopeParsed(signaturePath, signatureMediaType
 	return nil
 }
 
+// OnBundledCRLsParsed sets the CRLs captured in a signature bundle for the
+// handler.
+func (h *BlobInspectHandler) OnBundledCRLsParsed(crls []metadata.BundledCRL) {
+	h.bundledCRLs = crls
+}
+
+// OnBlobChecked sets the result of checking a blob against the signed
+// artifact for the handler.
+func (h *BlobInspectHandler) OnBlobChecked(result metadata.BlobCheckResult) {
+	h.blobCheck = &result
+}
+
 // Render prints out the metadata information in tree format.
 func (h *BlobInspectHandler) Render() error {
+	if len(h.bundledCRLs) > 0 {
+		addBundledCRLs(h.signatureNode, h.bundledCRLs)
+	}
+	if h.blobCheck != nil {
+		addBlobCheck(h.signatureNode, *h.blobCheck)
+	}
 	return h.signatureNode.Print(h.printer)
 }
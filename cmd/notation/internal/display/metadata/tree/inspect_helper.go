@@ -26,6 +26,7 @@ import (
 
 	coresignature "github.com/notaryproject/notation-core-go/signature"
 	"github.com/notaryproject/notation-go/plugin/proto"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
 	envelopeutil "github.com/notaryproject/notation/v2/internal/envelope"
 	"github.com/notaryproject/tspclient-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -132,6 +133,40 @@ func addCertificates(node *node, certChain []*x509.Certificate) {
 	}
 }
 
+func addBundledCRLs(node *node, crls []metadata.BundledCRL) {
+	crlListNode := node.Add("bundled CRLs")
+	for _, bundledCRL := range crls {
+		crlNode := crlListNode.AddPair("url", bundledCRL.URL)
+		crlNode.AddPair("issued by", bundledCRL.CRL.Issuer.String())
+		crlNode.AddPair("this update", formatTime(bundledCRL.CRL.ThisUpdate))
+		crlNode.AddPair("next update", formatTime(bundledCRL.CRL.NextUpdate))
+		crlNode.AddPair("revoked certificates", strconv.Itoa(len(bundledCRL.CRL.RevokedCertificateEntries)))
+	}
+}
+
+func addBlobCheck(node *node, result metadata.BlobCheckResult) {
+	blobNode := node.Add("blob check")
+	blobNode.AddPair("path", result.Path)
+	blobNode.AddPair("digest", result.Digest.String())
+	blobNode.AddPair("size", strconv.FormatInt(result.Size, 10))
+	blobNode.AddPair("result", blobCheckSummary(result))
+}
+
+// blobCheckSummary returns "match" if the blob matches the signed artifact,
+// otherwise it describes the mismatch.
+func blobCheckSummary(result metadata.BlobCheckResult) string {
+	switch {
+	case result.Matched():
+		return "match"
+	case !result.DigestMatched && !result.SizeMatched:
+		return "mismatch: digest and size do not match the signed artifact"
+	case !result.DigestMatched:
+		return "mismatch: digest does not match the signed artifact"
+	default:
+		return "mismatch: size does not match the signed artifact"
+	}
+}
+
 func formatTime(t time.Time) string {
 	return t.Format(time.ANSIC)
 }
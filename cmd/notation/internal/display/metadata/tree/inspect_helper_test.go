@@ -20,6 +20,7 @@ import (
 	"time"
 
 	coresignature "github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/metadata"
 )
 
 func TestAddSignedAttributes(t *testing.T) {
@@ -104,6 +105,37 @@ func TestAddUserDefinedAttributes(t *testing.T) {
 	})
 }
 
+func TestAddBlobCheck(t *testing.T) {
+	node := newNode("root")
+	addBlobCheck(node, metadata.BlobCheckResult{
+		Path:          "blob",
+		Digest:        "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		Size:          7,
+		DigestMatched: true,
+		SizeMatched:   true,
+	})
+	blobNode := node.Children[0]
+	if blobNode.Value != "blob check" {
+		t.Fatalf("expected 'blob check' node, got %s", blobNode.Value)
+	}
+	if len(blobNode.Children) != 4 || blobNode.Children[3].Value != "result: match" {
+		t.Fatalf("unexpected blob check node %v", blobNode.Children)
+	}
+
+	for _, tt := range []struct {
+		result   metadata.BlobCheckResult
+		expected string
+	}{
+		{metadata.BlobCheckResult{SizeMatched: true}, "mismatch: digest does not match the signed artifact"},
+		{metadata.BlobCheckResult{DigestMatched: true}, "mismatch: size does not match the signed artifact"},
+		{metadata.BlobCheckResult{}, "mismatch: digest and size do not match the signed artifact"},
+	} {
+		if got := blobCheckSummary(tt.result); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
 func TestAddTimestamp(t *testing.T) {
 	t.Run("invalid timestamp signature", func(t *testing.T) {
 		node := newNode("root")
@@ -0,0 +1,82 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+// PolicyTestHandler is a handler for rendering the trust policy statement
+// selected for an artifact in a tree format. It implements the
+// metadata.PolicyTestHandler interface.
+type PolicyTestHandler struct {
+	printer *output.Printer
+	root    *node
+}
+
+// NewPolicyTestHandler creates a PolicyTestHandler to print the trust policy
+// statement selected for an artifact in tree format.
+func NewPolicyTestHandler(printer *output.Printer) *PolicyTestHandler {
+	return &PolicyTestHandler{
+		printer: printer,
+	}
+}
+
+// OnStatementResolved sets the artifact and the statement resolved for it for
+// the handler.
+func (h *PolicyTestHandler) OnStatementResolved(target string, resolution *policy.Resolution) {
+	h.root = newNode(target)
+	statement := resolution.Statement
+	statementNode := h.root.AddPair("trust policy statement", statement.Name)
+	statementNode.AddPair("reason", resolution.Reason)
+	if len(statement.RegistryScopes) > 0 {
+		statementNode.AddPair("registry scopes", strings.Join(statement.RegistryScopes, ", "))
+	}
+
+	levelNode := statementNode.AddPair("verification level", resolution.VerificationLevel.Name)
+	for _, validationType := range trustpolicy.ValidationTypes {
+		action := string(resolution.VerificationLevel.Enforcement[validationType])
+		if _, ok := statement.SignatureVerification.Override[validationType]; ok {
+			action += " (overridden)"
+		}
+		levelNode.AddPair(string(validationType), action)
+	}
+	if resolution.VerificationLevel.Name == trustpolicy.LevelSkip.Name {
+		return
+	}
+	statementNode.AddPair("verify timestamp", string(resolution.VerifyTimestamp))
+
+	identitiesNode := statementNode.Add("trusted identities")
+	for _, identity := range statement.TrustedIdentities {
+		identitiesNode.Add(identity)
+	}
+	storesNode := statementNode.Add("trust stores")
+	for _, store := range resolution.TrustStores {
+		storeNode := storesNode.Add(store.Name)
+		if store.Err != nil {
+			storeNode.AddPair("error", store.Err.Error())
+			continue
+		}
+		addCertificates(storeNode, store.Certificates)
+	}
+}
+
+// Render prints out the trust policy statement in tree format.
+func (h *PolicyTestHandler) Render() error {
+	return h.root.Print(h.printer)
+}
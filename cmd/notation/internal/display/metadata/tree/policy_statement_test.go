@@ -0,0 +1,71 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+)
+
+func TestPolicyTestHandler_Render(t *testing.T) {
+	buf := bytes.Buffer{}
+	handler := NewPolicyTestHandler(output.NewPrinter(&buf, &buf))
+	handler.OnStatementResolved("localhost:5000/test:v1", &policy.Resolution{
+		Statement: policy.Statement{
+			Name: "test",
+			SignatureVerification: trustpolicy.SignatureVerification{
+				VerificationLevel: trustpolicy.LevelAudit.Name,
+				Override: map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+					trustpolicy.TypeExpiry: trustpolicy.ActionLog,
+				},
+			},
+			TrustedIdentities: []string{"*"},
+			RegistryScopes:    []string{"localhost:5000/test"},
+		},
+		Reason:            "matched",
+		VerificationLevel: trustpolicy.LevelAudit,
+		VerifyTimestamp:   trustpolicy.OptionAfterCertExpiry,
+		TrustStores: []policy.ResolvedTrustStore{
+			{Name: "ca:missing", Err: errors.New("not found")},
+		},
+	})
+	if err := handler.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := `localhost:5000/test:v1
+└── trust policy statement: test
+    ├── reason: matched
+    ├── registry scopes: localhost:5000/test
+    ├── verification level: audit
+    │   ├── integrity: enforce
+    │   ├── authenticity: log
+    │   ├── authenticTimestamp: log
+    │   ├── expiry: log (overridden)
+    │   └── revocation: log
+    ├── verify timestamp: afterCertExpiry
+    ├── trusted identities
+    │   └── *
+    └── trust stores
+        └── ca:missing
+            └── error: not found
+`
+	if got := buf.String(); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+)
+
+const (
+	// ArtifactTypeTrustConfig is the artifact type of the trust configuration
+	// pushed by `notation policy push`.
+	ArtifactTypeTrustConfig = "application/vnd.cncf.notary.trust-config.v1"
+
+	// MediaTypeOCITrustPolicy is the media type of the OCI trust policy
+	// configuration in the trust configuration.
+	MediaTypeOCITrustPolicy = "application/vnd.cncf.notary.trustpolicy.oci.v1+json"
+
+	// MediaTypeBlobTrustPolicy is the media type of the blob trust policy
+	// configuration in the trust configuration.
+	MediaTypeBlobTrustPolicy = "application/vnd.cncf.notary.trustpolicy.blob.v1+json"
+
+	// MediaTypeCertificate is the media type of a certificate file of a trust
+	// store in the trust configuration.
+	MediaTypeCertificate = "application/vnd.cncf.notary.truststore.certificate.v1"
+
+	// MaxBundleFileSize is the maximum size in bytes of a file in the trust
+	// configuration.
+	MaxBundleFileSize = 4 << 20
+)
+
+// validFileName matches the cross-platform compatible file names of trust
+// stores and certificates.
+var validFileName = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// BundleFile is a file of the trust configuration distributed by `notation
+// policy push` and `notation policy pull`.
+type BundleFile struct {
+	// Path is the slash separated path of the file relative to the
+	// configuration directory.
+	Path string
+
+	// MediaType is the media type of the file.
+	MediaType string
+
+	// Content is the content of the file.
+	Content []byte
+}
+
+// CollectBundle collects the OCI and the blob trust policy configurations in
+// fsys, and the certificates of trustStores, each in the format of
+// "<store_type>:<store_name>". At least one trust policy configuration is
+// required.
+func CollectBundle(fsys dir.SysFS, trustStores []string) ([]BundleFile, error) {
+	var files []BundleFile
+
+	// trust policy configurations
+	for _, policyPath := range []string{dir.PathOCITrustPolicy, dir.PathTrustPolicy} {
+		content, err := fs.ReadFile(fsys, policyPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OCI trust policy configuration: %w", err)
+		}
+		files = append(files, BundleFile{Path: dir.PathOCITrustPolicy, MediaType: MediaTypeOCITrustPolicy, Content: content})
+		break
+	}
+	content, err := fs.ReadFile(fsys, dir.PathBlobTrustPolicy)
+	if err == nil {
+		files = append(files, BundleFile{Path: dir.PathBlobTrustPolicy, MediaType: MediaTypeBlobTrustPolicy, Content: content})
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read blob trust policy configuration: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, errors.New("neither OCI nor blob trust policy configuration exists")
+	}
+
+	// trust stores
+	for _, name := range trustStores {
+		storeType, namedStore, ok := strings.Cut(name, ":")
+		if !ok || !isValidStoreType(storeType) || !validFileName.MatchString(namedStore) {
+			return nil, fmt.Errorf("invalid trust store %q, expecting \"<store_type>:<store_name>\"", name)
+		}
+		storeDir := dir.X509TrustStoreDir(storeType, namedStore)
+		entries, err := fs.ReadDir(fsys, storeDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trust store %q: %w", name, err)
+		}
+		var count int
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() {
+				continue
+			}
+			content, err := fs.ReadFile(fsys, path.Join(storeDir, entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read certificate %s of trust store %q: %w", entry.Name(), name, err)
+			}
+			files = append(files, BundleFile{Path: path.Join(storeDir, entry.Name()), MediaType: MediaTypeCertificate, Content: content})
+			count++
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("trust store %q has no certificate", name)
+		}
+	}
+	return files, ValidateBundle(files)
+}
+
+// ListTrustStores lists the trust stores in fsys having at least one file, in
+// the format of "<store_type>:<store_name>".
+func ListTrustStores(fsys dir.SysFS) ([]string, error) {
+	var trustStores []string
+	for _, storeType := range truststore.Types {
+		storeEntries, err := fs.ReadDir(fsys, path.Join(dir.TrustStoreDir, "x509", string(storeType)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list trust stores of type %s: %w", storeType, err)
+		}
+		for _, storeEntry := range storeEntries {
+			if !storeEntry.IsDir() || !validFileName.MatchString(storeEntry.Name()) {
+				continue
+			}
+			entries, err := fs.ReadDir(fsys, dir.X509TrustStoreDir(string(storeType), storeEntry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read trust store %s:%s: %w", storeType, storeEntry.Name(), err)
+			}
+			if slices.ContainsFunc(entries, func(entry fs.DirEntry) bool { return entry.Type().IsRegular() }) {
+				trustStores = append(trustStores, string(storeType)+":"+storeEntry.Name())
+			}
+		}
+	}
+	return trustStores, nil
+}
+
+// ValidateBundle validates the paths and the contents of files, so that they
+// can be safely installed into a configuration directory.
+func ValidateBundle(files []BundleFile) error {
+	seen := make(map[string]bool)
+	var hasPolicy bool
+	for _, file := range files {
+		if seen[file.Path] {
+			return fmt.Errorf("duplicate file %s in the trust configuration", file.Path)
+		}
+		seen[file.Path] = true
+		if len(file.Content) > MaxBundleFileSize {
+			return fmt.Errorf("file %s in the trust configuration exceeds the maximum size of %d bytes", file.Path, MaxBundleFileSize)
+		}
+
+		expectedMediaType := MediaTypeCertificate
+		switch file.Path {
+		case dir.PathOCITrustPolicy:
+			expectedMediaType = MediaTypeOCITrustPolicy
+		case dir.PathBlobTrustPolicy:
+			expectedMediaType = MediaTypeBlobTrustPolicy
+		}
+		if file.MediaType != expectedMediaType {
+			return fmt.Errorf("file %s in the trust configuration has unexpected media type %q", file.Path, file.MediaType)
+		}
+
+		switch file.Path {
+		case dir.PathOCITrustPolicy:
+			var doc trustpolicy.OCIDocument
+			if err := json.Unmarshal(file.Content, &doc); err != nil {
+				return fmt.Errorf("failed to parse OCI trust policy configuration: %w", err)
+			}
+			if err := doc.Validate(); err != nil {
+				return fmt.Errorf("invalid OCI trust policy configuration: %w", err)
+			}
+			hasPolicy = true
+		case dir.PathBlobTrustPolicy:
+			var doc trustpolicy.BlobDocument
+			if err := json.Unmarshal(file.Content, &doc); err != nil {
+				return fmt.Errorf("failed to parse blob trust policy configuration: %w", err)
+			}
+			if err := doc.Validate(); err != nil {
+				return fmt.Errorf("invalid blob trust policy configuration: %w", err)
+			}
+			hasPolicy = true
+		default:
+			if _, _, err := parseTrustStoreFilePath(file.Path); err != nil {
+				return err
+			}
+			certs, err := parseCertificates(file.Content)
+			if err != nil || len(certs) == 0 {
+				return fmt.Errorf("file %s in the trust configuration is not a certificate", file.Path)
+			}
+		}
+	}
+	if !hasPolicy {
+		return errors.New("the trust configuration has no trust policy configuration")
+	}
+	return nil
+}
+
+// parseTrustStoreFilePath parses path in the format of
+// "truststore/x509/<store_type>/<store_name>/<file_name>", and returns the
+// path of the trust store and the file name.
+func parseTrustStoreFilePath(filePath string) (string, string, error) {
+	parts := strings.Split(filePath, "/")
+	if len(parts) != 5 || parts[0] != dir.TrustStoreDir || parts[1] != "x509" ||
+		!isValidStoreType(parts[2]) || !validFileName.MatchString(parts[3]) ||
+		!validFileName.MatchString(parts[4]) || parts[3] == "." || parts[3] == ".." || parts[4] == "." || parts[4] == ".." {
+		return "", "", fmt.Errorf("unexpected file %s in the trust configuration", filePath)
+	}
+	return path.Join(parts[:4]...), parts[4], nil
+}
+
+// isValidStoreType reports whether storeType is a supported trust store type.
+func isValidStoreType(storeType string) bool {
+	return slices.Contains(truststore.Types, truststore.Type(storeType))
+}
+
+// parseCertificates parses the PEM or DER encoded certificates in data.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	block, rest := pem.Decode(data)
+	if block == nil {
+		return x509.ParseCertificates(data)
+	}
+	for ; block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// InstalledPaths returns the paths, relative to the configuration directory,
+// replaced by installing files: the trust policy configurations and the
+// directories of the trust stores.
+func InstalledPaths(files []BundleFile) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		target := file.Path
+		if file.MediaType == MediaTypeCertificate {
+			target, _, _ = parseTrustStoreFilePath(file.Path)
+		}
+		if !seen[target] {
+			seen[target] = true
+			paths = append(paths, target)
+		}
+	}
+	return paths
+}
+
+// InstallBundle installs the validated files into the configuration directory
+// root. The trust policy configurations in files replace the existing ones,
+// and the trust stores in files replace the existing trust stores of the same
+// type and name. Installing an OCI trust policy configuration removes the old
+// trust policy configuration "trustpolicy.json".
+//
+// The files are staged in a temporary directory under root first, and then
+// moved into place. If moving fails, the replaced paths are restored.
+func InstallBundle(root string, files []BundleFile) (err error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return err
+	}
+	staging, err := os.MkdirTemp(root, ".install-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	// stage the files
+	for _, file := range files {
+		stagedPath := filepath.Join(staging, "new", filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(stagedPath), 0700); err != nil {
+			return err
+		}
+		if err := os.WriteFile(stagedPath, file.Content, 0600); err != nil {
+			return fmt.Errorf("failed to stage %s: %w", file.Path, err)
+		}
+		if file.MediaType == MediaTypeCertificate {
+			// certificates are validated in the same way as `notation cert add`
+			if _, err := corex509.ReadCertificateFile(stagedPath); err != nil {
+				return fmt.Errorf("invalid certificate %s: %w", file.Path, err)
+			}
+		}
+	}
+	paths := InstalledPaths(files)
+	if _, err := os.Lstat(filepath.Join(root, dir.PathTrustPolicy)); err == nil && slices.Contains(paths, dir.PathOCITrustPolicy) {
+		paths = append(paths, dir.PathTrustPolicy)
+	}
+
+	// move the staged files into place, restoring the replaced paths on
+	// failure
+	type replacement struct {
+		target string
+		backup string
+		staged bool
+	}
+	var done []replacement
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(done) - 1; i >= 0; i-- {
+			if done[i].staged {
+				os.RemoveAll(done[i].target)
+			}
+			if done[i].backup != "" {
+				os.Rename(done[i].backup, done[i].target)
+			}
+		}
+	}()
+	for i, relPath := range paths {
+		r := replacement{target: filepath.Join(root, filepath.FromSlash(relPath))}
+		if _, err := os.Lstat(r.target); err == nil {
+			r.backup = filepath.Join(staging, "backup", fmt.Sprint(i))
+			if err := os.MkdirAll(filepath.Dir(r.backup), 0700); err != nil {
+				return err
+			}
+			if err := os.Rename(r.target, r.backup); err != nil {
+				return fmt.Errorf("failed to replace %s: %w", relPath, err)
+			}
+		}
+		done = append(done, r)
+		stagedPath := filepath.Join(staging, "new", filepath.FromSlash(relPath))
+		if _, err := os.Lstat(stagedPath); err != nil {
+			// the path is removed only, e.g., the old trust policy
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(r.target), 0700); err != nil {
+			return err
+		}
+		if err := os.Rename(stagedPath, r.target); err != nil {
+			return fmt.Errorf("failed to install %s: %w", relPath, err)
+		}
+		done[len(done)-1].staged = true
+	}
+	return nil
+}
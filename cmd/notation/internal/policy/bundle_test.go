@@ -0,0 +1,169 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+const testOCIPolicy = `{"version":"1.0","trustPolicies":[{"name":"test","registryScopes":["*"],"signatureVerification":{"level":"strict"},"trustStores":["ca:test"],"trustedIdentities":["*"]}]}`
+
+func TestCollectBundle(t *testing.T) {
+	root := t.TempDir()
+	if _, err := CollectBundle(dir.NewSysFS(root), nil); err == nil {
+		t.Fatal("CollectBundle() expected error without trust policy configuration")
+	}
+
+	// the old trust policy file is collected as trustpolicy.oci.json
+	if err := os.WriteFile(filepath.Join(root, dir.PathTrustPolicy), []byte(testOCIPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	writeTestCert(t, root, "ca", "test", "Notary", true, time.Now().Add(time.Hour))
+	files, err := CollectBundle(dir.NewSysFS(root), []string{"ca:test"})
+	if err != nil {
+		t.Fatalf("CollectBundle() error = %v", err)
+	}
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	if want := []string{"trustpolicy.oci.json", "truststore/x509/ca/test/Notary.crt"}; !reflect.DeepEqual(paths, want) {
+		t.Fatalf("CollectBundle() paths = %v, want %v", paths, want)
+	}
+
+	for _, trustStore := range []string{"ca:missing", "invalid", "unknown:test"} {
+		if _, err := CollectBundle(dir.NewSysFS(root), []string{trustStore}); err == nil {
+			t.Errorf("CollectBundle(%q) expected error", trustStore)
+		}
+	}
+}
+
+func TestListTrustStores(t *testing.T) {
+	root := t.TempDir()
+	trustStores, err := ListTrustStores(dir.NewSysFS(root))
+	if err != nil || len(trustStores) != 0 {
+		t.Fatalf("ListTrustStores() = %v, %v, want no trust store", trustStores, err)
+	}
+
+	writeTestCert(t, root, "ca", "test", "Notary", true, time.Now().Add(time.Hour))
+	writeTestCert(t, root, "tsa", "timestamp", "Notary", true, time.Now().Add(time.Hour))
+	if err := os.MkdirAll(filepath.Join(root, dir.X509TrustStoreDir("signingAuthority", "empty")), 0700); err != nil {
+		t.Fatal(err)
+	}
+	trustStores, err = ListTrustStores(dir.NewSysFS(root))
+	if err != nil {
+		t.Fatalf("ListTrustStores() error = %v", err)
+	}
+	if want := []string{"ca:test", "tsa:timestamp"}; !reflect.DeepEqual(trustStores, want) {
+		t.Fatalf("ListTrustStores() = %v, want %v", trustStores, want)
+	}
+}
+
+func TestValidateBundle(t *testing.T) {
+	root := t.TempDir()
+	writeTestCert(t, root, "ca", "test", "Notary", true, time.Now().Add(time.Hour))
+	cert, err := os.ReadFile(filepath.Join(root, dir.X509TrustStoreDir("ca", "test"), "Notary.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	policyFile := BundleFile{Path: dir.PathOCITrustPolicy, MediaType: MediaTypeOCITrustPolicy, Content: []byte(testOCIPolicy)}
+	if err := ValidateBundle([]BundleFile{policyFile, {Path: "truststore/x509/ca/test/root.crt", MediaType: MediaTypeCertificate, Content: cert}}); err != nil {
+		t.Fatalf("ValidateBundle() error = %v", err)
+	}
+
+	for name, files := range map[string][]BundleFile{
+		"no policy":          {{Path: "truststore/x509/ca/test/root.crt", MediaType: MediaTypeCertificate, Content: cert}},
+		"invalid policy":     {{Path: dir.PathOCITrustPolicy, MediaType: MediaTypeOCITrustPolicy, Content: []byte(`{"version":"1.0"}`)}},
+		"duplicate":          {policyFile, policyFile},
+		"path traversal":     {policyFile, {Path: "truststore/x509/ca/../../../config.json", MediaType: MediaTypeCertificate, Content: cert}},
+		"unexpected file":    {policyFile, {Path: dir.PathConfigFile, MediaType: MediaTypeCertificate, Content: cert}},
+		"invalid store type": {policyFile, {Path: "truststore/x509/root/test/root.crt", MediaType: MediaTypeCertificate, Content: cert}},
+		"not certificate":    {policyFile, {Path: "truststore/x509/ca/test/root.crt", MediaType: MediaTypeCertificate, Content: []byte("test")}},
+		"media type":         {{Path: dir.PathOCITrustPolicy, MediaType: MediaTypeBlobTrustPolicy, Content: []byte(testOCIPolicy)}},
+	} {
+		if err := ValidateBundle(files); err == nil {
+			t.Errorf("ValidateBundle() expected error for %s", name)
+		}
+	}
+}
+
+func TestInstallBundle(t *testing.T) {
+	source := t.TempDir()
+	writeTestCert(t, source, "ca", "test", "Notary", true, time.Now().Add(time.Hour))
+	cert, err := os.ReadFile(filepath.Join(source, dir.X509TrustStoreDir("ca", "test"), "Notary.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	writeTestCert(t, root, "ca", "test", "Old", true, time.Now().Add(time.Hour))
+	writeTestCert(t, root, "ca", "other", "Other", true, time.Now().Add(time.Hour))
+	if err := os.WriteFile(filepath.Join(root, dir.PathTrustPolicy), []byte(testOCIPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	files := []BundleFile{
+		{Path: dir.PathOCITrustPolicy, MediaType: MediaTypeOCITrustPolicy, Content: []byte(testOCIPolicy)},
+		{Path: "truststore/x509/ca/test/Notary.crt", MediaType: MediaTypeCertificate, Content: cert},
+	}
+	if err := InstallBundle(root, files); err != nil {
+		t.Fatalf("InstallBundle() error = %v", err)
+	}
+
+	// the trust store is replaced, the other trust stores are kept and the
+	// old trust policy is removed
+	for _, path := range []string{dir.PathOCITrustPolicy, "truststore/x509/ca/test/Notary.crt", "truststore/x509/ca/other/Other.crt"} {
+		if _, err := os.Stat(filepath.Join(root, path)); err != nil {
+			t.Errorf("%s should exist: %v", path, err)
+		}
+	}
+	for _, path := range []string{dir.PathTrustPolicy, "truststore/x509/ca/test/Old.crt"} {
+		if _, err := os.Stat(filepath.Join(root, path)); !os.IsNotExist(err) {
+			t.Errorf("%s should not exist: %v", path, err)
+		}
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".install-") {
+			t.Errorf("staging directory %s should be removed", entry.Name())
+		}
+	}
+}
+
+func TestInstallBundleInvalidCertificate(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, dir.PathOCITrustPolicy), []byte("existing"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	files := []BundleFile{
+		{Path: dir.PathOCITrustPolicy, MediaType: MediaTypeOCITrustPolicy, Content: []byte(testOCIPolicy)},
+		{Path: "truststore/x509/ca/test/root.crt", MediaType: MediaTypeCertificate, Content: []byte("invalid")},
+	}
+	if err := InstallBundle(root, files); err == nil {
+		t.Fatal("InstallBundle() expected error for invalid certificate")
+	}
+	content, err := os.ReadFile(filepath.Join(root, dir.PathOCITrustPolicy))
+	if err != nil || string(content) != "existing" {
+		t.Fatalf("existing configuration should be untouched, got %q, %v", content, err)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/notaryproject/notation-go/verifier/truststore"
+)
+
+// TrustStoreFiles are the files of a trust store.
+type TrustStoreFiles struct {
+	// Name is the name of the trust store in the format
+	// "<store_type>:<store_name>".
+	Name string
+
+	// Paths are the paths of the files in the trust store.
+	Paths []string
+}
+
+// CheckOptions are the options of CheckTrustStores.
+type CheckOptions struct {
+	// ExpiryThreshold is the period before the expiry of a certificate in
+	// which it is reported as expiring.
+	ExpiryThreshold time.Duration
+
+	// Now is the time to check the expiry of certificates against. The
+	// current time is used if it is zero.
+	Now time.Time
+
+	// SkipReferences skips reporting the trust stores referenced by no
+	// trust policy statement, e.g. when the trust policy configurations
+	// cannot be loaded.
+	SkipReferences bool
+}
+
+// CheckTrustStores checks the health of the trust stores regardless of how
+// the trust policy configurations use them. It reports
+//   - files that are not valid certificate files, including files with some
+//     certificates that cannot be parsed, as signature verification fails
+//     for any statement referencing the trust store
+//   - certificates that are expired or expire within the threshold
+//   - leaf certificates in trust stores of type ca
+//   - trust stores referenced by none of the statements
+//
+// Findings are reported in the order of the trust stores and their files.
+func CheckTrustStores(stores []TrustStoreFiles, statements []Statement, opts CheckOptions) []Finding {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	var findings []Finding
+	for _, store := range stores {
+		storeType, _, _ := strings.Cut(store.Name, ":")
+		for _, path := range store.Paths {
+			fileName := filepath.Base(path)
+			certs, err := parseCertificateFile(path)
+			if err != nil {
+				findings = append(findings, Finding{
+					Severity:   SeverityError,
+					Rule:       RuleCertificateFileInvalid,
+					TrustStore: store.Name,
+					Message:    fmt.Sprintf("signature verification with the trust store fails, as file %s is not a valid certificate file: %s", fileName, err),
+					Hint:       "remove or replace the file with `notation cert delete`",
+				})
+				continue
+			}
+			for _, cert := range certs {
+				subject := fmt.Sprintf("certificate %q in file %s", cert.Subject, fileName)
+				if finding, ok := checkExpiry(store.Name, subject, cert, opts.Now, opts.ExpiryThreshold); ok {
+					findings = append(findings, finding)
+				}
+				if storeType == string(truststore.TypeCA) && !cert.IsCA {
+					findings = append(findings, Finding{
+						Severity:   SeverityWarning,
+						Rule:       RuleLeafCertificate,
+						TrustStore: store.Name,
+						Message:    fmt.Sprintf("%s is a leaf certificate, only signatures made with it are trusted and renewing it breaks verification", subject),
+						Hint:       "trust the root CA certificate of the signing certificate instead",
+					})
+				}
+			}
+		}
+		if !opts.SkipReferences && !isReferenced(store.Name, statements) {
+			findings = append(findings, Finding{
+				Severity:   SeverityWarning,
+				Rule:       RuleTrustStoreUnreferenced,
+				TrustStore: store.Name,
+				Message:    "the trust store is referenced by no trust policy statement",
+				Hint:       "reference the trust store in a trust policy statement, or delete it with `notation cert delete --all`",
+			})
+		}
+	}
+	return findings
+}
+
+// isReferenced returns true if any of the statements references the trust
+// store named "<store_type>:<store_name>".
+func isReferenced(name string, statements []Statement) bool {
+	return slices.ContainsFunc(statements, func(statement Statement) bool {
+		return slices.Contains(statement.TrustStores, name)
+	})
+}
+
+// parseCertificateFile parses the certificates in a PEM or DER encoded
+// certificate file the same way as signature verification does, but reports
+// which of the PEM encoded certificates cannot be parsed.
+func parseCertificateFile(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, rest := pem.Decode(data)
+	if block == nil {
+		// data may be in DER format
+		certs, err := x509.ParseCertificates(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(certs) == 0 {
+			return nil, errors.New("no certificate found")
+		}
+		return certs, nil
+	}
+
+	var blocks []*pem.Block
+	for block != nil {
+		blocks = append(blocks, block)
+		block, rest = pem.Decode(rest)
+	}
+	var certs []*x509.Certificate
+	var errs []string
+	for i, block := range blocks {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("certificate %d of %d cannot be parsed: %s", i+1, len(blocks), err))
+			continue
+		}
+		certs = append(certs, cert)
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	return certs, nil
+}
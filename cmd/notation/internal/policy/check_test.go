@@ -0,0 +1,104 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+func TestCheckTrustStores(t *testing.T) {
+	now := time.Now()
+	root := t.TempDir()
+	writeTestCert(t, root, "ca", "acme", "valid", true, now.Add(365*24*time.Hour))
+	writeTestCert(t, root, "ca", "acme", "leaf", false, now.Add(365*24*time.Hour))
+	writeTestCert(t, root, "tsa", "timestamp", "expired", true, now.Add(-time.Hour))
+	writeTestCert(t, root, "signingAuthority", "unused", "expiring", true, now.Add(24*time.Hour))
+	storePath := func(storeType, storeName string) string {
+		return filepath.Join(root, dir.X509TrustStoreDir(storeType, storeName))
+	}
+
+	// a file with a valid and a broken certificate
+	valid, err := os.ReadFile(filepath.Join(storePath("ca", "acme"), "valid.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	broken := append(valid, []byte("-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n")...)
+	brokenPath := filepath.Join(storePath("ca", "acme"), "broken.pem")
+	if err := os.WriteFile(brokenPath, broken, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	stores := []TrustStoreFiles{
+		{Name: "ca:acme", Paths: []string{
+			brokenPath,
+			filepath.Join(storePath("ca", "acme"), "leaf.crt"),
+			filepath.Join(storePath("ca", "acme"), "valid.crt"),
+		}},
+		{Name: "tsa:timestamp", Paths: []string{filepath.Join(storePath("tsa", "timestamp"), "expired.crt")}},
+		{Name: "signingAuthority:unused", Paths: []string{filepath.Join(storePath("signingAuthority", "unused"), "expiring.crt")}},
+	}
+	statements := []Statement{{Name: "test", TrustStores: []string{"ca:acme", "tsa:timestamp"}}}
+
+	findings := CheckTrustStores(stores, statements, CheckOptions{
+		ExpiryThreshold: DefaultExpiryThreshold,
+		Now:             now,
+	})
+	expected := []string{
+		"error |ca:acme certificate-file-invalid",
+		"warning |ca:acme leaf-certificate",
+		"error |tsa:timestamp certificate-expired",
+		"warning |signingAuthority:unused certificate-expiring",
+		"warning |signingAuthority:unused trust-store-unreferenced",
+	}
+	if got := findingRules(findings); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected findings %v, but got %v", expected, got)
+	}
+	if !strings.Contains(findings[0].Message, "as file broken.pem is not a valid certificate file: certificate 2 of 2 cannot be parsed") {
+		t.Fatalf("unexpected message: %s", findings[0].Message)
+	}
+	if !strings.Contains(findings[1].Message, `certificate "CN=test,O=leaf,ST=WA,C=US" in file leaf.crt is a leaf certificate`) {
+		t.Fatalf("unexpected message: %s", findings[1].Message)
+	}
+
+	t.Run("skip references", func(t *testing.T) {
+		findings := CheckTrustStores(stores[2:], nil, CheckOptions{
+			ExpiryThreshold: time.Hour,
+			Now:             now,
+			SkipReferences:  true,
+		})
+		if len(findings) != 0 {
+			t.Fatalf("expected no findings, but got %v", findingRules(findings))
+		}
+	})
+}
+
+func TestParseCertificateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.crt")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseCertificateFile(path); err == nil {
+		t.Fatal("expected error for empty file, but got nil")
+	}
+	if _, err := parseCertificateFile(filepath.Join(t.TempDir(), "missing.crt")); err == nil {
+		t.Fatal("expected error for missing file, but got nil")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"crypto/x509"
+	"fmt"
+	"slices"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
+)
+
+// Proposal is the trust configuration proposed for trusting the signers of
+// a set of signatures.
+type Proposal struct {
+	// TrustStores are the trust stores of the proposed statement, in the
+	// format of "<store_type>:<store_name>".
+	TrustStores []string
+
+	// TrustedIdentities are the trusted identities of the proposed statement.
+	TrustedIdentities []string
+
+	// Certificates are the root certificates to add to each trust store,
+	// keyed by "<store_type>:<store_name>".
+	Certificates map[string][]*x509.Certificate
+
+	// Warnings are the issues to be resolved manually.
+	Warnings []string
+}
+
+// Propose proposes trust stores named storeName holding the root
+// certificates of signatures, and the trusted identities of their signing
+// certificates. The roots carried by the timestamp countersignatures are
+// proposed for a "tsa" trust store. storeName must be a valid trust store
+// name.
+func Propose(signatures []*certchain.Signature, storeName string) (*Proposal, error) {
+	proposal := &Proposal{
+		Certificates: make(map[string][]*x509.Certificate),
+	}
+	add := func(storeType truststore.Type, cert *x509.Certificate) {
+		trustStore := string(storeType) + ":" + storeName
+		if !slices.Contains(proposal.TrustStores, trustStore) {
+			proposal.TrustStores = append(proposal.TrustStores, trustStore)
+		}
+		certs := proposal.Certificates[trustStore]
+		if !slices.ContainsFunc(certs, func(existing *x509.Certificate) bool { return existing.Equal(cert) }) {
+			proposal.Certificates[trustStore] = append(certs, cert)
+		}
+	}
+	for _, sig := range signatures {
+		var storeType truststore.Type
+		switch sig.SigningScheme {
+		case signature.SigningSchemeX509:
+			storeType = truststore.TypeCA
+		case signature.SigningSchemeX509SigningAuthority:
+			storeType = truststore.TypeSigningAuthority
+		default:
+			return nil, fmt.Errorf("signature %s has unsupported signing scheme %q", sig.Source, sig.SigningScheme)
+		}
+		root, err := sig.Root()
+		if err != nil {
+			return nil, err
+		}
+		add(storeType, root)
+
+		subject, err := certchain.Subject(sig.CertificateChain[0])
+		if err != nil {
+			return nil, err
+		}
+		identity := "x509.subject: " + subject
+		if !slices.Contains(proposal.TrustedIdentities, identity) {
+			proposal.TrustedIdentities = append(proposal.TrustedIdentities, identity)
+		}
+
+		timestampRoots := sig.TimestampRoots()
+		if len(sig.TimestampCertificates) > 0 && len(timestampRoots) == 0 {
+			proposal.Warnings = append(proposal.Warnings, fmt.Sprintf("the timestamp countersignature of signature %s carries no root certificate, the root certificate of the timestamping authority needs to be added to a \"tsa\" trust store manually", sig.Source))
+		}
+		for _, timestampRoot := range timestampRoots {
+			add(truststore.TypeTSA, timestampRoot)
+		}
+	}
+	return proposal, nil
+}
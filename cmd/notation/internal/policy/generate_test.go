@@ -0,0 +1,103 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
+)
+
+// newSelfSignedCert creates a self-signed certificate of organization.
+func newSelfSignedCert(t *testing.T, organization string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test", Organization: []string{organization}, Province: []string{"WA"}, Country: []string{"US"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestPropose(t *testing.T) {
+	acme := newSelfSignedCert(t, "acme")
+	wabbit := newSelfSignedCert(t, "wabbit")
+	tsaRoot := newSelfSignedCert(t, "tsa")
+	signatures := []*certchain.Signature{
+		{Source: "sig1", SigningScheme: signature.SigningSchemeX509, CertificateChain: []*x509.Certificate{acme}, TimestampCertificates: []*x509.Certificate{tsaRoot}},
+		{Source: "sig2", SigningScheme: signature.SigningSchemeX509, CertificateChain: []*x509.Certificate{acme}},
+		{Source: "sig3", SigningScheme: signature.SigningSchemeX509SigningAuthority, CertificateChain: []*x509.Certificate{wabbit}},
+	}
+	proposal, err := Propose(signatures, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"ca:test", "tsa:test", "signingAuthority:test"}; !reflect.DeepEqual(proposal.TrustStores, want) {
+		t.Fatalf("TrustStores = %v, want %v", proposal.TrustStores, want)
+	}
+	if want := []string{"x509.subject: C=US, ST=WA, O=acme, CN=test", "x509.subject: C=US, ST=WA, O=wabbit, CN=test"}; !reflect.DeepEqual(proposal.TrustedIdentities, want) {
+		t.Fatalf("TrustedIdentities = %v, want %v", proposal.TrustedIdentities, want)
+	}
+	if certs := proposal.Certificates["ca:test"]; len(certs) != 1 || !certs[0].Equal(acme) {
+		t.Fatalf("Certificates[ca:test] = %v, want the acme root only", certs)
+	}
+	if len(proposal.Warnings) != 0 {
+		t.Fatalf("Warnings = %v, want none", proposal.Warnings)
+	}
+}
+
+func TestProposeTimestampWithoutRoot(t *testing.T) {
+	acme := newSelfSignedCert(t, "acme")
+	tsaRoot := newSelfSignedCert(t, "tsa")
+	tsaRoot.RawIssuer = []byte("not self-signed")
+	proposal, err := Propose([]*certchain.Signature{
+		{Source: "sig", SigningScheme: signature.SigningSchemeX509, CertificateChain: []*x509.Certificate{acme}, TimestampCertificates: []*x509.Certificate{tsaRoot}},
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposal.Warnings) != 1 || !reflect.DeepEqual(proposal.TrustStores, []string{"ca:test"}) {
+		t.Fatalf("Propose() = %+v, want a warning and no tsa trust store", proposal)
+	}
+}
+
+func TestProposeUnsupportedSigningScheme(t *testing.T) {
+	if _, err := Propose([]*certchain.Signature{
+		{Source: "sig", SigningScheme: "unknown", CertificateChain: []*x509.Certificate{newSelfSignedCert(t, "acme")}},
+	}, "test"); err == nil {
+		t.Fatal("Propose() expected error for an unsupported signing scheme")
+	}
+}
@@ -0,0 +1,486 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	ldapv3 "github.com/go-ldap/ldap/v3"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
+)
+
+// Severity is the severity of a lint finding.
+type Severity string
+
+const (
+	// SeverityError indicates that signature verification fails or is
+	// bypassed for the affected artifacts.
+	SeverityError Severity = "error"
+
+	// SeverityWarning indicates that signature verification works but the
+	// configuration is weaker or more fragile than it should be.
+	SeverityWarning Severity = "warning"
+)
+
+// Rules reporting the findings of Lint and CheckTrustStores.
+const (
+	RuleInvalidConfiguration   = "invalid-configuration"
+	RuleTrustStoreMissing      = "trust-store-missing"
+	RuleTrustStoreEmpty        = "trust-store-empty"
+	RuleTrustStoreInvalid      = "trust-store-invalid"
+	RuleWildcardIdentity       = "wildcard-identity"
+	RuleWeakVerificationLevel  = "weak-verification-level"
+	RuleUnsatisfiableIdentity  = "unsatisfiable-identity"
+	RuleCertificateExpired     = "certificate-expired"
+	RuleCertificateExpiring    = "certificate-expiring"
+	RuleLeafCertificate        = "leaf-certificate"
+	RuleCertificateFileInvalid = "certificate-file-invalid"
+	RuleTrustStoreUnreferenced = "trust-store-unreferenced"
+	RuleSystemPolicyConflict   = "system-policy-conflict"
+)
+
+const (
+	wildcard    = "*"
+	x509Subject = "x509.subject"
+)
+
+// DefaultExpiryThreshold is the default period before the expiry of a
+// trusted certificate in which it is reported as expiring.
+const DefaultExpiryThreshold = 30 * 24 * time.Hour
+
+// Finding is an issue found by linting a trust policy configuration.
+type Finding struct {
+	// Severity is the severity of the finding.
+	Severity Severity
+
+	// Rule is the name of the rule reporting the finding.
+	Rule string
+
+	// Statement is the name of the trust policy statement the finding is
+	// about, if any.
+	Statement string
+
+	// TrustStore is the trust store in the format
+	// "<store_type>:<store_name>" the finding is about, if any.
+	TrustStore string
+
+	// Message describes the finding.
+	Message string
+
+	// Hint suggests how to fix the finding.
+	Hint string
+}
+
+// Statement is a trust policy statement of either an OCI or a blob trust
+// policy configuration.
+type Statement struct {
+	Name                  string
+	SignatureVerification trustpolicy.SignatureVerification
+	TrustStores           []string
+	TrustedIdentities     []string
+
+	// RegistryScopes is nil for blob trust policy statements.
+	RegistryScopes []string
+
+	// GlobalPolicy is always false for OCI trust policy statements.
+	GlobalPolicy bool
+}
+
+// OCIStatements returns the statements of an OCI trust policy configuration.
+func OCIStatements(doc *trustpolicy.OCIDocument) []Statement {
+	statements := make([]Statement, 0, len(doc.TrustPolicies))
+	for _, policy := range doc.TrustPolicies {
+		statements = append(statements, Statement{
+			Name:                  policy.Name,
+			SignatureVerification: policy.SignatureVerification,
+			TrustStores:           policy.TrustStores,
+			TrustedIdentities:     policy.TrustedIdentities,
+			RegistryScopes:        policy.RegistryScopes,
+		})
+	}
+	return statements
+}
+
+// BlobStatements returns the statements of a blob trust policy
+// configuration.
+func BlobStatements(doc *trustpolicy.BlobDocument) []Statement {
+	statements := make([]Statement, 0, len(doc.TrustPolicies))
+	for _, policy := range doc.TrustPolicies {
+		statements = append(statements, Statement{
+			Name:                  policy.Name,
+			SignatureVerification: policy.SignatureVerification,
+			TrustStores:           policy.TrustStores,
+			TrustedIdentities:     policy.TrustedIdentities,
+			GlobalPolicy:          policy.GlobalPolicy,
+		})
+	}
+	return statements
+}
+
+// LintOptions are the options of Lint.
+type LintOptions struct {
+	// ProductionScopes are the registry scopes, or prefixes of them, hosting
+	// production artifacts.
+	ProductionScopes []string
+
+	// ExpiryThreshold is the period before the expiry of a trusted
+	// certificate in which it is reported as expiring.
+	ExpiryThreshold time.Duration
+
+	// Now is the time to check the expiry of trusted certificates against.
+	// The current time is used if it is zero.
+	Now time.Time
+}
+
+// Lint checks the statements of a trust policy configuration and the trust
+// stores they reference, which are read from x509TrustStore. validationErr is
+// the error returned by validating the configuration, if any.
+//
+// Findings about a statement are reported in the order of the statements,
+// followed by the findings about the trust stores in the order they are
+// first referenced.
+func Lint(ctx context.Context, x509TrustStore truststore.X509TrustStore, statements []Statement, validationErr error, opts LintOptions) []Finding {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	var findings []Finding
+	if validationErr != nil {
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Rule:     RuleInvalidConfiguration,
+			Message:  validationErr.Error(),
+			Hint:     "fix the trust policy configuration, otherwise every signature verification fails",
+		})
+	}
+
+	// load the trust stores in the order they are first referenced
+	var storeNames []string
+	stores := make(map[string]*trustStoreInfo)
+	for _, statement := range statements {
+		if statement.SignatureVerification.VerificationLevel == trustpolicy.LevelSkip.Name {
+			continue
+		}
+		for _, name := range statement.TrustStores {
+			if _, ok := stores[name]; !ok {
+				storeNames = append(storeNames, name)
+				stores[name] = loadTrustStore(ctx, x509TrustStore, name)
+			}
+		}
+	}
+
+	for _, statement := range statements {
+		findings = append(findings, lintStatement(statement, stores, opts)...)
+	}
+	for _, name := range storeNames {
+		findings = append(findings, lintTrustStore(name, stores[name], opts)...)
+	}
+	return findings
+}
+
+// trustStoreInfo is the result of loading a trust store.
+type trustStoreInfo struct {
+	storeType string
+	certs     []*x509.Certificate
+	missing   bool
+	err       error
+}
+
+// loadTrustStore loads the certificates of the trust store named
+// "<store_type>:<store_name>" from x509TrustStore, the same way as signature
+// verification does.
+func loadTrustStore(ctx context.Context, x509TrustStore truststore.X509TrustStore, name string) *trustStoreInfo {
+	storeType, namedStore, ok := strings.Cut(name, ":")
+	if !ok {
+		return &trustStoreInfo{err: fmt.Errorf("invalid trust store %q, expecting \"<store_type>:<store_name>\"", name)}
+	}
+	info := &trustStoreInfo{storeType: storeType}
+	info.certs, info.err = x509TrustStore.GetCertificates(ctx, truststore.Type(storeType), namedStore)
+
+	// a missing trust store is reported as a TrustStoreError, while an empty
+	// one is reported as a CertificateError
+	var storeErr truststore.TrustStoreError
+	info.missing = errors.As(info.err, &storeErr) && errors.Is(info.err, fs.ErrNotExist)
+	return info
+}
+
+// SystemPolicyConflicts returns the findings about the warnings of merging
+// the user level trust policy configuration into the system level one.
+func SystemPolicyConflicts(warnings []string) []Finding {
+	var findings []Finding
+	for _, warning := range warnings {
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Rule:     RuleSystemPolicyConflict,
+			Message:  warning,
+			Hint:     "rename the user level statement, or remove the registry scopes and the global policy governed by the system level trust policy",
+		})
+	}
+	return findings
+}
+
+// lintStatement reports the findings about a trust policy statement.
+func lintStatement(statement Statement, stores map[string]*trustStoreInfo, opts LintOptions) []Finding {
+	var findings []Finding
+	level := statement.SignatureVerification.VerificationLevel
+	if level == trustpolicy.LevelAudit.Name || level == trustpolicy.LevelSkip.Name {
+		if scope, ok := productionScope(statement, opts.ProductionScopes); ok {
+			findings = append(findings, Finding{
+				Severity:  SeverityWarning,
+				Rule:      RuleWeakVerificationLevel,
+				Statement: statement.Name,
+				Message:   fmt.Sprintf("verification level %q applies to %s, so artifacts failing signature verification are still accepted", level, scope),
+				Hint:      fmt.Sprintf("use verification level %q, or narrow the statement to non-production artifacts", trustpolicy.LevelStrict.Name),
+			})
+		}
+	}
+	if level == trustpolicy.LevelSkip.Name {
+		return findings
+	}
+
+	if slices.Contains(statement.TrustedIdentities, wildcard) {
+		findings = append(findings, Finding{
+			Severity:  SeverityWarning,
+			Rule:      RuleWildcardIdentity,
+			Statement: statement.Name,
+			Message:   "the wildcard trusted identity \"*\" trusts any certificate issued by the trust stores of the statement",
+			Hint:      "pin the signing identities with \"x509.subject:<subject_of_signing_certificate>\" trusted identities",
+		})
+	}
+	findings = append(findings, lintIdentities(statement, stores)...)
+
+	for _, name := range statement.TrustStores {
+		store := stores[name]
+		if store.missing {
+			findings = append(findings, Finding{
+				Severity:   SeverityError,
+				Rule:       RuleTrustStoreMissing,
+				Statement:  statement.Name,
+				TrustStore: name,
+				Message:    store.err.Error(),
+				Hint:       addCertHint(name),
+			})
+		} else if errors.Is(store.err, fs.ErrNotExist) {
+			findings = append(findings, Finding{
+				Severity:   SeverityError,
+				Rule:       RuleTrustStoreEmpty,
+				Statement:  statement.Name,
+				TrustStore: name,
+				Message:    store.err.Error(),
+				Hint:       addCertHint(name),
+			})
+		}
+	}
+	return findings
+}
+
+// lintIdentities reports the x509.subject trusted identities of a statement
+// that no signing certificate can satisfy.
+//
+// A signing certificate is trusted if it chains to a certificate in the trust
+// stores of the statement. If none of the certificates is a CA certificate,
+// the signing certificate must be one of them, so each x509.subject identity
+// must match the subject of one of them.
+func lintIdentities(statement Statement, stores map[string]*trustStoreInfo) []Finding {
+	var certs []*x509.Certificate
+	for _, name := range statement.TrustStores {
+		store := stores[name]
+		if store.storeType == string(truststore.TypeTSA) {
+			continue
+		}
+		if store.missing || store.err != nil {
+			// reported as trust store findings
+			return nil
+		}
+		certs = append(certs, store.certs...)
+	}
+	if len(certs) == 0 || slices.ContainsFunc(certs, func(cert *x509.Certificate) bool { return cert.IsCA }) {
+		return nil
+	}
+
+	var findings []Finding
+	for _, identity := range statement.TrustedIdentities {
+		identityType, subject, ok := strings.Cut(identity, ":")
+		if !ok || identityType != x509Subject {
+			continue
+		}
+		identityDN, err := parseDistinguishedName(strings.TrimSpace(subject))
+		if err != nil {
+			// invalid identities are reported by the validation
+			continue
+		}
+		if !slices.ContainsFunc(certs, func(cert *x509.Certificate) bool {
+			certDN, err := parseDistinguishedName(cert.Subject.String())
+			return err == nil && isSubsetDN(identityDN, certDN)
+		}) {
+			findings = append(findings, Finding{
+				Severity:  SeverityError,
+				Rule:      RuleUnsatisfiableIdentity,
+				Statement: statement.Name,
+				Message:   fmt.Sprintf("trusted identity %q matches none of the certificates in the trust stores, which only contain leaf certificates", identity),
+				Hint:      "correct the trusted identity, or add the certificate of the identity to the trust stores",
+			})
+		}
+	}
+	return findings
+}
+
+// lintTrustStore reports the findings about the certificates of a trust
+// store.
+func lintTrustStore(name string, store *trustStoreInfo, opts LintOptions) []Finding {
+	if store.missing || errors.Is(store.err, fs.ErrNotExist) {
+		// reported as statement findings
+		return nil
+	}
+	if store.err != nil {
+		return []Finding{{
+			Severity:   SeverityError,
+			Rule:       RuleTrustStoreInvalid,
+			TrustStore: name,
+			Message:    store.err.Error(),
+			Hint:       "remove or replace the invalid certificates with `notation cert delete`",
+		}}
+	}
+
+	var findings []Finding
+	for _, cert := range store.certs {
+		if finding, ok := checkExpiry(name, fmt.Sprintf("certificate %q", cert.Subject), cert, opts.Now, opts.ExpiryThreshold); ok {
+			findings = append(findings, finding)
+		}
+		if !cert.IsCA {
+			kind := "non-CA (leaf) certificate"
+			switch {
+			case certchain.IsSelfSigned(cert):
+				kind = "self-signed leaf certificate"
+			case store.storeType == string(truststore.TypeCA):
+				kind += " in a CA trust store"
+			}
+			findings = append(findings, Finding{
+				Severity:   SeverityWarning,
+				Rule:       RuleLeafCertificate,
+				TrustStore: name,
+				Message:    fmt.Sprintf("certificate %q is a %s, only signatures made with it are trusted and renewing it breaks verification", cert.Subject, kind),
+				Hint:       "trust the root CA certificate of the signing certificate instead",
+			})
+		}
+	}
+	return findings
+}
+
+// checkExpiry reports a certificate of the trust store named
+// "<store_type>:<store_name>" that is expired at now or expires within
+// threshold. subject describes the certificate in the message.
+func checkExpiry(name, subject string, cert *x509.Certificate, now time.Time, threshold time.Duration) (Finding, bool) {
+	switch {
+	case !now.Before(cert.NotAfter):
+		return Finding{
+			Severity:   SeverityError,
+			Rule:       RuleCertificateExpired,
+			TrustStore: name,
+			Message:    fmt.Sprintf("%s expired on %s", subject, cert.NotAfter.Format(time.RFC3339)),
+			Hint:       "replace the certificate with a renewed one",
+		}, true
+	case now.Add(threshold).After(cert.NotAfter):
+		return Finding{
+			Severity:   SeverityWarning,
+			Rule:       RuleCertificateExpiring,
+			TrustStore: name,
+			Message:    fmt.Sprintf("%s expires on %s", subject, cert.NotAfter.Format(time.RFC3339)),
+			Hint:       "add the renewed certificate to the trust store before it expires",
+		}, true
+	}
+	return Finding{}, false
+}
+
+// productionScope returns the description of the production artifacts the
+// statement applies to, if any.
+//
+// Statements with the wildcard registry scope and global blob trust policy
+// statements always apply to production artifacts.
+func productionScope(statement Statement, productionScopes []string) (string, bool) {
+	if statement.GlobalPolicy {
+		return "all the blobs as the global policy", true
+	}
+	for _, scope := range statement.RegistryScopes {
+		if scope == wildcard {
+			return "all the repositories", true
+		}
+		for _, productionScope := range productionScopes {
+			productionScope = strings.TrimSuffix(productionScope, "/")
+			if scope == productionScope || strings.HasPrefix(scope, productionScope+"/") {
+				return fmt.Sprintf("production registry scope %q", scope), true
+			}
+		}
+	}
+	return "", false
+}
+
+// addCertHint returns the hint to add certificates to the trust store named
+// "<store_type>:<store_name>".
+func addCertHint(name string) string {
+	storeType, namedStore, _ := strings.Cut(name, ":")
+	return fmt.Sprintf("add certificates with `notation cert add --type %s --store %s <cert_path>`", storeType, namedStore)
+}
+
+// parseDistinguishedName parses a distinguished name into its attributes,
+// the same way as signature verification does.
+func parseDistinguishedName(name string) (map[string]string, error) {
+	dn, err := ldapv3.ParseDN(name)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]string)
+	for _, rdn := range dn.RDNs {
+		for _, attribute := range rdn.Attributes {
+			// stateOrProvince name 'S' is an alias for 'ST'
+			if attribute.Type == "S" {
+				attribute.Type = "ST"
+			}
+			attributes[attribute.Type] = attribute.Value
+		}
+	}
+	return attributes, nil
+}
+
+// isSubsetDN returns true if every attribute of dn1 is in dn2.
+func isSubsetDN(dn1, dn2 map[string]string) bool {
+	for key, value := range dn1 {
+		if dn2[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// CountFindings returns the number of the error findings and the number of
+// the warning findings.
+func CountFindings(findings []Finding) (errorCount, warningCount int) {
+	for _, finding := range findings {
+		switch finding.Severity {
+		case SeverityError:
+			errorCount++
+		case SeverityWarning:
+			warningCount++
+		}
+	}
+	return errorCount, warningCount
+}
@@ -0,0 +1,274 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+)
+
+// writeTestCert writes a self-signed certificate of organization expiring at
+// notAfter to the trust store storeType:storeName under root.
+func writeTestCert(t *testing.T, root, storeType, storeName, organization string, isCA bool, notAfter time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test", Organization: []string{organization}, Country: []string{"US"}, Province: []string{"WA"}},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign
+		template.ExtKeyUsage = nil
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storePath := filepath.Join(root, dir.X509TrustStoreDir(storeType, storeName))
+	if err := os.MkdirAll(storePath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+	if err := os.WriteFile(filepath.Join(storePath, organization+".crt"), certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// findingRules formats each finding as "<severity> <statement>|<trust_store>
+// <rule>" for comparison.
+func findingRules(findings []Finding) []string {
+	var rules []string
+	for _, finding := range findings {
+		rules = append(rules, string(finding.Severity)+" "+finding.Statement+"|"+finding.TrustStore+" "+finding.Rule)
+	}
+	return rules
+}
+
+func TestLint(t *testing.T) {
+	now := time.Now()
+	root := t.TempDir()
+	x509TrustStore := truststore.NewX509TrustStore(dir.NewSysFS(root))
+	writeTestCert(t, root, "ca", "root", "Root", true, now.Add(365*24*time.Hour))
+	writeTestCert(t, root, "ca", "expiring", "Expiring", true, now.Add(24*time.Hour))
+	writeTestCert(t, root, "ca", "expired", "Expired", true, now.Add(-time.Hour))
+	writeTestCert(t, root, "signingAuthority", "leaf", "Leaf", false, now.Add(365*24*time.Hour))
+	if err := os.MkdirAll(filepath.Join(root, dir.X509TrustStoreDir("ca", "empty")), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, dir.X509TrustStoreDir("ca", "expiring"), "invalid.crt"), []byte("invalid"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	strict := trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelStrict.Name}
+
+	t.Run("no findings", func(t *testing.T) {
+		findings := Lint(context.Background(), x509TrustStore, []Statement{{
+			Name:                  "test",
+			SignatureVerification: strict,
+			TrustStores:           []string{"ca:root"},
+			TrustedIdentities:     []string{"x509.subject: C=US, ST=WA, O=Acme"},
+			RegistryScopes:        []string{"localhost:5000/test"},
+		}}, nil, LintOptions{ExpiryThreshold: DefaultExpiryThreshold})
+		if len(findings) != 0 {
+			t.Fatalf("expected no findings, got %v", findings)
+		}
+	})
+
+	t.Run("statements and trust stores", func(t *testing.T) {
+		statements := []Statement{
+			{
+				Name:                  "wildcard",
+				SignatureVerification: strict,
+				TrustStores:           []string{"ca:root", "ca:missing"},
+				TrustedIdentities:     []string{"*"},
+				RegistryScopes:        []string{"localhost:5000/dev"},
+			},
+			{
+				Name:                  "audit",
+				SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelAudit.Name},
+				TrustStores:           []string{"ca:empty"},
+				TrustedIdentities:     []string{"x509.subject: C=US, ST=WA, O=Acme"},
+				RegistryScopes:        []string{"localhost:5000/prod/app"},
+			},
+			{
+				Name:                  "skip",
+				SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelSkip.Name},
+				RegistryScopes:        []string{"*"},
+			},
+			{
+				Name:                  "leaf",
+				SignatureVerification: strict,
+				TrustStores:           []string{"signingAuthority:leaf"},
+				TrustedIdentities:     []string{"x509.subject: C=US, ST=WA, O=Leaf", "x509.subject: C=US, S=WA, O=Other"},
+				RegistryScopes:        []string{"localhost:5000/leaf"},
+			},
+			{
+				Name:                  "expiry",
+				SignatureVerification: strict,
+				TrustStores:           []string{"ca:expiring", "ca:expired", "ca:root"},
+				TrustedIdentities:     []string{"x509.subject: C=US, ST=WA, O=Acme"},
+				RegistryScopes:        []string{"localhost:5000/expiry"},
+			},
+		}
+		validationErr := errors.New("invalid")
+		findings := Lint(context.Background(), x509TrustStore, statements, validationErr, LintOptions{
+			ProductionScopes: []string{"localhost:5000/prod/"},
+			ExpiryThreshold:  DefaultExpiryThreshold,
+			Now:              now,
+		})
+		expected := []string{
+			"error | invalid-configuration",
+			"warning wildcard| wildcard-identity",
+			"error wildcard|ca:missing trust-store-missing",
+			"warning audit| weak-verification-level",
+			"error audit|ca:empty trust-store-empty",
+			"warning skip| weak-verification-level",
+			"error leaf| unsatisfiable-identity",
+			"warning |signingAuthority:leaf leaf-certificate",
+			"error |ca:expiring trust-store-invalid",
+			"error |ca:expired certificate-expired",
+		}
+		if got := findingRules(findings); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected findings %q, got %q", expected, got)
+		}
+		if findings[6].Message != `trusted identity "x509.subject: C=US, S=WA, O=Other" matches none of the certificates in the trust stores, which only contain leaf certificates` {
+			t.Fatalf("unexpected message %q", findings[6].Message)
+		}
+		if !strings.Contains(findings[7].Message, "is a self-signed leaf certificate") {
+			t.Fatalf("unexpected message %q", findings[7].Message)
+		}
+	})
+
+	t.Run("expiring certificate", func(t *testing.T) {
+		if err := os.Remove(filepath.Join(root, dir.X509TrustStoreDir("ca", "expiring"), "invalid.crt")); err != nil {
+			t.Fatal(err)
+		}
+		findings := Lint(context.Background(), x509TrustStore, []Statement{{
+			Name:                  "global",
+			SignatureVerification: strict,
+			TrustStores:           []string{"ca:expiring"},
+			TrustedIdentities:     []string{"x509.subject: C=US, ST=WA, O=Acme"},
+			GlobalPolicy:          true,
+		}}, nil, LintOptions{ExpiryThreshold: DefaultExpiryThreshold, Now: now})
+		expected := []string{"warning |ca:expiring certificate-expiring"}
+		if got := findingRules(findings); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected findings %q, got %q", expected, got)
+		}
+
+		findings = Lint(context.Background(), x509TrustStore, []Statement{{
+			Name:                  "global",
+			SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelAudit.Name},
+			TrustStores:           []string{"ca:expiring"},
+			TrustedIdentities:     []string{"x509.subject: C=US, ST=WA, O=Acme"},
+			GlobalPolicy:          true,
+		}}, nil, LintOptions{ExpiryThreshold: time.Hour, Now: now})
+		expected = []string{"warning global| weak-verification-level"}
+		if got := findingRules(findings); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected findings %q, got %q", expected, got)
+		}
+	})
+}
+
+func TestLintTrustStore_IssuedLeafCertificate(t *testing.T) {
+	now := time.Now()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "root"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "leaf"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+	}
+	leafBytes, err := x509.CreateCertificate(rand.Reader, leafTemplate, caTemplate, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(leafBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := lintTrustStore("ca:issued", &trustStoreInfo{storeType: "ca", certs: []*x509.Certificate{leaf}}, LintOptions{ExpiryThreshold: DefaultExpiryThreshold, Now: now})
+	if len(findings) != 1 || findings[0].Rule != RuleLeafCertificate {
+		t.Fatalf("expected a leaf-certificate finding, got %v", findings)
+	}
+	if expected := `certificate "CN=leaf" is a non-CA (leaf) certificate in a CA trust store`; !strings.HasPrefix(findings[0].Message, expected) {
+		t.Fatalf("expected message starting with %q, got %q", expected, findings[0].Message)
+	}
+}
+
+func TestCountFindings(t *testing.T) {
+	errorCount, warningCount := CountFindings([]Finding{
+		{Severity: SeverityError},
+		{Severity: SeverityWarning},
+		{Severity: SeverityWarning},
+	})
+	if errorCount != 1 || warningCount != 2 {
+		t.Fatalf("expected 1 error and 2 warnings, got %d and %d", errorCount, warningCount)
+	}
+}
+
+func TestSystemPolicyConflicts(t *testing.T) {
+	findings := SystemPolicyConflicts([]string{`user OCI trust policy statement "prod" is ignored as a system OCI trust policy statement has the same name`})
+	expected := []string{"warning | system-policy-conflict"}
+	if got := findingRules(findings); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected findings %q, got %q", expected, got)
+	}
+	if findings := SystemPolicyConflicts(nil); len(findings) != 0 {
+		t.Fatalf("expected no findings, got %v", findings)
+	}
+}
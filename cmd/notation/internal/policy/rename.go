@@ -0,0 +1,77 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"slices"
+
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+)
+
+// RenameOCITrustStore replaces the references to the trust store oldName by
+// newName in the statements of an OCI trust policy configuration, where the
+// names are in the format "<store_type>:<store_name>". It returns the names of
+// the updated statements.
+func RenameOCITrustStore(doc *trustpolicy.OCIDocument, oldName, newName string) []string {
+	var updated []string
+	for i := range doc.TrustPolicies {
+		if renameTrustStore(&doc.TrustPolicies[i].TrustStores, oldName, newName) {
+			updated = append(updated, doc.TrustPolicies[i].Name)
+		}
+	}
+	return updated
+}
+
+// RenameBlobTrustStore replaces the references to the trust store oldName by
+// newName in the statements of a blob trust policy configuration, where the
+// names are in the format "<store_type>:<store_name>". It returns the names of
+// the updated statements.
+func RenameBlobTrustStore(doc *trustpolicy.BlobDocument, oldName, newName string) []string {
+	var updated []string
+	for i := range doc.TrustPolicies {
+		if renameTrustStore(&doc.TrustPolicies[i].TrustStores, oldName, newName) {
+			updated = append(updated, doc.TrustPolicies[i].Name)
+		}
+	}
+	return updated
+}
+
+// ReferringStatements returns the names of the statements referencing the
+// trust store name.
+func ReferringStatements(statements []Statement, name string) []string {
+	var names []string
+	for _, statement := range statements {
+		if slices.Contains(statement.TrustStores, name) {
+			names = append(names, statement.Name)
+		}
+	}
+	return names
+}
+
+// renameTrustStore replaces oldName by newName in trustStores, and reports
+// whether trustStores is changed. The reference to oldName is removed instead
+// if newName is already referenced, as a trust store can be referenced only
+// once by a statement.
+func renameTrustStore(trustStores *[]string, oldName, newName string) bool {
+	i := slices.Index(*trustStores, oldName)
+	if i < 0 {
+		return false
+	}
+	if slices.Contains(*trustStores, newName) {
+		*trustStores = slices.Delete(*trustStores, i, i+1)
+	} else {
+		(*trustStores)[i] = newName
+	}
+	return true
+}
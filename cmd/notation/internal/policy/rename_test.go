@@ -0,0 +1,79 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+)
+
+func TestRenameOCITrustStore(t *testing.T) {
+	doc := &trustpolicy.OCIDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.OCITrustPolicy{
+			{Name: "a", TrustStores: []string{"ca:acme", "tsa:timestamp"}},
+			{Name: "b", TrustStores: []string{"ca:other"}},
+			{Name: "c", TrustStores: []string{"ca:acme", "ca:acme-prod"}},
+		},
+	}
+	updated := RenameOCITrustStore(doc, "ca:acme", "ca:acme-prod")
+	if !slices.Equal(updated, []string{"a", "c"}) {
+		t.Fatalf("expected updated statements [a c], got %v", updated)
+	}
+	for i, expected := range [][]string{
+		{"ca:acme-prod", "tsa:timestamp"},
+		{"ca:other"},
+		{"ca:acme-prod"},
+	} {
+		if got := doc.TrustPolicies[i].TrustStores; !slices.Equal(got, expected) {
+			t.Fatalf("expected trust stores %v of statement %s, got %v", expected, doc.TrustPolicies[i].Name, got)
+		}
+	}
+}
+
+func TestRenameBlobTrustStore(t *testing.T) {
+	doc := &trustpolicy.BlobDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.BlobTrustPolicy{
+			{Name: "a", TrustStores: []string{"ca:acme"}},
+			{Name: "b", TrustStores: []string{"signingAuthority:acme"}},
+		},
+	}
+	updated := RenameBlobTrustStore(doc, "ca:acme", "ca:acme-dev")
+	if !slices.Equal(updated, []string{"a"}) {
+		t.Fatalf("expected updated statements [a], got %v", updated)
+	}
+	if got := doc.TrustPolicies[0].TrustStores; !slices.Equal(got, []string{"ca:acme-dev"}) {
+		t.Fatalf("unexpected trust stores %v", got)
+	}
+	if got := doc.TrustPolicies[1].TrustStores; !slices.Equal(got, []string{"signingAuthority:acme"}) {
+		t.Fatalf("unexpected trust stores %v", got)
+	}
+}
+
+func TestReferringStatements(t *testing.T) {
+	statements := []Statement{
+		{Name: "a", TrustStores: []string{"ca:acme"}},
+		{Name: "b", TrustStores: []string{"ca:other"}},
+		{Name: "c", TrustStores: []string{"tsa:acme", "ca:acme"}},
+	}
+	if got := ReferringStatements(statements, "ca:acme"); !slices.Equal(got, []string{"a", "c"}) {
+		t.Fatalf("expected statements [a c], got %v", got)
+	}
+	if got := ReferringStatements(statements, "ca:none"); got != nil {
+		t.Fatalf("expected no statements, got %v", got)
+	}
+}
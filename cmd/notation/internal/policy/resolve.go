@@ -0,0 +1,139 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+)
+
+// Resolution is the trust policy statement selected for an artifact with the
+// settings and the trust anchors it resolves to.
+type Resolution struct {
+	// Statement is the selected statement.
+	Statement Statement
+
+	// Reason explains why the statement is selected.
+	Reason string
+
+	// VerificationLevel is the verification level of the statement with the
+	// overrides applied to its enforcement. Its name is the name of the
+	// verification level of the statement.
+	VerificationLevel *trustpolicy.VerificationLevel
+
+	// VerifyTimestamp is the timestamp verification option of the statement,
+	// with the default applied.
+	VerifyTimestamp trustpolicy.TimestampOption
+
+	// TrustStores are the trust stores of the statement in order.
+	TrustStores []ResolvedTrustStore
+}
+
+// ResolvedTrustStore is a trust store with the certificates used as trust
+// anchors.
+type ResolvedTrustStore struct {
+	// Name is the trust store in the format "<store_type>:<store_name>".
+	Name string
+
+	// Certificates are the certificates in the trust store.
+	Certificates []*x509.Certificate
+
+	// Err is the error of loading the certificates, if any. Signature
+	// verification against the trust store fails if it is not nil.
+	Err error
+}
+
+// SelectOCIStatement selects the statement of doc applying to the artifacts
+// in the repository artifactPath, in the format "<registry>/<repository>", the
+// same way as signature verification does. It returns the statement and the
+// reason it is selected.
+func SelectOCIStatement(doc *trustpolicy.OCIDocument, artifactPath string) (Statement, string, error) {
+	statements := OCIStatements(doc)
+	for _, statement := range statements {
+		if slices.Contains(statement.RegistryScopes, artifactPath) {
+			// a statement with exact match for registry scope takes
+			// precedence over a wildcard (*) statement.
+			return statement, fmt.Sprintf("registry scope %q matches the repository of the reference", artifactPath), nil
+		}
+	}
+	for _, statement := range statements {
+		if slices.Contains(statement.RegistryScopes, wildcard) {
+			return statement, fmt.Sprintf("no statement has registry scope %q, the statement with the wildcard registry scope \"*\" applies", artifactPath), nil
+		}
+	}
+	return Statement{}, "", fmt.Errorf("no OCI trust policy statement applies to repository %q: no statement has registry scope %q and no statement has the wildcard registry scope \"*\"", artifactPath, artifactPath)
+}
+
+// SelectBlobStatement selects the statement of doc named policyName, or the
+// global statement if policyName is empty, the same way as signature
+// verification does. It returns the statement and the reason it is selected.
+func SelectBlobStatement(doc *trustpolicy.BlobDocument, policyName string) (Statement, string, error) {
+	var (
+		selected *trustpolicy.BlobTrustPolicy
+		reason   string
+		err      error
+	)
+	if policyName == "" {
+		selected, err = doc.GetGlobalTrustPolicy()
+		reason = "no policy name is specified, the global policy applies"
+	} else {
+		selected, err = doc.GetApplicableTrustPolicy(policyName)
+		reason = fmt.Sprintf("the statement is named %q", policyName)
+	}
+	if err != nil {
+		return Statement{}, "", err
+	}
+	return BlobStatements(&trustpolicy.BlobDocument{TrustPolicies: []trustpolicy.BlobTrustPolicy{*selected}})[0], reason, nil
+}
+
+// Resolve resolves the settings of statement and loads the certificates of
+// its trust stores from x509TrustStore.
+func Resolve(ctx context.Context, x509TrustStore truststore.X509TrustStore, statement Statement, reason string) (*Resolution, error) {
+	verificationLevel, err := statement.SignatureVerification.GetVerificationLevel()
+	if err != nil {
+		return nil, err
+	}
+	// the name of the verification level is "custom" if it is overridden
+	verificationLevel.Name = statement.SignatureVerification.VerificationLevel
+	resolution := &Resolution{
+		Statement:         statement,
+		Reason:            reason,
+		VerificationLevel: verificationLevel,
+		VerifyTimestamp:   statement.SignatureVerification.VerifyTimestamp,
+	}
+	if resolution.VerifyTimestamp == "" {
+		resolution.VerifyTimestamp = trustpolicy.OptionAlways
+	}
+	if verificationLevel.Name == trustpolicy.LevelSkip.Name {
+		return resolution, nil
+	}
+
+	for _, name := range statement.TrustStores {
+		resolved := ResolvedTrustStore{Name: name}
+		storeType, namedStore, ok := strings.Cut(name, ":")
+		if !ok {
+			resolved.Err = fmt.Errorf("invalid trust store %q, expecting \"<store_type>:<store_name>\"", name)
+		} else {
+			resolved.Certificates, resolved.Err = x509TrustStore.GetCertificates(ctx, truststore.Type(storeType), namedStore)
+		}
+		resolution.TrustStores = append(resolution.TrustStores, resolved)
+	}
+	return resolution, nil
+}
@@ -0,0 +1,129 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+)
+
+func TestSelectOCIStatement(t *testing.T) {
+	doc := &trustpolicy.OCIDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.OCITrustPolicy{
+			{Name: "wildcard", RegistryScopes: []string{"*"}},
+			{Name: "exact", RegistryScopes: []string{"localhost:5000/test", "localhost:5000/test2"}},
+		},
+	}
+	for artifactPath, expected := range map[string]string{
+		"localhost:5000/test2": "exact",
+		"localhost:5000/other": "wildcard",
+	} {
+		statement, reason, err := SelectOCIStatement(doc, artifactPath)
+		if err != nil {
+			t.Fatalf("SelectOCIStatement(%q) error = %v", artifactPath, err)
+		}
+		if statement.Name != expected || reason == "" {
+			t.Fatalf("SelectOCIStatement(%q) expected statement %q, got %q with reason %q", artifactPath, expected, statement.Name, reason)
+		}
+	}
+
+	doc.TrustPolicies = doc.TrustPolicies[1:]
+	if _, _, err := SelectOCIStatement(doc, "localhost:5000/other"); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
+
+func TestSelectBlobStatement(t *testing.T) {
+	doc := &trustpolicy.BlobDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.BlobTrustPolicy{
+			{Name: "named"},
+			{Name: "global", GlobalPolicy: true},
+		},
+	}
+	for policyName, expected := range map[string]string{
+		"":      "global",
+		"named": "named",
+	} {
+		statement, _, err := SelectBlobStatement(doc, policyName)
+		if err != nil {
+			t.Fatalf("SelectBlobStatement(%q) error = %v", policyName, err)
+		}
+		if statement.Name != expected {
+			t.Fatalf("SelectBlobStatement(%q) expected statement %q, got %q", policyName, expected, statement.Name)
+		}
+	}
+	if _, _, err := SelectBlobStatement(doc, "non-existing"); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	root := t.TempDir()
+	writeTestCert(t, root, "ca", "root", "Root", true, time.Now().Add(time.Hour))
+
+	resolution, err := Resolve(context.Background(), truststore.NewX509TrustStore(dir.NewSysFS(root)), Statement{
+		Name: "test",
+		SignatureVerification: trustpolicy.SignatureVerification{
+			VerificationLevel: trustpolicy.LevelPermissive.Name,
+			Override: map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+				trustpolicy.TypeRevocation: trustpolicy.ActionSkip,
+			},
+		},
+		TrustStores: []string{"ca:root", "ca:missing"},
+	}, "reason")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if resolution.VerificationLevel.Name != trustpolicy.LevelPermissive.Name ||
+		resolution.VerificationLevel.Enforcement[trustpolicy.TypeRevocation] != trustpolicy.ActionSkip ||
+		resolution.VerificationLevel.Enforcement[trustpolicy.TypeExpiry] != trustpolicy.ActionLog {
+		t.Fatalf("unexpected verification level %+v", resolution.VerificationLevel)
+	}
+	if resolution.VerifyTimestamp != trustpolicy.OptionAlways {
+		t.Fatalf("expected verify timestamp %q, got %q", trustpolicy.OptionAlways, resolution.VerifyTimestamp)
+	}
+	if len(resolution.TrustStores) != 2 {
+		t.Fatalf("expected 2 trust stores, got %d", len(resolution.TrustStores))
+	}
+	if store := resolution.TrustStores[0]; store.Err != nil || len(store.Certificates) != 1 {
+		t.Fatalf("unexpected trust store %q: %d certificates, error %v", store.Name, len(store.Certificates), store.Err)
+	}
+	if store := resolution.TrustStores[1]; store.Err == nil {
+		t.Fatalf("expected error for trust store %q, but got nil", store.Name)
+	}
+
+	resolution, err = Resolve(context.Background(), truststore.NewX509TrustStore(dir.NewSysFS(root)), Statement{
+		Name:                  "skip",
+		SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelSkip.Name},
+	}, "reason")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if resolution.TrustStores != nil {
+		t.Fatalf("expected no trust stores, got %v", resolution.TrustStores)
+	}
+
+	if _, err := Resolve(context.Background(), truststore.NewX509TrustStore(dir.NewSysFS(root)), Statement{
+		SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: "invalid"},
+	}, "reason"); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
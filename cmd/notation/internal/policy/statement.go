@@ -0,0 +1,116 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy provides helpers shared by the commands editing trust policy
+// statements.
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/spf13/pflag"
+)
+
+// StatementFlagOpts are the flags to edit the trust stores, the trusted
+// identities and the signature verification of a trust policy statement.
+type StatementFlagOpts struct {
+	TrustStores       []string
+	TrustedIdentities []string
+	VerificationLevel string
+	Overrides         []string
+	RemoveOverrides   []string
+}
+
+// ApplyFlags sets up the flags to edit a trust policy statement.
+func (opts *StatementFlagOpts) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVar(&opts.TrustStores, "trust-store", nil, "trust store in the format \"<store_type>:<store_name>\". Replaces the trust stores of the statement")
+	fs.StringArrayVar(&opts.TrustedIdentities, "trusted-identity", nil, "trusted identity, use the format \"x509.subject:<subject_of_signing_certificate>\" for x509 CA scheme and \"<signing_authority_identity>\" for x509 signingAuthority scheme. Replaces the trusted identities of the statement")
+	fs.StringVar(&opts.VerificationLevel, "verification-level", "", fmt.Sprintf("signature verification level, options: %s. The trust stores and the trusted identities are removed for level %q", verificationLevelNames(), trustpolicy.LevelSkip.Name))
+	fs.StringArrayVar(&opts.Overrides, "override", nil, fmt.Sprintf("{validation_type}={action} pair overriding the action of a validation of the verification level. Validation types: %s. Actions: %s", joinQuoted(trustpolicy.ValidationTypes), joinQuoted(trustpolicy.ValidationActions)))
+	fs.StringArrayVar(&opts.RemoveOverrides, "remove-override", nil, "validation type whose override is removed")
+}
+
+// Apply applies the flags changed in fs to the signature verification, the
+// trust stores and the trusted identities of a trust policy statement.
+func (opts *StatementFlagOpts) Apply(fs *pflag.FlagSet, signatureVerification *trustpolicy.SignatureVerification, trustStores, trustedIdentities *[]string) error {
+	if fs.Changed("trust-store") {
+		*trustStores = opts.TrustStores
+	}
+	if fs.Changed("trusted-identity") {
+		*trustedIdentities = opts.TrustedIdentities
+	}
+	if fs.Changed("verification-level") {
+		if opts.VerificationLevel == "" {
+			return fmt.Errorf("verification level cannot be empty, options: %s", verificationLevelNames())
+		}
+		signatureVerification.VerificationLevel = opts.VerificationLevel
+		if opts.VerificationLevel == trustpolicy.LevelSkip.Name {
+			*trustStores = nil
+			*trustedIdentities = nil
+			signatureVerification.Override = nil
+		}
+	}
+	for _, validationType := range opts.RemoveOverrides {
+		delete(signatureVerification.Override, trustpolicy.ValidationType(validationType))
+	}
+	overrides, err := ParseOverrides(opts.Overrides)
+	if err != nil {
+		return err
+	}
+	for validationType, action := range overrides {
+		if signatureVerification.Override == nil {
+			signatureVerification.Override = make(map[trustpolicy.ValidationType]trustpolicy.ValidationAction)
+		}
+		signatureVerification.Override[validationType] = action
+	}
+	if len(signatureVerification.Override) == 0 {
+		signatureVerification.Override = nil
+	}
+	return nil
+}
+
+// ParseOverrides parses the {validation_type}={action} pairs of overrides.
+func ParseOverrides(overrides []string) (map[trustpolicy.ValidationType]trustpolicy.ValidationAction, error) {
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+	parsed := make(map[trustpolicy.ValidationType]trustpolicy.ValidationAction, len(overrides))
+	for _, override := range overrides {
+		validationType, action, ok := strings.Cut(override, "=")
+		if !ok || validationType == "" || action == "" {
+			return nil, fmt.Errorf("invalid override %q: expecting {validation_type}={action}", override)
+		}
+		parsed[trustpolicy.ValidationType(strings.TrimSpace(validationType))] = trustpolicy.ValidationAction(strings.TrimSpace(action))
+	}
+	return parsed, nil
+}
+
+// verificationLevelNames returns the quoted names of the verification levels.
+func verificationLevelNames() string {
+	names := make([]string, 0, len(trustpolicy.VerificationLevels))
+	for _, level := range trustpolicy.VerificationLevels {
+		names = append(names, level.Name)
+	}
+	return joinQuoted(names)
+}
+
+// joinQuoted joins the quoted values with ", ".
+func joinQuoted[T ~string](values []T) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", value))
+	}
+	return strings.Join(quoted, ", ")
+}
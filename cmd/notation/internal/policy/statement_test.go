@@ -0,0 +1,133 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/spf13/pflag"
+)
+
+func TestStatementFlagOpts_Apply(t *testing.T) {
+	newFlagSet := func(opts *StatementFlagOpts, args ...string) *pflag.FlagSet {
+		t.Helper()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		opts.ApplyFlags(fs)
+		if err := fs.Parse(args); err != nil {
+			t.Fatalf("Parse Flag failed: %v", err)
+		}
+		return fs
+	}
+
+	t.Run("update changed flags only", func(t *testing.T) {
+		opts := &StatementFlagOpts{}
+		fs := newFlagSet(opts, "--trust-store", "ca:store2", "--override", "revocation=log", "--override", "expiry = log", "--remove-override", "authenticity")
+		signatureVerification := trustpolicy.SignatureVerification{
+			VerificationLevel: trustpolicy.LevelStrict.Name,
+			Override: map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+				trustpolicy.TypeAuthenticity: trustpolicy.ActionLog,
+			},
+		}
+		trustStores := []string{"ca:store1"}
+		trustedIdentities := []string{"*"}
+		if err := opts.Apply(fs, &signatureVerification, &trustStores, &trustedIdentities); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		expected := trustpolicy.SignatureVerification{
+			VerificationLevel: trustpolicy.LevelStrict.Name,
+			Override: map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+				trustpolicy.TypeRevocation: trustpolicy.ActionLog,
+				trustpolicy.TypeExpiry:     trustpolicy.ActionLog,
+			},
+		}
+		if !reflect.DeepEqual(signatureVerification, expected) {
+			t.Fatalf("expected %+v, got %+v", expected, signatureVerification)
+		}
+		if !reflect.DeepEqual(trustStores, []string{"ca:store2"}) || !reflect.DeepEqual(trustedIdentities, []string{"*"}) {
+			t.Fatalf("unexpected trust stores %v or trusted identities %v", trustStores, trustedIdentities)
+		}
+	})
+
+	t.Run("skip level", func(t *testing.T) {
+		opts := &StatementFlagOpts{}
+		fs := newFlagSet(opts, "--verification-level", "skip")
+		signatureVerification := trustpolicy.SignatureVerification{
+			VerificationLevel: trustpolicy.LevelStrict.Name,
+			Override: map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+				trustpolicy.TypeRevocation: trustpolicy.ActionLog,
+			},
+		}
+		trustStores := []string{"ca:store1"}
+		trustedIdentities := []string{"*"}
+		if err := opts.Apply(fs, &signatureVerification, &trustStores, &trustedIdentities); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if signatureVerification.VerificationLevel != "skip" || signatureVerification.Override != nil || trustStores != nil || trustedIdentities != nil {
+			t.Fatalf("unexpected statement %+v %v %v", signatureVerification, trustStores, trustedIdentities)
+		}
+	})
+
+	t.Run("remove the last override", func(t *testing.T) {
+		opts := &StatementFlagOpts{}
+		fs := newFlagSet(opts, "--remove-override", "revocation")
+		signatureVerification := trustpolicy.SignatureVerification{
+			Override: map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+				trustpolicy.TypeRevocation: trustpolicy.ActionLog,
+			},
+		}
+		var trustStores, trustedIdentities []string
+		if err := opts.Apply(fs, &signatureVerification, &trustStores, &trustedIdentities); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if signatureVerification.Override != nil {
+			t.Fatalf("expected no override, got %v", signatureVerification.Override)
+		}
+	})
+
+	for name, args := range map[string][]string{
+		"empty verification level": {"--verification-level", ""},
+		"invalid override":         {"--override", "revocation"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			opts := &StatementFlagOpts{}
+			fs := newFlagSet(opts, args...)
+			var signatureVerification trustpolicy.SignatureVerification
+			var trustStores, trustedIdentities []string
+			if err := opts.Apply(fs, &signatureVerification, &trustStores, &trustedIdentities); err == nil {
+				t.Fatal("expected error, but got nil")
+			}
+		})
+	}
+}
+
+func TestParseOverrides(t *testing.T) {
+	overrides, err := ParseOverrides([]string{"revocation=log", "expiry=log"})
+	if err != nil {
+		t.Fatalf("ParseOverrides() error = %v", err)
+	}
+	expected := map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+		trustpolicy.TypeRevocation: trustpolicy.ActionLog,
+		trustpolicy.TypeExpiry:     trustpolicy.ActionLog,
+	}
+	if !reflect.DeepEqual(overrides, expected) {
+		t.Fatalf("expected %v, got %v", expected, overrides)
+	}
+	for _, override := range []string{"revocation", "=log", "revocation="} {
+		if _, err := ParseOverrides([]string{override}); err == nil {
+			t.Errorf("ParseOverrides(%q) expected error, but got nil", override)
+		}
+	}
+}
@@ -11,7 +11,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package registry
 
 import (
 	"context"
@@ -21,13 +21,16 @@ import (
 	"testing"
 
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/registry/registrytest"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
 )
 
 const (
 	zeroDigest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
 )
 
-func TestRegistry_getRemoteRepositoryWithReferrersAPISupported(t *testing.T) {
+func TestRegistry_GetRemoteRepositoryWithReferrersAPISupported(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet && r.URL.Path == "/v2/test/v1/referrers/"+zeroDigest {
 			w.WriteHeader(http.StatusOK)
@@ -45,13 +48,13 @@ func TestRegistry_getRemoteRepositoryWithReferrersAPISupported(t *testing.T) {
 	secureOpts := flag.SecureFlagOpts{
 		InsecureRegistry: true,
 	}
-	_, err = getRemoteRepository(context.Background(), &secureOpts, uri.Host+"/test:v1", true)
+	_, err = GetRemoteRepository(context.Background(), &secureOpts, uri.Host+"/test:v1", true)
 	if err != nil {
-		t.Errorf("getRemoteRepository() expected nil error, but got error: %v", err)
+		t.Errorf("GetRemoteRepository() expected nil error, but got error: %v", err)
 	}
 }
 
-func TestRegistry_getRemoteRepositoryWithReferrersAPINotSupported(t *testing.T) {
+func TestRegistry_GetRemoteRepositoryWithReferrersAPINotSupported(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet && r.URL.Path == "/v2/test/v1/referrers/"+zeroDigest {
 			w.WriteHeader(http.StatusNotFound)
@@ -68,13 +71,13 @@ func TestRegistry_getRemoteRepositoryWithReferrersAPINotSupported(t *testing.T)
 	secureOpts := flag.SecureFlagOpts{
 		InsecureRegistry: true,
 	}
-	_, err = getRemoteRepository(context.Background(), &secureOpts, uri.Host+"/test:v1", true)
+	_, err = GetRemoteRepository(context.Background(), &secureOpts, uri.Host+"/test:v1", true)
 	if err != nil {
-		t.Errorf("getRemoteRepository() expected nil error, but got error: %v", err)
+		t.Errorf("GetRemoteRepository() expected nil error, but got error: %v", err)
 	}
 }
 
-func TestRegistry_getRemoteRepositoryWithReferrersTagSchema(t *testing.T) {
+func TestRegistry_GetRemoteRepositoryWithReferrersTagSchema(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet && r.URL.Path == "/v2/test/v1/referrers/"+zeroDigest {
 			w.WriteHeader(http.StatusOK)
@@ -92,8 +95,31 @@ func TestRegistry_getRemoteRepositoryWithReferrersTagSchema(t *testing.T) {
 	secureOpts := flag.SecureFlagOpts{
 		InsecureRegistry: true,
 	}
-	_, err = getRemoteRepository(context.Background(), &secureOpts, uri.Host+"/test:v1", false)
+	_, err = GetRemoteRepository(context.Background(), &secureOpts, uri.Host+"/test:v1", false)
 	if err != nil {
-		t.Errorf("getRemoteRepository() expected nil error, but got error: %v", err)
+		t.Errorf("GetRemoteRepository() expected nil error, but got error: %v", err)
+	}
+}
+
+func TestPushIfNotExist(t *testing.T) {
+	ctx := context.Background()
+	reg := registrytest.New(t)
+	ref, err := registry.ParseReference(reg.Host + "/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := GetRepositoryClient(ctx, &flag.SecureFlagOpts{InsecureRegistry: true}, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("test blob")
+	desc := content.NewDescriptorFromBytes("application/octet-stream", data)
+	for i := 0; i < 2; i++ {
+		if err := PushIfNotExist(ctx, repo, desc, data); err != nil {
+			t.Fatalf("PushIfNotExist() error = %v", err)
+		}
+	}
+	if uploads := reg.BlobUploads(); uploads != 1 {
+		t.Fatalf("expected the blob to be uploaded once, got %d uploads", uploads)
 	}
 }
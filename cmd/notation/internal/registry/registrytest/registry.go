@@ -0,0 +1,181 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registrytest provides an in-memory registry for testing.
+package registrytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Registry is an in-memory registry supporting the subset of the OCI
+// distribution API used by notation to push and pull artifacts, including
+// the referrers API. The content of all the repositories is shared.
+type Registry struct {
+	// Host is the host of the registry in the form of "<address>:<port>".
+	Host string
+
+	mu          sync.Mutex
+	blobs       map[digest.Digest][]byte
+	manifests   map[string]*manifest
+	blobUploads int
+}
+
+type manifest struct {
+	mediaType string
+	content   []byte
+}
+
+// New starts a new in-memory registry, which is closed when the test
+// finishes.
+func New(t *testing.T) *Registry {
+	t.Helper()
+	reg := &Registry{
+		blobs:     make(map[digest.Digest][]byte),
+		manifests: make(map[string]*manifest),
+	}
+	ts := httptest.NewServer(reg)
+	t.Cleanup(ts.Close)
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("invalid test http server: %v", err)
+	}
+	reg.Host = uri.Host
+	return reg
+}
+
+// BlobUploads returns the number of blobs uploaded to the registry.
+func (reg *Registry) BlobUploads() int {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	return reg.blobUploads
+}
+
+// SetBlob sets the content of the blob d, regardless of whether content
+// matches d.
+func (reg *Registry) SetBlob(d digest.Digest, content []byte) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.blobs[d] = content
+}
+
+// SetManifest sets the manifest content of mediaType, which is referenced by
+// its digest and the tag.
+func (reg *Registry) SetManifest(tag, mediaType string, content []byte) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	m := &manifest{mediaType: mediaType, content: content}
+	reg.manifests[digest.FromBytes(content).String()] = m
+	reg.manifests[tag] = m
+}
+
+// ServeHTTP serves the OCI distribution API.
+func (reg *Registry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	reference := path[strings.LastIndex(path, "/")+1:]
+	switch {
+	case path == "":
+		w.WriteHeader(http.StatusOK)
+	case strings.HasSuffix(path, "/blobs/uploads/") && r.Method == http.MethodPost:
+		w.Header().Set("Location", r.URL.Path+"upload")
+		w.WriteHeader(http.StatusAccepted)
+	case strings.HasSuffix(path, "/blobs/uploads/upload") && r.Method == http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		d := digest.Digest(r.URL.Query().Get("digest"))
+		if d != digest.FromBytes(content) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reg.blobs[d] = content
+		reg.blobUploads++
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(path, "/blobs/"):
+		content, ok := reg.blobs[digest.Digest(reference)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeContent(w, r, "application/octet-stream", content)
+	case strings.Contains(path, "/manifests/"):
+		if r.Method == http.MethodPut {
+			content, _ := io.ReadAll(r.Body)
+			m := &manifest{mediaType: r.Header.Get("Content-Type"), content: content}
+			d := digest.FromBytes(content)
+			reg.manifests[d.String()] = m
+			reg.manifests[reference] = m
+			var subject struct {
+				Subject *ocispec.Descriptor `json:"subject"`
+			}
+			if json.Unmarshal(content, &subject) == nil && subject.Subject != nil {
+				w.Header().Set("OCI-Subject", subject.Subject.Digest.String())
+			}
+			w.Header().Set("Docker-Content-Digest", d.String())
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		m, ok := reg.manifests[reference]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeContent(w, r, m.mediaType, m.content)
+	case strings.Contains(path, "/referrers/"):
+		index := ocispec.Index{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageIndex,
+			Manifests: []ocispec.Descriptor{},
+		}
+		for key, m := range reg.manifests {
+			var content ocispec.Manifest
+			if _, err := digest.Parse(key); err != nil || json.Unmarshal(m.content, &content) != nil || content.Subject == nil || content.Subject.Digest.String() != reference {
+				continue
+			}
+			index.Manifests = append(index.Manifests, ocispec.Descriptor{
+				MediaType:    m.mediaType,
+				ArtifactType: content.ArtifactType,
+				Digest:       digest.Digest(key),
+				Size:         int64(len(m.content)),
+				Annotations:  content.Annotations,
+			})
+		}
+		content, _ := json.Marshal(index)
+		writeContent(w, r, ocispec.MediaTypeImageIndex, content)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeContent(w http.ResponseWriter, r *http.Request, mediaType string, content []byte) {
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+	w.Header().Set("Docker-Content-Digest", digest.FromBytes(content).String())
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		w.Write(content)
+	}
+}
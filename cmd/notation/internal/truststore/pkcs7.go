@@ -0,0 +1,135 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package truststore
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+)
+
+// oidSignedData is the object identifier of the PKCS #7 signed data content
+// type.
+var oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+// contentInfo is the PKCS #7 ContentInfo defined in RFC 2315 section 7.
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+// signedData is the PKCS #7 SignedData defined in RFC 2315 section 9.1. Only
+// the certificates are parsed.
+type signedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	ContentInfo      asn1.RawValue
+	Certificates     rawCertificates `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue   `asn1:"optional,tag:1"`
+	SignerInfos      asn1.RawValue
+}
+
+// rawCertificates is the implicitly tagged set of certificates of a PKCS #7
+// SignedData.
+type rawCertificates struct {
+	Raw asn1.RawContent
+}
+
+// ReadCertificateFile reads the certificates in a PEM or DER encoded
+// certificate file, or a PEM or DER encoded PKCS #7 file such as a .p7b or
+// .p7c bundle. It also reports whether the file is a PKCS #7 file, whose
+// content cannot be added to the trust store verbatim.
+func ReadCertificateFile(path string) ([]*x509.Certificate, bool, error) {
+	certs, err := corex509.ReadCertificateFile(path)
+	if err == nil {
+		return certs, false, nil
+	}
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, false, readErr
+	}
+	if !isPKCS7(data) {
+		return nil, false, err
+	}
+	certs, err = parsePKCS7Certificates(data)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to parse PKCS #7 file %s: %w", path, err)
+	}
+	return certs, true, nil
+}
+
+// isPKCS7 reports whether data is a PEM or DER encoded PKCS #7 content info.
+func isPKCS7(data []byte) bool {
+	if block, _ := pem.Decode(data); block != nil {
+		return block.Type == "PKCS7"
+	}
+	var info contentInfo
+	_, err := asn1.Unmarshal(data, &info)
+	return err == nil && info.ContentType.Equal(oidSignedData)
+}
+
+// parsePKCS7Certificates parses the certificates of the PEM or DER encoded
+// PKCS #7 signed data. PEM encoded data may hold more than one PKCS #7 block.
+func parsePKCS7Certificates(data []byte) ([]*x509.Certificate, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----")) {
+		return parsePKCS7DER(data)
+	}
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "PKCS7" {
+			return nil, fmt.Errorf("unexpected PEM block type %q", block.Type)
+		}
+		blockCerts, err := parsePKCS7DER(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, blockCerts...)
+	}
+	return certs, nil
+}
+
+func parsePKCS7DER(der []byte) ([]*x509.Certificate, error) {
+	var info contentInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, errors.New("trailing data after content info")
+	}
+	if !info.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("unsupported content type %s", info.ContentType)
+	}
+	var sd signedData
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &sd); err != nil {
+		return nil, err
+	}
+	if len(sd.Certificates.Raw) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+	var certSet asn1.RawValue
+	if _, err := asn1.Unmarshal(sd.Certificates.Raw, &certSet); err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificates(certSet.Bytes)
+}
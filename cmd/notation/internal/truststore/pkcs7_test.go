@@ -0,0 +1,63 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package truststore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadCertificateFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		expectedCount int
+		expectedP7    bool
+	}{
+		{name: "PEM certificate", path: "testdata/NotationTestRoot.pem", expectedCount: 1},
+		{name: "PEM encoded PKCS #7", path: "testdata/bundle.p7b", expectedCount: 3, expectedP7: true},
+		{name: "DER encoded PKCS #7", path: "testdata/bundle.p7c", expectedCount: 3, expectedP7: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certs, isPKCS7, err := ReadCertificateFile(filepath.FromSlash(tt.path))
+			if err != nil {
+				t.Fatalf("ReadCertificateFile() error = %v", err)
+			}
+			if len(certs) != tt.expectedCount || isPKCS7 != tt.expectedP7 {
+				t.Fatalf("expected %d certificates and PKCS #7 %v, but got %d and %v", tt.expectedCount, tt.expectedP7, len(certs), isPKCS7)
+			}
+		})
+	}
+
+	t.Run("invalid file", func(t *testing.T) {
+		expectedErrMsg := "x509: malformed certificate"
+		_, _, err := ReadCertificateFile(filepath.FromSlash("testdata/invalid.txt"))
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("invalid PKCS #7 file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "invalid.p7b")
+		if err := os.WriteFile(path, []byte("-----BEGIN PKCS7-----\naW52YWxpZA==\n-----END PKCS7-----\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		_, isPKCS7, err := ReadCertificateFile(path)
+		if err == nil || !isPKCS7 {
+			t.Fatalf("expected error for an invalid PKCS #7 file, but got: %v", err)
+		}
+	})
+}
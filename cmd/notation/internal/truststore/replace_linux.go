@@ -0,0 +1,43 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package truststore
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// replaceDir replaces the directory dst with the directory src and returns
+// the path holding the previous content of dst, or an empty path if dst did
+// not exist. An existing dst is exchanged with src in one atomic step, so dst
+// is never observed missing. It falls back to renameDir if the file system
+// does not support the exchange.
+func replaceDir(src, dst string) (string, error) {
+	err := unix.Renameat2(unix.AT_FDCWD, src, unix.AT_FDCWD, dst, unix.RENAME_EXCHANGE)
+	switch {
+	case err == nil:
+		return src, nil
+	case errors.Is(err, unix.ENOENT):
+		// dst does not exist
+		return "", os.Rename(src, dst)
+	case errors.Is(err, unix.ENOSYS), errors.Is(err, unix.EINVAL):
+		// the exchange is not supported
+		return renameDir(src, dst)
+	}
+	return "", &os.LinkError{Op: "renameat2", Old: src, New: dst, Err: err}
+}
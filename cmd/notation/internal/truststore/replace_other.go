@@ -0,0 +1,23 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package truststore
+
+// replaceDir replaces the directory dst with the directory src and returns
+// the path holding the previous content of dst, or an empty path if dst did
+// not exist. See renameDir for the window during which dst does not exist.
+func replaceDir(src, dst string) (string, error) {
+	return renameDir(src, dst)
+}
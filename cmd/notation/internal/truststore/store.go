@@ -0,0 +1,196 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package truststore
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/internal/osutil"
+)
+
+// CopyCerts copies the certificate files fileNames from the trust store
+// under dir truststore/x509/srcType/srcStore to the trust store under dir
+// truststore/x509/dstType/dstStore, or all the certificate files if fileNames
+// is empty. Nothing is copied if any of the certificates is already in the
+// destination trust store, or if any of the file names is taken by a
+// different certificate file. It returns the names of the copied files.
+func CopyCerts(srcType, srcStore, dstType, dstStore string, fileNames []string) ([]string, error) {
+	srcPath, dstPath, files, err := prepareCopy(srcType, srcStore, dstType, dstStore, fileNames)
+	if err != nil {
+		return nil, err
+	}
+	var copied []string
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(srcPath, file.name))
+		if err == nil {
+			err = osutil.WriteFileWithPermission(filepath.Join(dstPath, file.name), data, 0600, false)
+		}
+		if err != nil {
+			// roll back the copied files
+			for _, name := range copied {
+				os.Remove(filepath.Join(dstPath, name))
+			}
+			removeStoreIfEmpty(dstPath)
+			return nil, fmt.Errorf("failed to copy %s: %w", file.name, err)
+		}
+		copied = append(copied, file.name)
+	}
+	return copied, nil
+}
+
+// MoveCerts moves the certificate files fileNames from the trust store under
+// dir truststore/x509/srcType/srcStore to the trust store under dir
+// truststore/x509/dstType/dstStore, or all the certificate files if fileNames
+// is empty, as CopyCerts does. The source trust store directory is removed if
+// it becomes empty. It returns the names of the moved files. If a file fails
+// to be deleted from the source trust store, the copies of the files not yet
+// deleted are removed from the destination trust store, and the names of the
+// files moved so far are returned with the error.
+func MoveCerts(srcType, srcStore, dstType, dstStore string, fileNames []string) ([]string, error) {
+	copied, err := CopyCerts(srcType, srcStore, dstType, dstStore, fileNames)
+	if err != nil {
+		return nil, err
+	}
+	srcPath, err := storePath(srcType, srcStore)
+	if err != nil {
+		return nil, err
+	}
+	dstPath, err := storePath(dstType, dstStore)
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range copied {
+		if err := os.Remove(filepath.Join(srcPath, name)); err != nil {
+			// roll back the copies of the files not moved
+			for _, name := range copied[i:] {
+				os.Remove(filepath.Join(dstPath, name))
+			}
+			removeStoreIfEmpty(dstPath)
+			removeStoreIfEmpty(srcPath)
+			return copied[:i], fmt.Errorf("failed to delete %s from the source trust store: %w", name, err)
+		}
+	}
+	removeStoreIfEmpty(srcPath)
+	return copied, nil
+}
+
+// RenameStore renames the trust store under dir
+// truststore/x509/storeType/oldName to newName.
+func RenameStore(storeType, oldName, newName string) error {
+	if err := validateStore(storeType, oldName); err != nil {
+		return err
+	}
+	if err := validateStore(storeType, newName); err != nil {
+		return err
+	}
+	if oldName == newName {
+		return errors.New("the new name of the trust store is the same as the current name")
+	}
+	oldPath, err := storePath(storeType, oldName)
+	if err != nil {
+		return err
+	}
+	newPath, err := storePath(storeType, newName)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(oldPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("trust store %s of type %s does not exist", oldName, storeType)
+		}
+		return err
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("trust store %s of type %s already exists", newName, storeType)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return os.Rename(oldPath, newPath)
+}
+
+// prepareCopy validates the trust stores and the certificate files to be
+// copied, and returns the paths of the trust stores and the files.
+func prepareCopy(srcType, srcStore, dstType, dstStore string, fileNames []string) (string, string, []certFile, error) {
+	if err := validateStore(srcType, srcStore); err != nil {
+		return "", "", nil, err
+	}
+	if err := validateStore(dstType, dstStore); err != nil {
+		return "", "", nil, err
+	}
+	if srcType == dstType && srcStore == dstStore {
+		return "", "", nil, errors.New("the source and the destination trust stores are the same")
+	}
+	srcPath, err := storePath(srcType, srcStore)
+	if err != nil {
+		return "", "", nil, err
+	}
+	dstPath, err := storePath(dstType, dstStore)
+	if err != nil {
+		return "", "", nil, err
+	}
+	if _, err := os.Stat(srcPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", "", nil, fmt.Errorf("trust store %s of type %s does not exist", srcStore, srcType)
+		}
+		return "", "", nil, err
+	}
+	srcFiles, err := readStore(srcPath)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	// select the files to be copied
+	files := srcFiles
+	if len(fileNames) > 0 {
+		files = nil
+		for _, name := range fileNames {
+			hasName := func(file certFile) bool { return file.name == name }
+			i := slices.IndexFunc(srcFiles, hasName)
+			if i < 0 {
+				return "", "", nil, fmt.Errorf("certificate file %s does not exist in trust store %s of type %s", name, srcStore, srcType)
+			}
+			if !slices.ContainsFunc(files, hasName) {
+				files = append(files, srcFiles[i])
+			}
+		}
+	}
+	if len(files) == 0 {
+		return "", "", nil, fmt.Errorf("no certificate file found in trust store %s of type %s", srcStore, srcType)
+	}
+
+	// check conflicts with the destination trust store and among the files
+	dstFiles, err := readStore(dstPath)
+	if err != nil {
+		return "", "", nil, err
+	}
+	for _, file := range files {
+		if err := checkFileConflict(dstFiles, file); err != nil {
+			return "", "", nil, fmt.Errorf("failed to copy %s: %w", file.name, err)
+		}
+		dstFiles = append(dstFiles, file)
+	}
+	return srcPath, dstPath, files, nil
+}
+
+// storePath returns the path of the trust store under dir
+// truststore/x509/storeType/namedStore.
+func storePath(storeType, namedStore string) (string, error) {
+	return dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509", storeType, namedStore)
+}
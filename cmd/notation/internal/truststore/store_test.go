@@ -0,0 +1,180 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package truststore
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+// setupStores sets up a trust store directory with the certificate files laid
+// out as "<type>/<store>/<file>", and returns the x509 directory.
+func setupStores(t *testing.T, files map[string]string) string {
+	t.Helper()
+	oldDir := dir.UserConfigDir
+	t.Cleanup(func() {
+		dir.UserConfigDir = oldDir
+	})
+	dir.UserConfigDir = t.TempDir()
+	x509Dir := filepath.Join(dir.UserConfigDir, "truststore", "x509")
+	writeLayout(t, x509Dir, files)
+	return x509Dir
+}
+
+func TestCopyCerts(t *testing.T) {
+	x509Dir := setupStores(t, map[string]string{
+		"ca/acme/root.crt":     "testdata/self-signed.crt",
+		"ca/acme/notation.pem": "testdata/NotationTestRoot.pem",
+		"ca/acme/junk.txt":     "testdata/invalid.txt",
+	})
+
+	copied, err := CopyCerts("ca", "acme", "ca", "acme-prod", nil)
+	if err != nil {
+		t.Fatalf("CopyCerts() error = %v", err)
+	}
+	if expected := []string{"notation.pem", "root.crt"}; !reflect.DeepEqual(copied, expected) {
+		t.Fatalf("expected copied files %v, got %v", expected, copied)
+	}
+	for _, file := range []string{"ca/acme/root.crt", "ca/acme-prod/root.crt", "ca/acme-prod/notation.pem"} {
+		if _, err := os.Stat(filepath.Join(x509Dir, filepath.FromSlash(file))); err != nil {
+			t.Fatalf("expected file %s: %v", file, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(x509Dir, "ca", "acme-prod", "junk.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected junk.txt not to be copied, got %v", err)
+	}
+
+	copied, err = CopyCerts("ca", "acme", "signingAuthority", "acme", []string{"root.crt", "root.crt"})
+	if err != nil {
+		t.Fatalf("CopyCerts() error = %v", err)
+	}
+	if expected := []string{"root.crt"}; !reflect.DeepEqual(copied, expected) {
+		t.Fatalf("expected copied files %v, got %v", expected, copied)
+	}
+}
+
+func TestCopyCerts_Error(t *testing.T) {
+	x509Dir := setupStores(t, map[string]string{
+		"ca/acme/root.crt":      "testdata/self-signed.crt",
+		"ca/acme/notation.pem":  "testdata/NotationTestRoot.pem",
+		"ca/acme/junk.txt":      "testdata/invalid.txt",
+		"ca/dst/duplicate.crt":  "testdata/NotationTestRoot.pem",
+		"ca/empty/junk.txt":     "testdata/invalid.txt",
+		"ca/other/root.crt":     "testdata/NotationTestRoot.pem",
+		"tsa/timestamp/tsa.crt": "testdata/self-signed.crt",
+	})
+
+	tests := []struct {
+		name                                 string
+		srcType, srcStore, dstType, dstStore string
+		fileNames                            []string
+		expectedErr                          string
+	}{
+		{"same store", "ca", "acme", "ca", "acme", nil, "the source and the destination trust stores are the same"},
+		{"invalid type", "ca", "acme", "unknown", "acme", nil, "unsupported store type: unknown"},
+		{"invalid store name", "ca", "acme", "ca", "a/b", nil, "named store name needs to follow [a-zA-Z0-9_.-]+ format"},
+		{"source not exist", "ca", "none", "ca", "dst", nil, "trust store none of type ca does not exist"},
+		{"file not exist", "ca", "acme", "ca", "new", []string{"none.crt"}, "certificate file none.crt does not exist in trust store acme of type ca"},
+		{"not a certificate file", "ca", "acme", "ca", "new", []string{"junk.txt"}, "certificate file junk.txt does not exist in trust store acme of type ca"},
+		{"no certificate file", "ca", "empty", "ca", "new", nil, "no certificate file found in trust store empty of type ca"},
+		{"duplicate certificate", "ca", "acme", "ca", "dst", nil, "failed to copy notation.pem: certificate with SHA-256 fingerprint"},
+		{"certificate under another name", "tsa", "timestamp", "ca", "acme", nil, "failed to copy tsa.crt: certificate with SHA-256 fingerprint"},
+		{"file name taken", "ca", "acme", "ca", "other", []string{"root.crt"}, "failed to copy root.crt: a different certificate file named root.crt already exists in the Trust Store"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CopyCerts(tt.srcType, tt.srcStore, tt.dstType, tt.dstStore, tt.fileNames)
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+
+	// nothing is copied on conflicts
+	if _, err := os.Stat(filepath.Join(x509Dir, "ca", "dst", "root.crt")); !os.IsNotExist(err) {
+		t.Fatalf("expected root.crt not to be copied, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(x509Dir, "ca", "new")); !os.IsNotExist(err) {
+		t.Fatalf("expected trust store new not to be created, got %v", err)
+	}
+}
+
+func TestMoveCerts(t *testing.T) {
+	x509Dir := setupStores(t, map[string]string{
+		"ca/acme/root.crt":     "testdata/self-signed.crt",
+		"ca/acme/notation.pem": "testdata/NotationTestRoot.pem",
+	})
+
+	moved, err := MoveCerts("ca", "acme", "signingAuthority", "acme", []string{"root.crt"})
+	if err != nil {
+		t.Fatalf("MoveCerts() error = %v", err)
+	}
+	if expected := []string{"root.crt"}; !reflect.DeepEqual(moved, expected) {
+		t.Fatalf("expected moved files %v, got %v", expected, moved)
+	}
+	if _, err := os.Stat(filepath.Join(x509Dir, "ca", "acme", "root.crt")); !os.IsNotExist(err) {
+		t.Fatalf("expected root.crt to be moved, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(x509Dir, "signingAuthority", "acme", "root.crt")); err != nil {
+		t.Fatalf("expected root.crt in the destination trust store: %v", err)
+	}
+
+	// the source trust store is removed once empty
+	if _, err := MoveCerts("ca", "acme", "ca", "acme-dev", nil); err != nil {
+		t.Fatalf("MoveCerts() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(x509Dir, "ca", "acme")); !os.IsNotExist(err) {
+		t.Fatalf("expected empty trust store acme to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(x509Dir, "ca", "acme-dev", "notation.pem")); err != nil {
+		t.Fatalf("expected notation.pem in the destination trust store: %v", err)
+	}
+}
+
+func TestRenameStore(t *testing.T) {
+	x509Dir := setupStores(t, map[string]string{
+		"ca/acme/root.crt":      "testdata/self-signed.crt",
+		"ca/taken/root.crt":     "testdata/self-signed.crt",
+		"tsa/acme/notation.pem": "testdata/NotationTestRoot.pem",
+	})
+
+	if err := RenameStore("ca", "acme", "acme-prod"); err != nil {
+		t.Fatalf("RenameStore() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(x509Dir, "ca", "acme-prod", "root.crt")); err != nil {
+		t.Fatalf("expected renamed trust store: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(x509Dir, "tsa", "acme", "notation.pem")); err != nil {
+		t.Fatalf("expected trust store of other types to be kept: %v", err)
+	}
+
+	for _, tt := range []struct {
+		oldName, newName, expectedErr string
+	}{
+		{"acme", "new", "trust store acme of type ca does not exist"},
+		{"acme-prod", "taken", "trust store taken of type ca already exists"},
+		{"acme-prod", "acme-prod", "the new name of the trust store is the same as the current name"},
+		{"acme-prod", "a b", "named store name needs to follow [a-zA-Z0-9_.-]+ format"},
+	} {
+		err := RenameStore("ca", tt.oldName, tt.newName)
+		if err == nil || err.Error() != tt.expectedErr {
+			t.Fatalf("expected error %q, got %v", tt.expectedErr, err)
+		}
+	}
+}
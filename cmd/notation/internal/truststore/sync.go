@@ -0,0 +1,327 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package truststore
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+)
+
+// SyncAction is the action to sync a certificate file of the trust store.
+type SyncAction string
+
+const (
+	// SyncAdd adds a certificate file to the trust store.
+	SyncAdd SyncAction = "add"
+
+	// SyncUpdate replaces a certificate file of the trust store with a file
+	// of different content.
+	SyncUpdate SyncAction = "update"
+
+	// SyncDelete deletes a certificate file from the trust store.
+	SyncDelete SyncAction = "delete"
+)
+
+// SyncChange is a change to a certificate file of the trust store to bring
+// it in line with a source directory.
+type SyncChange struct {
+	Action    SyncAction
+	StoreType string
+	StoreName string
+	FileName  string
+
+	// Content is the validated content of the source certificate file to be
+	// written by SyncAdd and SyncUpdate.
+	Content []byte
+}
+
+// Path returns the path of the certificate file relative to the x509 trust
+// store directory, in the form of "<type>/<store>/<file>".
+func (c SyncChange) Path() string {
+	return path.Join(c.StoreType, c.StoreName, c.FileName)
+}
+
+// PlanSync computes the changes to bring the x509 trust stores in line with
+// sourceDir, which is laid out as "<type>/<store>/<file>" like the
+// truststore/x509 directory. Certificate files in sourceDir that are not in
+// the trust stores or are of different content are added or updated. If
+// prune is set, certificate files in the trust stores that are not in
+// sourceDir are deleted. The changes are sorted by path.
+func PlanSync(sourceDir string, prune bool) ([]SyncChange, error) {
+	x509Dir, err := dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509")
+	if err != nil {
+		return nil, err
+	}
+	desired, err := readLayout(sourceDir, true)
+	if err != nil {
+		return nil, err
+	}
+	current, err := readLayout(x509Dir, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the trust stores: %w", err)
+	}
+
+	var changes []SyncChange
+	for relPath, content := range desired {
+		currentContent, ok := current[relPath]
+		switch {
+		case !ok:
+			changes = append(changes, newSyncChange(SyncAdd, relPath, content))
+		case !bytes.Equal(content, currentContent):
+			changes = append(changes, newSyncChange(SyncUpdate, relPath, content))
+		}
+	}
+	if prune {
+		for relPath := range current {
+			if _, ok := desired[relPath]; !ok {
+				changes = append(changes, newSyncChange(SyncDelete, relPath, nil))
+			}
+		}
+	}
+	slices.SortFunc(changes, func(a, b SyncChange) int {
+		return strings.Compare(a.Path(), b.Path())
+	})
+	return changes, nil
+}
+
+// ApplySync applies the changes planned by PlanSync to the x509 trust stores.
+// Added and updated files are written with the content validated by
+// PlanSync, so later modifications of the source directory are not applied.
+// The changes are applied to a copy of the x509 trust store directory, which
+// then replaces the directory, so that the trust stores are never observed
+// partially synced. On Linux, the directories are exchanged atomically; on
+// other platforms, the trust stores are briefly missing while the directory
+// is replaced (see renameDir).
+func ApplySync(changes []SyncChange) (err error) {
+	x509Dir, err := dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509")
+	if err != nil {
+		return err
+	}
+	parentDir := filepath.Dir(x509Dir)
+	if err := os.MkdirAll(parentDir, 0700); err != nil {
+		return err
+	}
+
+	// stage the synced trust stores
+	stagingDir, err := os.MkdirTemp(parentDir, ".x509-sync-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(stagingDir)
+		}
+	}()
+	if err := copyTree(x509Dir, stagingDir); err != nil {
+		return fmt.Errorf("failed to copy the trust stores: %w", err)
+	}
+	for _, change := range changes {
+		stagedPath := filepath.Join(stagingDir, filepath.FromSlash(change.Path()))
+		switch change.Action {
+		case SyncAdd, SyncUpdate:
+			if err := os.MkdirAll(filepath.Dir(stagedPath), 0700); err != nil {
+				return err
+			}
+			if err := os.WriteFile(stagedPath, change.Content, 0600); err != nil {
+				return err
+			}
+		case SyncDelete:
+			if err := os.Remove(stagedPath); err != nil {
+				return err
+			}
+			removeEmptyDir(filepath.Dir(stagedPath))
+		}
+	}
+
+	// replace the trust stores with the staged ones
+	oldDir, err := replaceDir(stagingDir, x509Dir)
+	if err != nil {
+		return err
+	}
+	if oldDir != "" {
+		if err := os.RemoveAll(oldDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove the previous trust stores at %s: %v\n", oldDir, err)
+		}
+	}
+	return nil
+}
+
+// renameDir replaces the directory dst with the directory src by moving dst
+// aside and then moving src into place, and returns the path holding the
+// previous content of dst, or an empty path if dst did not exist. dst does
+// not exist between the two renames, so a concurrent reader may briefly find
+// no trust store at all, but never a partially replaced one. dst is restored
+// if src cannot be moved into place.
+func renameDir(src, dst string) (string, error) {
+	backupDir := src + ".old"
+	if err := os.Rename(dst, backupDir); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		return "", os.Rename(src, dst)
+	}
+	if err := os.Rename(src, dst); err != nil {
+		if restoreErr := os.Rename(backupDir, dst); restoreErr != nil {
+			return "", fmt.Errorf("%w, and failed to restore %s from %s: %v", err, dst, backupDir, restoreErr)
+		}
+		return "", err
+	}
+	return backupDir, nil
+}
+
+func newSyncChange(action SyncAction, relPath string, content []byte) SyncChange {
+	parts := strings.SplitN(relPath, "/", 3)
+	return SyncChange{
+		Action:    action,
+		StoreType: parts[0],
+		StoreName: parts[1],
+		FileName:  parts[2],
+		Content:   content,
+	}
+}
+
+// readLayout reads the certificate files laid out as "<type>/<store>/<file>"
+// under root, keyed by their paths relative to root in the form of
+// "<type>/<store>/<file>". Directories of unsupported store types,
+// subdirectories of the named stores, and hidden files, such as ".gitkeep",
+// are ignored. If validate is set, the content read of every file must be a
+// valid certificate file. An empty layout is returned if root does not exist.
+func readLayout(root string, validate bool) (map[string][]byte, error) {
+	layout := make(map[string][]byte)
+	for _, t := range truststore.Types {
+		storeType := string(t)
+		stores, err := os.ReadDir(filepath.Join(root, storeType))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, store := range stores {
+			if !store.IsDir() || strings.HasPrefix(store.Name(), ".") {
+				continue
+			}
+			if !IsValidFileName(store.Name()) {
+				return nil, fmt.Errorf("invalid trust store name %q in %s: named store name needs to follow [a-zA-Z0-9_.-]+ format", store.Name(), filepath.Join(root, storeType))
+			}
+			storePath := filepath.Join(root, storeType, store.Name())
+			files, err := os.ReadDir(storePath)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range files {
+				if !file.Type().IsRegular() || strings.HasPrefix(file.Name(), ".") {
+					continue
+				}
+				filePath := filepath.Join(storePath, file.Name())
+				content, err := os.ReadFile(filePath)
+				if err != nil {
+					return nil, err
+				}
+				if validate {
+					certs, err := parseCertificates(content)
+					if err == nil && len(certs) == 0 {
+						err = errors.New("no valid certificate found in the file")
+					}
+					if err != nil {
+						return nil, fmt.Errorf("%s is not a valid certificate file: %w", filePath, err)
+					}
+				}
+				layout[path.Join(storeType, store.Name(), file.Name())] = content
+			}
+		}
+	}
+	return layout, nil
+}
+
+// parseCertificates parses the PEM or DER encoded certificates of data in the
+// same way as corex509.ReadCertificateFile.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	block, rest := pem.Decode(data)
+	if block == nil {
+		return x509.ParseCertificates(data)
+	}
+	var certs []*x509.Certificate
+	for ; block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// copyTree copies the directory tree at src to the existing directory dst,
+// preserving the file modes and symbolic links. Nothing is copied if src does
+// not exist.
+func copyTree(src, dst string) error {
+	if _, err := os.Stat(src); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		target := filepath.Join(dst, relPath)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.Mkdir(target, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			content, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(target, content, info.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+// removeEmptyDir removes the directory at dirPath if it is empty.
+func removeEmptyDir(dirPath string) {
+	entries, err := os.ReadDir(dirPath)
+	if err == nil && len(entries) == 0 {
+		os.Remove(dirPath)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package truststore
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+// writeLayout copies the certificate files to root laid out as
+// "<type>/<store>/<file>".
+func writeLayout(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for relPath, src := range files {
+		dst := filepath.Join(root, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+			t.Fatal(err)
+		}
+		copyFile(t, filepath.FromSlash(src), dst)
+	}
+}
+
+func TestSync(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+	x509Dir := filepath.Join(dir.UserConfigDir, "truststore", "x509")
+	writeLayout(t, x509Dir, map[string]string{
+		"ca/acme/root.crt":   "testdata/self-signed.crt",
+		"ca/acme/stale.crt":  "testdata/self-signed.crt",
+		"ca/old/root.crt":    "testdata/self-signed.crt",
+		"tsa/timestamp/.tsa": "testdata/invalid.txt",
+	})
+	sourceDir := t.TempDir()
+	writeLayout(t, sourceDir, map[string]string{
+		"ca/acme/root.crt":             "testdata/NotationTestRoot.pem",
+		"ca/acme/.gitkeep":             "testdata/invalid.txt",
+		"signingAuthority/wabbit/cert": "testdata/self-signed.crt",
+		"unknown/store/file":           "testdata/invalid.txt",
+	})
+
+	rootContent, err := os.ReadFile(filepath.Join("testdata", "NotationTestRoot.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	certContent, err := os.ReadFile(filepath.Join("testdata", "self-signed.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := PlanSync(sourceDir, false)
+	if err != nil {
+		t.Fatalf("PlanSync() error = %v", err)
+	}
+	expected := []SyncChange{
+		{Action: SyncUpdate, StoreType: "ca", StoreName: "acme", FileName: "root.crt", Content: rootContent},
+		{Action: SyncAdd, StoreType: "signingAuthority", StoreName: "wabbit", FileName: "cert", Content: certContent},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected changes %v, but got %v", expected, changes)
+	}
+
+	changes, err = PlanSync(sourceDir, true)
+	if err != nil {
+		t.Fatalf("PlanSync() error = %v", err)
+	}
+	expected = []SyncChange{
+		{Action: SyncUpdate, StoreType: "ca", StoreName: "acme", FileName: "root.crt", Content: rootContent},
+		{Action: SyncDelete, StoreType: "ca", StoreName: "acme", FileName: "stale.crt"},
+		{Action: SyncDelete, StoreType: "ca", StoreName: "old", FileName: "root.crt"},
+		{Action: SyncAdd, StoreType: "signingAuthority", StoreName: "wabbit", FileName: "cert", Content: certContent},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected changes %v, but got %v", expected, changes)
+	}
+
+	// modifications of the source directory after planning are not applied
+	copyFile(t, filepath.Join("testdata", "invalid.txt"), filepath.Join(sourceDir, "signingAuthority", "wabbit", "cert"))
+	if err := ApplySync(changes); err != nil {
+		t.Fatalf("ApplySync() error = %v", err)
+	}
+	copyFile(t, filepath.Join("testdata", "self-signed.crt"), filepath.Join(sourceDir, "signingAuthority", "wabbit", "cert"))
+	changes, err = PlanSync(sourceDir, true)
+	if err != nil {
+		t.Fatalf("PlanSync() error = %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected the trust store in sync, but got changes %v", changes)
+	}
+	if _, err := os.Stat(filepath.Join(x509Dir, "ca", "old")); !os.IsNotExist(err) {
+		t.Fatalf("expected the emptied trust store to be removed, but got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(x509Dir, "tsa", "timestamp", ".tsa")); err != nil {
+		t.Fatalf("expected hidden files to be kept, but got %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Dir(x509Dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the x509 directory in the trust store directory, but got %d entries", len(entries))
+	}
+}
+
+func TestPlanSyncInvalidCertificate(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+	sourceDir := t.TempDir()
+	writeLayout(t, sourceDir, map[string]string{
+		"ca/acme/invalid.txt": "testdata/invalid.txt",
+	})
+
+	_, err := PlanSync(sourceDir, false)
+	if err == nil || !strings.Contains(err.Error(), "invalid.txt is not a valid certificate file") {
+		t.Fatalf("expected error for an invalid certificate file, but got: %v", err)
+	}
+}
+
+func TestApplySyncToEmptyTrustStore(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+	sourceDir := t.TempDir()
+	writeLayout(t, sourceDir, map[string]string{
+		"tsa/timestamp/tsa.crt": "testdata/self-signed.crt",
+	})
+
+	changes, err := PlanSync(sourceDir, true)
+	if err != nil {
+		t.Fatalf("PlanSync() error = %v", err)
+	}
+	if err := ApplySync(changes); err != nil {
+		t.Fatalf("ApplySync() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir.UserConfigDir, "truststore", "x509", "tsa", "timestamp", "tsa.crt")); err != nil {
+		t.Fatalf("expected the certificate file to be added, but got %v", err)
+	}
+}
+
+func TestReplaceDir(t *testing.T) {
+	for name, replace := range map[string]func(src, dst string) (string, error){
+		"replaceDir": replaceDir,
+		"renameDir":  renameDir,
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			src := filepath.Join(root, "src")
+			dst := filepath.Join(root, "dst")
+			writeLayout(t, src, map[string]string{"ca/acme/new.crt": "testdata/self-signed.crt"})
+
+			// dst does not exist
+			oldDir, err := replace(src, dst)
+			if err != nil {
+				t.Fatalf("%s() error = %v", name, err)
+			}
+			if oldDir != "" {
+				t.Fatalf("expected no previous content, but got %s", oldDir)
+			}
+
+			// dst exists
+			writeLayout(t, src, map[string]string{"ca/acme/newer.crt": "testdata/self-signed.crt"})
+			oldDir, err = replace(src, dst)
+			if err != nil {
+				t.Fatalf("%s() error = %v", name, err)
+			}
+			if _, err := os.Stat(filepath.Join(dst, "ca", "acme", "newer.crt")); err != nil {
+				t.Fatalf("expected dst to be replaced, but got %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(oldDir, "ca", "acme", "new.crt")); err != nil {
+				t.Fatalf("expected the previous content at %s, but got %v", oldDir, err)
+			}
+		})
+	}
+}
@@ -14,51 +14,52 @@
 package truststore
 
 import (
-	"crypto/sha256"
 	"crypto/x509"
-	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
 	"github.com/notaryproject/notation/v2/internal/osutil"
 )
 
 // AddCert adds a single cert file at path to the trust store
-// under dir truststore/x509/storeType/namedStore
+// under dir truststore/x509/storeType/namedStore. A PKCS #7 file is added as
+// a PEM file of the same base name, as the trust store does not support
+// PKCS #7 files.
 func AddCert(path, storeType, namedStore string, display bool) error {
 	// initialize
 	certPath, err := filepath.Abs(path)
 	if err != nil {
 		return err
 	}
-	if storeType == "" {
-		return errors.New("store type cannot be empty")
-	}
-	if !IsValidStoreType(storeType) {
-		return fmt.Errorf("unsupported store type: %s", storeType)
-	}
-	if !IsValidFileName(namedStore) {
-		return errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
+	if err := validateStore(storeType, namedStore); err != nil {
+		return err
 	}
 
 	// check if the target path is a x509 certificate
-	// (support PEM and DER formats)
-	certs, err := corex509.ReadCertificateFile(certPath)
+	// (support PEM, DER, and PKCS #7 formats)
+	certs, isPKCS7, err := ReadCertificateFile(certPath)
 	if err != nil {
 		return err
 	}
 	if len(certs) == 0 {
 		return errors.New("no valid certificate found in the file")
 	}
+	fileName := filepath.Base(certPath)
+	if isPKCS7 {
+		fileName = strings.TrimSuffix(fileName, filepath.Ext(fileName)) + ".pem"
+	}
 
 	// core process
 	// get the trust store path
@@ -68,28 +69,252 @@ func AddCert(path, storeType, namedStore string, display bool) error {
 	}
 
 	// check if certificate already in the trust store
-	if _, err := os.Stat(filepath.Join(trustStorePath, filepath.Base(certPath))); err == nil {
-		return errors.New("certificate already exists in the Trust Store")
+	if err := checkConflict(trustStorePath, fileName, certs); err != nil {
+		return err
 	}
 
 	// add cert to trust store
-	_, err = osutil.CopyToDir(certPath, trustStorePath)
+	if isPKCS7 {
+		err = osutil.WriteFileWithPermission(filepath.Join(trustStorePath, fileName), encodePEM(certs...), 0600, false)
+	} else {
+		_, err = osutil.CopyToDir(certPath, trustStorePath)
+	}
 	if err != nil {
 		return err
 	}
 
 	// write out
 	if display {
-		fmt.Printf("Successfully added %s to named store %s of type %s\n", filepath.Base(certPath), namedStore, storeType)
+		fmt.Printf("Successfully added %s to named store %s of type %s\n", fileName, namedStore, storeType)
 	}
 	return nil
 }
 
+// AddSplitCert adds cert to the trust store under dir
+// truststore/x509/storeType/namedStore as its own certificate file, named by
+// SplitCertFileName. It returns the name of the added file.
+func AddSplitCert(cert *x509.Certificate, storeType, namedStore string) (string, error) {
+	fileName := SplitCertFileName(cert)
+	if err := AddCertAs(cert, storeType, namedStore, fileName); err != nil {
+		return "", err
+	}
+	return fileName, nil
+}
+
+// AddCertAs adds cert to the trust store under dir
+// truststore/x509/storeType/namedStore as the certificate file fileName. It
+// returns a *CertExistsError if cert is already in the trust store.
+func AddCertAs(cert *x509.Certificate, storeType, namedStore, fileName string) error {
+	if err := validateStore(storeType, namedStore); err != nil {
+		return err
+	}
+	trustStorePath, err := dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509", storeType, namedStore)
+	if err := CheckNonErrNotExistError(err); err != nil {
+		return err
+	}
+	if err := checkConflict(trustStorePath, fileName, []*x509.Certificate{cert}); err != nil {
+		return err
+	}
+	return osutil.WriteFileWithPermission(filepath.Join(trustStorePath, fileName), encodePEM(cert), 0600, false)
+}
+
+// SplitCertFileName returns the file name of cert added to the trust store as
+// its own certificate file, which is its subject common name, or organization
+// if there is no common name, followed by the first 16 hex digits of its
+// SHA-256 fingerprint, such as "Acme_Root_CA-3b4d2a2e5fc2a5c3.crt".
+func SplitCertFileName(cert *x509.Certificate) string {
+	subject := cert.Subject.CommonName
+	if subject == "" && len(cert.Subject.Organization) > 0 {
+		subject = cert.Subject.Organization[0]
+	}
+	subject = strings.Trim(invalidFileNameChars.ReplaceAllString(subject, "_"), "_.-")
+	if subject == "" {
+		subject = "certificate"
+	}
+	return subject + "-" + certchain.Fingerprint(cert)[:16] + ".crt"
+}
+
+// validateStore validates the trust store type and the named store.
+func validateStore(storeType, namedStore string) error {
+	if storeType == "" {
+		return errors.New("store type cannot be empty")
+	}
+	if !IsValidStoreType(storeType) {
+		return fmt.Errorf("unsupported store type: %s", storeType)
+	}
+	if !IsValidFileName(namedStore) {
+		return errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
+	}
+	return nil
+}
+
+// encodePEM encodes certs in PEM format.
+func encodePEM(certs ...*x509.Certificate) []byte {
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return data
+}
+
+// checkConflict checks that certs to be added to the trust store at
+// trustStorePath as fileName are not in the trust store under any file name,
+// and that fileName is not taken by a different certificate file.
+func checkConflict(trustStorePath, fileName string, certs []*x509.Certificate) error {
+	files, err := readStore(trustStorePath)
+	if err != nil {
+		return err
+	}
+	return checkFileConflict(files, certFile{name: fileName, fingerprints: fingerprints(certs)})
+}
+
+// checkFileConflict checks that the certificates of file are not in files
+// under any file name, and that the name of file is not taken by a different
+// certificate file.
+func checkFileConflict(files []certFile, file certFile) error {
+	for _, existing := range files {
+		if existing.name == file.name {
+			if slices.Equal(existing.fingerprints, file.fingerprints) {
+				return &CertExistsError{}
+			}
+			return fmt.Errorf("a different certificate file named %s already exists in the Trust Store", file.name)
+		}
+	}
+	for _, fp := range file.fingerprints {
+		for _, existing := range files {
+			if slices.Contains(existing.fingerprints, fp) {
+				return &CertExistsError{Fingerprint: fp, FileName: existing.name}
+			}
+		}
+	}
+	return nil
+}
+
+// CertExistsError is returned when a certificate to be added is already in
+// the trust store.
+type CertExistsError struct {
+	// Fingerprint is the SHA-256 fingerprint of the certificate found in the
+	// certificate file FileName. Both are empty if the certificate file to be
+	// added is already in the trust store under the same name.
+	Fingerprint string
+	FileName    string
+}
+
+func (e *CertExistsError) Error() string {
+	if e.FileName == "" {
+		return "certificate already exists in the Trust Store"
+	}
+	return fmt.Sprintf("certificate with SHA-256 fingerprint %s already exists in the Trust Store as %s", e.Fingerprint, e.FileName)
+}
+
+// certFile is a certificate file in a trust store.
+type certFile struct {
+	name         string
+	fingerprints []string
+}
+
+// readStore reads the certificate files in the trust store at storePath.
+// Files that are not certificate files are skipped. An empty list is returned
+// if the trust store does not exist.
+func readStore(storePath string) ([]certFile, error) {
+	entries, err := os.ReadDir(storePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []certFile
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		certs, err := corex509.ReadCertificateFile(filepath.Join(storePath, entry.Name()))
+		if err != nil || len(certs) == 0 {
+			continue
+		}
+		files = append(files, certFile{
+			name:         entry.Name(),
+			fingerprints: fingerprints(certs),
+		})
+	}
+	return files, nil
+}
+
+// Duplicate is a certificate stored in more than one file of a trust store.
+type Duplicate struct {
+	// Fingerprint is the SHA-256 fingerprint of the certificate.
+	Fingerprint string
+
+	// Paths are the paths of the certificate files holding the certificate.
+	Paths []string
+}
+
+// FindDuplicates finds the certificates stored in more than one of the
+// certificate files at certPaths within the same trust store. Files that are
+// not certificate files are skipped.
+func FindDuplicates(certPaths []string) []Duplicate {
+	var duplicates []Duplicate
+	index := make(map[string]int) // key: trust store path and fingerprint
+	seen := make(map[string]string)
+	for _, certPath := range certPaths {
+		certs, err := corex509.ReadCertificateFile(certPath)
+		if err != nil {
+			continue
+		}
+		storePath := filepath.Dir(certPath)
+		for _, fp := range fingerprints(certs) {
+			key := storePath + string(os.PathSeparator) + fp
+			firstPath, ok := seen[key]
+			if !ok {
+				seen[key] = certPath
+				continue
+			}
+			if firstPath == certPath {
+				continue
+			}
+			if i, ok := index[key]; ok {
+				if !slices.Contains(duplicates[i].Paths, certPath) {
+					duplicates[i].Paths = append(duplicates[i].Paths, certPath)
+				}
+				continue
+			}
+			index[key] = len(duplicates)
+			duplicates = append(duplicates, Duplicate{
+				Fingerprint: fp,
+				Paths:       []string{firstPath, certPath},
+			})
+		}
+	}
+	return duplicates
+}
+
 // ListCerts walks through root and returns all x509 certificates in it,
 // sub-dirs are ignored.
 func ListCerts(root string, depth int) ([]string, error) {
-	maxDepth := strings.Count(root, string(os.PathSeparator)) + depth
+	files, err := ListFiles(root, depth)
+	if err != nil {
+		return nil, err
+	}
 	var certPaths []string
+	for _, path := range files {
+		certs, err := corex509.ReadCertificateFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(certs) != 0 {
+			certPaths = append(certPaths, path)
+		}
+	}
+	return certPaths, nil
+}
+
+// ListFiles walks through root and returns all regular files in it, whether
+// they are valid certificate files or not. Sub-dirs deeper than depth are
+// ignored.
+func ListFiles(root string, depth int) ([]string, error) {
+	maxDepth := strings.Count(root, string(os.PathSeparator)) + depth
+	var paths []string
 	if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -102,43 +327,13 @@ func ListCerts(root string, depth int) ([]string, error) {
 			return err
 		}
 		if info.Mode().IsRegular() {
-			certs, err := corex509.ReadCertificateFile(path)
-			if err != nil {
-				return err
-			}
-			if len(certs) != 0 {
-				certPaths = append(certPaths, path)
-			}
+			paths = append(paths, path)
 		}
 		return nil
 	}); err != nil {
 		return nil, err
 	}
-	return certPaths, nil
-}
-
-// ShowCerts writes out details of certificates
-func ShowCerts(certs []*x509.Certificate) {
-	fmt.Println("Certificate details")
-	fmt.Println("--------------------------------------------------------------------------------")
-	for ind, cert := range certs {
-		showCert(cert)
-		if ind != len(certs)-1 {
-			fmt.Println("--------------------------------------------------------------------------------")
-		}
-	}
-}
-
-// showCert displays details of a certificate
-func showCert(cert *x509.Certificate) {
-	fmt.Println("Issuer:", cert.Issuer)
-	fmt.Println("Subject:", cert.Subject)
-	fmt.Println("Valid from:", cert.NotBefore)
-	fmt.Println("Valid to:", cert.NotAfter)
-	fmt.Println("IsCA:", cert.IsCA)
-
-	h := sha256.Sum256(cert.Raw)
-	fmt.Println("SHA256 Thumbprint:", strings.ToLower(hex.EncodeToString(h[:])))
+	return paths, nil
 }
 
 // DeleteAllCerts deletes all certificate files from the trust store
@@ -191,7 +386,66 @@ func DeleteCert(storeType, namedStore, cert string, confirmed bool) error {
 	}
 
 	// remove the trust store directory if empty
-	storePath := filepath.Dir(path)
+	removeStoreIfEmpty(filepath.Dir(path))
+
+	// write out on success
+	fmt.Printf("Successfully deleted %s from trust store %s of type %s\n", cert, namedStore, storeType)
+	return nil
+}
+
+// DeleteCertByFingerprint deletes the certificate files holding the
+// certificate of SHA-256 fingerprint from the trust store, namely
+// truststore/x509/storeType/namedStore. All duplicates of the certificate are
+// deleted. A certificate file holding other certificates is not deleted.
+func DeleteCertByFingerprint(storeType, namedStore, fingerprint string, confirmed bool) error {
+	fingerprint = strings.ToLower(fingerprint)
+	if !fingerprintRegexp.MatchString(fingerprint) {
+		return fmt.Errorf("invalid SHA-256 fingerprint %q: expecting 64 hexadecimal characters", fingerprint)
+	}
+	storePath, err := dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509", storeType, namedStore)
+	if err != nil {
+		return err
+	}
+	files, err := readStore(storePath)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, file := range files {
+		if !slices.Contains(file.fingerprints, fingerprint) {
+			continue
+		}
+		if len(file.fingerprints) > 1 {
+			return fmt.Errorf("certificate with SHA-256 fingerprint %s is stored in %s with other certificates, delete the file by name instead", fingerprint, file.name)
+		}
+		names = append(names, file.name)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("certificate with SHA-256 fingerprint %s does not exist in trust store %s of type %s", fingerprint, namedStore, storeType)
+	}
+	prompt := fmt.Sprintf("Are you sure you want to delete %s in %q of type %q?", strings.Join(names, ", "), namedStore, storeType)
+	confirmed, err = display.AskForConfirmation(os.Stdin, prompt, confirmed)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+	for _, name := range names {
+		if err := os.Remove(filepath.Join(storePath, name)); err != nil {
+			return err
+		}
+		fmt.Printf("Successfully deleted %s from trust store %s of type %s\n", name, namedStore, storeType)
+	}
+
+	// remove the trust store directory if empty
+	removeStoreIfEmpty(storePath)
+	return nil
+}
+
+// removeStoreIfEmpty removes the trust store directory at storePath if it is
+// empty. Failures are printed as warnings.
+func removeStoreIfEmpty(storePath string) {
 	empty, err := osutil.IsDirEmpty(storePath)
 	if err != nil {
 		// in this case, empty is always false
@@ -202,10 +456,6 @@ func DeleteCert(storeType, namedStore, cert string, confirmed bool) error {
 			fmt.Fprintf(os.Stderr, "Warning: failed to remove the empty trust store directory %s: %v\n", storePath, err)
 		}
 	}
-
-	// write out on success
-	fmt.Printf("Successfully deleted %s from trust store %s of type %s\n", cert, namedStore, storeType)
-	return nil
 }
 
 // CheckNonErrNotExistError returns nil when err is nil or err is fs.ErrNotExist
@@ -226,6 +476,22 @@ func IsValidStoreType(storeType string) bool {
 	return false
 }
 
+// fingerprintRegexp matches a SHA-256 fingerprint in lower case hex.
+var fingerprintRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// invalidFileNameChars matches the characters not allowed in the file names
+// of the trust store.
+var invalidFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// fingerprints returns the SHA-256 fingerprints of certs.
+func fingerprints(certs []*x509.Certificate) []string {
+	fps := make([]string, 0, len(certs))
+	for _, cert := range certs {
+		fps = append(fps, certchain.Fingerprint(cert))
+	}
+	return fps
+}
+
 // IsValidFileName checks if a file name is cross-platform compatible
 func IsValidFileName(fileName string) bool {
 	return regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`).MatchString(fileName)
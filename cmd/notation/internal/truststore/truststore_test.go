@@ -18,10 +18,13 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 
+	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
 )
 
 func TestAddCert(t *testing.T) {
@@ -72,6 +75,29 @@ func TestAddCert(t *testing.T) {
 		}
 	})
 
+	t.Run("cert already exists under another name", func(t *testing.T) {
+		dir.UserConfigDir = "testdata"
+		storeDir := t.TempDir()
+		path := filepath.Join(storeDir, "root.crt")
+		copyFile(t, filepath.FromSlash("testdata/self-signed.crt"), path)
+		expectedErrMsg := "certificate with SHA-256 fingerprint " + fingerprintOf(t, path) + " already exists in the Trust Store as self-signed.crt"
+		err := AddCert(path, "ca", "test", false)
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("different cert with the same file name", func(t *testing.T) {
+		dir.UserConfigDir = "testdata"
+		path := filepath.Join(t.TempDir(), "self-signed.crt")
+		copyFile(t, filepath.FromSlash("testdata/NotationTestRoot.pem"), path)
+		expectedErrMsg := "a different certificate file named self-signed.crt already exists in the Trust Store"
+		err := AddCert(path, "ca", "test", false)
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+	})
+
 	t.Run("empty file", func(t *testing.T) {
 		path := filepath.FromSlash("../../../../internal/testdata/Empty.txt")
 		expectedErr := errors.New("no valid certificate found in the empty file")
@@ -81,6 +107,20 @@ func TestAddCert(t *testing.T) {
 		}
 	})
 
+	t.Run("PKCS #7 file", func(t *testing.T) {
+		dir.UserConfigDir = t.TempDir()
+		if err := AddCert(filepath.FromSlash("testdata/bundle.p7b"), "ca", "test", false); err != nil {
+			t.Fatalf("AddCert() error = %v", err)
+		}
+		certs, err := corex509.ReadCertificateFile(filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "test", "bundle.pem"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(certs) != 3 {
+			t.Fatalf("expected 3 certificates in bundle.pem, but got %d", len(certs))
+		}
+	})
+
 	t.Run("failed to add cert to store", func(t *testing.T) {
 		if runtime.GOOS == "windows" {
 			t.Skip("skipping test on Windows")
@@ -101,6 +141,68 @@ func TestAddCert(t *testing.T) {
 	})
 }
 
+func TestAddSplitCert(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+
+	certs, _, err := ReadCertificateFile(filepath.FromSlash("testdata/bundle.p7c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, cert := range certs {
+		name, err := AddSplitCert(cert, "ca", "test")
+		if err != nil {
+			t.Fatalf("AddSplitCert() error = %v", err)
+		}
+		names = append(names, name)
+	}
+	expected := []string{
+		"LeafCert-" + certchain.Fingerprint(certs[0])[:16] + ".crt",
+		"IntermediateCA-" + certchain.Fingerprint(certs[1])[:16] + ".crt",
+		"RootCA-" + certchain.Fingerprint(certs[2])[:16] + ".crt",
+	}
+	if !slices.Equal(names, expected) {
+		t.Fatalf("expected file names %v, but got %v", expected, names)
+	}
+	for i, name := range names {
+		stored, err := corex509.ReadCertificateFile(filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "test", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stored) != 1 || !stored[0].Equal(certs[i]) {
+			t.Fatalf("expected %s to hold exactly the added certificate", name)
+		}
+	}
+
+	var existsErr *CertExistsError
+	if _, err := AddSplitCert(certs[2], "ca", "test"); !errors.As(err, &existsErr) {
+		t.Fatalf("expected CertExistsError when adding a certificate already in the trust store, but got %v", err)
+	}
+	if err := AddCertAs(certs[2], "ca", "test", "root.crt"); !errors.As(err, &existsErr) || existsErr.FileName != names[2] {
+		t.Fatalf("expected CertExistsError for %s when adding a certificate already in the trust store under another name, but got %v", names[2], err)
+	}
+}
+
+func TestSplitCertFileName(t *testing.T) {
+	certs, _, err := ReadCertificateFile(filepath.FromSlash("testdata/bundle.p7b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := certs[0]
+	cert.Subject.CommonName = "Acme Rockets: Root CA"
+	if got, expected := SplitCertFileName(cert), "Acme_Rockets_Root_CA-"+certchain.Fingerprint(cert)[:16]+".crt"; got != expected {
+		t.Fatalf("expected %s, but got %s", expected, got)
+	}
+	cert.Subject.CommonName = ""
+	cert.Subject.Organization = nil
+	if got, expected := SplitCertFileName(cert), "certificate-"+certchain.Fingerprint(cert)[:16]+".crt"; got != expected {
+		t.Fatalf("expected %s, but got %s", expected, got)
+	}
+}
+
 func TestDeleteAllCerts(t *testing.T) {
 	defer func(oldDir string) {
 		dir.UserConfigDir = oldDir
@@ -119,3 +221,122 @@ func TestDeleteAllCerts(t *testing.T) {
 		}
 	})
 }
+
+func TestFindDuplicates(t *testing.T) {
+	storeDir := filepath.Join(t.TempDir(), "ca", "test")
+	otherStoreDir := filepath.Join(t.TempDir(), "ca", "other")
+	for _, p := range []string{storeDir, otherStoreDir} {
+		if err := os.MkdirAll(p, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	paths := []string{
+		filepath.Join(storeDir, "a.crt"),
+		filepath.Join(storeDir, "b.crt"),
+		filepath.Join(storeDir, "c.pem"),
+		filepath.Join(otherStoreDir, "a.crt"),
+	}
+	copyFile(t, filepath.FromSlash("testdata/self-signed.crt"), paths[0])
+	copyFile(t, filepath.FromSlash("testdata/self-signed.crt"), paths[1])
+	copyFile(t, filepath.FromSlash("testdata/NotationTestRoot.pem"), paths[2])
+	copyFile(t, filepath.FromSlash("testdata/self-signed.crt"), paths[3])
+	invalidPath := filepath.Join(storeDir, "invalid.txt")
+	copyFile(t, filepath.FromSlash("testdata/invalid.txt"), invalidPath)
+
+	duplicates := FindDuplicates(append(paths, invalidPath))
+	if len(duplicates) != 1 {
+		t.Fatalf("expected 1 duplicate, but got: %v", duplicates)
+	}
+	if duplicates[0].Fingerprint != fingerprintOf(t, paths[0]) {
+		t.Fatalf("expected fingerprint %s, but got: %s", fingerprintOf(t, paths[0]), duplicates[0].Fingerprint)
+	}
+	if !slices.Equal(duplicates[0].Paths, paths[:2]) {
+		t.Fatalf("expected paths %v, but got: %v", paths[:2], duplicates[0].Paths)
+	}
+}
+
+func TestDeleteCertByFingerprint(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+
+	t.Run("invalid fingerprint", func(t *testing.T) {
+		expectedErrMsg := `invalid SHA-256 fingerprint "abc": expecting 64 hexadecimal characters`
+		err := DeleteCertByFingerprint("ca", "test", "abc", true)
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("fingerprint not found", func(t *testing.T) {
+		dir.UserConfigDir = "testdata"
+		fp := fingerprintOf(t, filepath.FromSlash("testdata/NotationTestRoot.pem"))
+		expectedErrMsg := "certificate with SHA-256 fingerprint " + fp + " does not exist in trust store test of type ca"
+		err := DeleteCertByFingerprint("ca", "test", fp, true)
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("stored with other certificates", func(t *testing.T) {
+		dir.UserConfigDir = t.TempDir()
+		storeDir := filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "test")
+		if err := os.MkdirAll(storeDir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		first, err := os.ReadFile(filepath.FromSlash("testdata/self-signed.crt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := os.ReadFile(filepath.FromSlash("testdata/NotationTestRoot.pem"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(storeDir, "bundle.pem"), append(first, second...), 0600); err != nil {
+			t.Fatal(err)
+		}
+		fp := fingerprintOf(t, filepath.FromSlash("testdata/self-signed.crt"))
+		expectedErrMsg := "certificate with SHA-256 fingerprint " + fp + " is stored in bundle.pem with other certificates, delete the file by name instead"
+		err = DeleteCertByFingerprint("ca", "test", fp, true)
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("delete all duplicates", func(t *testing.T) {
+		dir.UserConfigDir = t.TempDir()
+		storeDir := filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "test")
+		if err := os.MkdirAll(storeDir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		copyFile(t, filepath.FromSlash("testdata/self-signed.crt"), filepath.Join(storeDir, "a.crt"))
+		copyFile(t, filepath.FromSlash("testdata/self-signed.crt"), filepath.Join(storeDir, "b.crt"))
+		fp := fingerprintOf(t, filepath.FromSlash("testdata/self-signed.crt"))
+		if err := DeleteCertByFingerprint("ca", "test", strings.ToUpper(fp), true); err != nil {
+			t.Fatalf("expected no error, but got: %v", err)
+		}
+		if _, err := os.Stat(storeDir); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected the empty trust store to be removed, but got: %v", err)
+		}
+	})
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+	data, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func fingerprintOf(t *testing.T, path string) string {
+	t.Helper()
+	certs, err := corex509.ReadCertificateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certchain.Fingerprint(certs[0])
+}
@@ -20,14 +20,17 @@ import (
 	"fmt"
 	"io/fs"
 
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
 	"github.com/notaryproject/notation-core-go/revocation/purpose"
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation-go/plugin"
 	"github.com/notaryproject/notation-go/verifier"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
 	"github.com/notaryproject/notation-go/verifier/truststore"
 
+	"github.com/notaryproject/notation/v2/internal/layered"
 	clirev "github.com/notaryproject/notation/v2/internal/revocation"
 )
 
@@ -39,45 +42,71 @@ type Verifier interface {
 
 // GetVerifier creates a Verifier.
 func GetVerifier(ctx context.Context) (Verifier, error) {
-	verifierOptions, err := newVerifierOptions(ctx)
+	// trust policy layered from the system level and the user level
+	// configurations
+	policyDocument, warnings, err := layered.LoadOCIDocument()
 	if err != nil {
 		return nil, err
 	}
+	logWarnings(ctx, warnings)
+	return GetVerifierWithOCITrustPolicy(ctx, policyDocument)
+}
 
-	// trust policy and trust store
-	x509TrustStore := truststore.NewX509TrustStore(dir.ConfigFS())
-	policyDocument, err := trustpolicy.LoadOCIDocument()
+// GetVerifierWithOCITrustPolicy creates a Verifier verifying against
+// policyDocument instead of the OCI trust policy configuration.
+func GetVerifierWithOCITrustPolicy(ctx context.Context, policyDocument *trustpolicy.OCIDocument) (Verifier, error) {
+	verifierOptions, err := newVerifierOptions(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	verifierOptions.OCITrustPolicy = policyDocument
-	return verifier.NewVerifierWithOptions(x509TrustStore, verifierOptions)
+
+	// trust store layered from the system level and the user level
+	// configurations
+	return verifier.NewVerifierWithOptions(layered.NewX509TrustStore(), verifierOptions)
 }
 
 // GetBlobVerifier creates a BlobVerifier.
 func GetBlobVerifier(ctx context.Context) (Verifier, error) {
-	verifierOptions, err := newVerifierOptions(ctx)
+	return GetBlobVerifierWithFallbackCRLs(ctx, nil)
+}
+
+// GetBlobVerifierWithFallbackCRLs creates a BlobVerifier that uses the CRLs in
+// fallbackCRLs, keyed by the CRL distribution point URL, when fetching the
+// CRL fails.
+func GetBlobVerifierWithFallbackCRLs(ctx context.Context, fallbackCRLs map[string]*corecrl.Bundle) (Verifier, error) {
+	verifierOptions, err := newVerifierOptions(ctx, fallbackCRLs)
 	if err != nil {
 		return nil, err
 	}
 
-	// trust policy and trust store
-	x509TrustStore := truststore.NewX509TrustStore(dir.ConfigFS())
-	blobPolicyDocument, err := trustpolicy.LoadBlobDocument()
+	// trust policy and trust store layered from the system level and the
+	// user level configurations
+	x509TrustStore := layered.NewX509TrustStore()
+	blobPolicyDocument, warnings, err := layered.LoadBlobDocument()
 	if err != nil {
 		return nil, err
 	}
+	logWarnings(ctx, warnings)
 	verifierOptions.BlobTrustPolicy = blobPolicyDocument
 	return verifier.NewVerifierWithOptions(x509TrustStore, verifierOptions)
 }
 
+// logWarnings logs the warnings of merging the trust policy configurations.
+func logWarnings(ctx context.Context, warnings []string) {
+	logger := log.GetLogger(ctx)
+	for _, warning := range warnings {
+		logger.Warn(warning)
+	}
+}
+
 // newVerifierOptions creates a verifier.VerifierOptions.
-func newVerifierOptions(ctx context.Context) (verifier.VerifierOptions, error) {
-	revocationCodeSigningValidator, err := clirev.NewRevocationValidator(ctx, purpose.CodeSigning)
+func newVerifierOptions(ctx context.Context, fallbackCRLs map[string]*corecrl.Bundle) (verifier.VerifierOptions, error) {
+	revocationCodeSigningValidator, err := clirev.NewRevocationValidatorWithFallbackCRLs(ctx, purpose.CodeSigning, fallbackCRLs)
 	if err != nil {
 		return verifier.VerifierOptions{}, err
 	}
-	revocationTimestampingValidator, err := clirev.NewRevocationValidator(ctx, purpose.Timestamping)
+	revocationTimestampingValidator, err := clirev.NewRevocationValidatorWithFallbackCRLs(ctx, purpose.Timestamping, fallbackCRLs)
 	if err != nil {
 		return verifier.VerifierOptions{}, err
 	}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	registryutil "github.com/notaryproject/notation/v2/cmd/notation/internal/registry"
 	"github.com/notaryproject/notation/v2/internal/auth"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -114,7 +115,7 @@ func runLogin(ctx context.Context, opts *loginOpts) error {
 	if err != nil {
 		return fmt.Errorf("failed to get credentials store: %v", err)
 	}
-	registry, err := getRegistryLoginClient(ctx, &opts.SecureFlagOpts, serverAddress)
+	registry, err := registryutil.GetRegistryLoginClient(ctx, &opts.SecureFlagOpts, serverAddress)
 	if err != nil {
 		return fmt.Errorf("failed to get registry client: %v", err)
 	}
@@ -21,9 +21,11 @@ import (
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation/v2/cmd/notation/blob"
 	"github.com/notaryproject/notation/v2/cmd/notation/cert"
+	"github.com/notaryproject/notation/v2/cmd/notation/config"
 	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
 	"github.com/notaryproject/notation/v2/cmd/notation/plugin"
 	"github.com/notaryproject/notation/v2/cmd/notation/policy"
+	"github.com/notaryproject/notation/v2/internal/layered"
 	"github.com/spf13/cobra"
 )
 
@@ -43,6 +45,11 @@ func run() error {
 				dir.UserConfigDir = notationConfig
 			}
 
+			// update Notation system level config directory
+			if notationSystemConfig := os.Getenv("NOTATION_SYSTEM_CONFIG"); notationSystemConfig != "" {
+				layered.SystemConfigDir = notationSystemConfig
+			}
+
 			// update Notation cache directory
 			if notationCache := os.Getenv("NOTATION_CACHE"); notationCache != "" {
 				dir.UserCacheDir = notationCache
@@ -61,6 +68,7 @@ func run() error {
 		listCommand(nil),
 		cert.Cmd(),
 		policy.Cmd(),
+		config.Cmd(),
 		keyCommand(),
 		plugin.Cmd(),
 		loginCommand(nil),
@@ -0,0 +1,93 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type addOpts struct {
+	policy.StatementFlagOpts
+	printer        *output.Printer
+	name           string
+	registryScopes []string
+}
+
+func addCmd() *cobra.Command {
+	opts := addOpts{}
+	command := &cobra.Command{
+		Use:   `add [flags] --name <policy_name> --registry-scope <registry_scope> --trust-store "<store_type>:<store_name>" --trusted-identity "<trusted_identity>"`,
+		Short: "Add a statement to the OCI trust policy configuration",
+		Long: `Add a statement to the OCI trust policy configuration. The configuration is created if it does not exist.
+
+Example - Add an OCI trust policy statement with a registry scope, a trust store and a trusted identity:
+  notation policy add --name examplePolicy --registry-scope localhost:5000/net-monitor --trust-store ca:exampleStore --trusted-identity "x509.subject: C=US, ST=WA, O=acme-rockets.io"
+
+Example - Add an OCI trust policy statement with multiple registry scopes:
+  notation policy add --name examplePolicy --registry-scope localhost:5000/net-monitor --registry-scope localhost:5000/net-logger --trust-store ca:exampleStore --trusted-identity "*"
+
+Example - Add an OCI trust policy statement with the permissive verification level, only logging revocation check failures:
+  notation policy add --name examplePolicy --registry-scope localhost:5000/net-monitor --trust-store ca:exampleStore --trusted-identity "*" --verification-level permissive --override revocation=log
+
+Example - Add an OCI trust policy statement skipping the signature verification:
+  notation policy add --name examplePolicy --registry-scope localhost:5000/net-utils --verification-level skip
+`,
+		Args: cobra.ExactArgs(0),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdd(cmd.Flags(), &opts)
+		},
+	}
+
+	command.Flags().StringVarP(&opts.name, "name", "n", "", "name of the OCI trust policy statement")
+	command.Flags().StringArrayVar(&opts.registryScopes, "registry-scope", nil, "registry scope in the format \"<registry>/<repository>\", or \"*\" for all the repositories")
+	opts.StatementFlagOpts.ApplyFlags(command.Flags())
+	command.MarkFlagRequired("name")
+	command.MarkFlagRequired("registry-scope")
+	return command
+}
+
+func runAdd(fs *pflag.FlagSet, opts *addOpts) error {
+	doc, policyPath, err := loadOCIDocument(true)
+	if err != nil {
+		return err
+	}
+	if findOCITrustPolicy(doc, opts.name) >= 0 {
+		return fmt.Errorf("OCI trust policy statement %q already exists. Use \"notation policy update\" to update it", opts.name)
+	}
+
+	statement := trustpolicy.OCITrustPolicy{
+		Name: opts.name,
+		SignatureVerification: trustpolicy.SignatureVerification{
+			VerificationLevel: trustpolicy.LevelStrict.Name,
+		},
+		RegistryScopes: opts.registryScopes,
+	}
+	if err := opts.StatementFlagOpts.Apply(fs, &statement.SignatureVerification, &statement.TrustStores, &statement.TrustedIdentities); err != nil {
+		return err
+	}
+	doc.TrustPolicies = append(doc.TrustPolicies, statement)
+	if err := saveOCIDocument(doc, policyPath, opts.printer); err != nil {
+		return err
+	}
+	return opts.printer.Printf("Successfully added OCI trust policy statement %q to %s.\n", opts.name, policyPath)
+}
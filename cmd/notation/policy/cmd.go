@@ -25,6 +25,15 @@ func Cmd() *cobra.Command {
 	command.AddCommand(
 		showCmd(),
 		importCmd(),
+		initCmd(),
+		addCmd(),
+		updateCmd(),
+		removeCmd(),
+		lintCmd(),
+		testCmd(),
+		pushCmd(),
+		pullCmd(),
+		generateCmd(),
 	)
 
 	return command
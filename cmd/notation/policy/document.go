@@ -0,0 +1,92 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/internal/osutil"
+)
+
+// loadOCIDocument loads the OCI trust policy configuration for editing and
+// returns it with the path it is saved to. The old trust policy file
+// `trustpolicy.json` is loaded if `trustpolicy.oci.json` does not exist. If
+// neither exists and allowNotExist is true, an empty document is returned.
+func loadOCIDocument(allowNotExist bool) (*trustpolicy.OCIDocument, string, error) {
+	policyPath, err := dir.ConfigFS().SysPath(dir.PathOCITrustPolicy)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to obtain path of OCI trust policy configuration: %w", err)
+	}
+	if allowNotExist {
+		oldPolicyPath, err := dir.ConfigFS().SysPath(dir.PathTrustPolicy)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to obtain path of OCI trust policy configuration: %w", err)
+		}
+		if !fileExists(policyPath) && !fileExists(oldPolicyPath) {
+			return &trustpolicy.OCIDocument{Version: "1.0"}, policyPath, nil
+		}
+	}
+	doc, err := trustpolicy.LoadOCIDocument()
+	if err != nil {
+		return nil, "", err
+	}
+	return doc, policyPath, nil
+}
+
+// saveOCIDocument validates doc and writes it to policyPath atomically. The
+// old trust policy file `trustpolicy.json` is deleted afterwards, as its
+// content is superseded by doc.
+func saveOCIDocument(doc *trustpolicy.OCIDocument, policyPath string, printer *output.Printer) error {
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("invalid OCI trust policy configuration: %w", err)
+	}
+	policyJSON, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal OCI trust policy: %w", err)
+	}
+	if err := osutil.WriteFileAtomic(policyPath, policyJSON); err != nil {
+		return fmt.Errorf("failed to write OCI trust policy configuration: %w", err)
+	}
+	if err := deleteOldTrustPolicyFile(); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			printer.PrintErrorf("Warning: failed to delete old trust policy configuration trustpolicy.json: %s\n", err)
+		}
+		return nil
+	}
+	return printer.Printf("Deleted old trust policy configuration trustpolicy.json.\n")
+}
+
+// findOCITrustPolicy returns the index of the statement named name in doc, or
+// -1 if not found.
+func findOCITrustPolicy(doc *trustpolicy.OCIDocument, name string) int {
+	for i, statement := range doc.TrustPolicies {
+		if statement.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// fileExists reports whether a file exists at path.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
@@ -0,0 +1,260 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/certchain"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/v2/internal/osutil"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry"
+)
+
+type generateOpts struct {
+	flag.LoggingFlagOpts
+	flag.SecureFlagOpts
+	reference     string
+	signaturePath string
+	name          string
+	storeName     string
+	outputDir     string
+	importRoot    bool
+	force         bool
+	maxSignatures int
+}
+
+func generateCmd() *cobra.Command {
+	opts := generateOpts{}
+	command := &cobra.Command{
+		Use:   "generate [flags] (--from <reference> | --from-signature <signature_path>)",
+		Short: "Generate a trust policy statement and trust stores from the signatures of a signed artifact",
+		Long: `Generate a trust policy statement and trust stores from the signatures of a signed artifact.
+
+The signing certificate chains are extracted from the signatures without verifying them. A trust store is proposed with the root certificates of the chains, and a statement is proposed trusting the subjects of the signing certificates. The roots carried by timestamp countersignatures are proposed for a "tsa" trust store. With "--from", an OCI trust policy statement scoped to the repository of the artifact is generated. With "--from-signature", a blob trust policy statement is generated.
+
+The generated configuration is printed for review. Use "--output-dir" to write it in the layout of the Notation configuration directory, and "--import-root" to add the root certificates to the trust stores.
+
+Example - Generate an OCI trust policy statement from the signatures of an artifact:
+  notation policy generate --from <registry>/<repository>:<tag>
+
+Example - Generate an OCI trust policy statement named "acme-rockets" and write it with the root certificates to a directory for review:
+  notation policy generate --from <registry>/<repository>@<digest> --name acme-rockets --output-dir ./generated
+
+Example - Generate a blob trust policy statement from a blob signature and add the root certificate to the trust store "acme-rockets":
+  notation policy generate --from-signature blob.jws.sig --store acme-rockets --import-root
+`,
+		Args: cobra.ExactArgs(0),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.maxSignatures <= 0 {
+				return fmt.Errorf("max-signatures value %d must be a positive number", opts.maxSignatures)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerate(cmd, &opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.SecureFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().StringVar(&opts.reference, "from", "", "reference of the signed artifact in the format of <registry>/<repository>:<tag> or <registry>/<repository>@<digest>")
+	command.Flags().StringVar(&opts.signaturePath, "from-signature", "", "path of the signature file or the signature bundle of a signed blob")
+	command.Flags().StringVarP(&opts.name, "name", "n", "", "name of the generated statement. Defaults to the last component of the repository, or the file name of the signature up to the first \".\"")
+	command.Flags().StringVar(&opts.storeName, "store", "", "name of the proposed trust stores. Defaults to the name of the statement")
+	command.Flags().StringVar(&opts.outputDir, "output-dir", "", "directory to write the generated trust policy configuration and the root certificates to, in the layout of the Notation configuration directory")
+	command.Flags().BoolVar(&opts.importRoot, "import-root", false, "add the root certificates to the proposed trust stores")
+	command.Flags().BoolVar(&opts.force, "force", false, "override the existing files in the output directory")
+	command.Flags().IntVar(&opts.maxSignatures, "max-signatures", 100, "maximum number of signatures to examine")
+	command.MarkFlagsOneRequired("from", "from-signature")
+	command.MarkFlagsMutuallyExclusive("from", "from-signature")
+	return command
+}
+
+func runGenerate(command *cobra.Command, opts *generateOpts) error {
+	// set log level
+	ctx := opts.LoggingFlagOpts.InitializeLogger(command.Context())
+
+	// collect signatures
+	var signatures []*certchain.Signature
+	var registryScope string
+	name := opts.name
+	if opts.reference != "" {
+		var ref registry.Reference
+		var err error
+		signatures, ref, err = certchain.FetchReference(ctx, &opts.SecureFlagOpts, opts.reference, opts.maxSignatures)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Fetched %d signature(s) of %s\n", len(signatures), ref)
+		registryScope = ref.Registry + "/" + ref.Repository
+		if name == "" {
+			name = path.Base(registryScope)
+		}
+	} else {
+		sig, err := certchain.ReadFile(opts.signaturePath)
+		if err != nil {
+			return err
+		}
+		signatures = []*certchain.Signature{sig}
+		fmt.Printf("Read the signature %s\n", opts.signaturePath)
+		if name == "" {
+			name, _, _ = strings.Cut(filepath.Base(opts.signaturePath), ".")
+		}
+	}
+	storeName := opts.storeName
+	if storeName == "" {
+		storeName = name
+	}
+	if !truststore.IsValidFileName(storeName) {
+		return fmt.Errorf("invalid trust store name %q: trust store name needs to follow [a-zA-Z0-9_.-]+ format. Use \"--store\" to specify a valid trust store name", storeName)
+	}
+	proposal, err := policy.Propose(signatures, storeName)
+	if err != nil {
+		return err
+	}
+	for _, warning := range proposal.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	// generate trust policy configuration
+	signatureVerification := trustpolicy.SignatureVerification{
+		VerificationLevel: trustpolicy.LevelStrict.Name,
+	}
+	var doc interface{ Validate() error }
+	var policyPath, policyKind string
+	if registryScope != "" {
+		doc = &trustpolicy.OCIDocument{
+			Version: "1.0",
+			TrustPolicies: []trustpolicy.OCITrustPolicy{{
+				Name:                  name,
+				RegistryScopes:        []string{registryScope},
+				SignatureVerification: signatureVerification,
+				TrustStores:           proposal.TrustStores,
+				TrustedIdentities:     proposal.TrustedIdentities,
+			}},
+		}
+		policyPath, policyKind = dir.PathOCITrustPolicy, "OCI"
+	} else {
+		doc = &trustpolicy.BlobDocument{
+			Version: "1.0",
+			TrustPolicies: []trustpolicy.BlobTrustPolicy{{
+				Name:                  name,
+				SignatureVerification: signatureVerification,
+				TrustStores:           proposal.TrustStores,
+				TrustedIdentities:     proposal.TrustedIdentities,
+			}},
+		}
+		policyPath, policyKind = dir.PathBlobTrustPolicy, "blob"
+	}
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("failed to generate %s trust policy configuration: %w", policyKind, err)
+	}
+	policyJSON, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s trust policy: %w", policyKind, err)
+	}
+
+	// print the proposal for review
+	for _, trustStore := range proposal.TrustStores {
+		fmt.Printf("Proposed trust store %s with root certificates:\n", trustStore)
+		for _, cert := range proposal.Certificates[trustStore] {
+			fmt.Printf("  %s (SHA-256 fingerprint: %s)\n", cert.Subject, certchain.Fingerprint(cert))
+		}
+	}
+	fmt.Printf("Generated %s trust policy configuration:\n%s\n", policyKind, policyJSON)
+
+	// write out
+	if opts.outputDir != "" {
+		if err := writeGenerated(opts.outputDir, policyPath, policyJSON, proposal, opts.force); err != nil {
+			return err
+		}
+	}
+	if opts.importRoot {
+		if err := importRoots(proposal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeGenerated writes the generated trust policy configuration and the
+// root certificates of proposal to outputDir, in the layout of the Notation
+// configuration directory.
+func writeGenerated(outputDir, policyPath string, policyJSON []byte, proposal *policy.Proposal, force bool) error {
+	write := func(relPath string, content []byte) error {
+		filePath := filepath.Join(outputDir, filepath.FromSlash(relPath))
+		if err := osutil.WriteFileWithPermission(filePath, content, 0600, force); err != nil {
+			if errors.Is(err, os.ErrExist) {
+				return fmt.Errorf("%s already exists, use --force to override it", filePath)
+			}
+			return fmt.Errorf("failed to write %s: %w", filePath, err)
+		}
+		fmt.Printf("Wrote %s\n", filePath)
+		return nil
+	}
+	if err := write(policyPath, policyJSON); err != nil {
+		return err
+	}
+	for _, trustStore := range proposal.TrustStores {
+		storeType, storeName, _ := strings.Cut(trustStore, ":")
+		for _, cert := range proposal.Certificates[trustStore] {
+			certPath := path.Join(dir.X509TrustStoreDir(storeType, storeName), rootCertFileName(cert))
+			if err := write(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// importRoots adds the root certificates of proposal to the trust stores in
+// the user level configuration directory. Certificates already in the trust
+// stores are skipped.
+func importRoots(proposal *policy.Proposal) error {
+	for _, trustStore := range proposal.TrustStores {
+		storeType, storeName, _ := strings.Cut(trustStore, ":")
+		for _, cert := range proposal.Certificates[trustStore] {
+			err := truststore.AddCertAs(cert, storeType, storeName, rootCertFileName(cert))
+			var existsErr *truststore.CertExistsError
+			if errors.As(err, &existsErr) {
+				fmt.Printf("Root certificate %s already exists in trust store %s\n", certchain.Fingerprint(cert), trustStore)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to add root certificate to trust store %s: %w", trustStore, err)
+			}
+			fmt.Printf("Added root certificate %s to trust store %s\n", certchain.Fingerprint(cert), trustStore)
+		}
+	}
+	return nil
+}
+
+// rootCertFileName returns the file name of a root certificate, which is
+// its SHA-256 fingerprint.
+func rootCertFileName(cert *x509.Certificate) string {
+	return certchain.Fingerprint(cert) + ".crt"
+}
@@ -0,0 +1,114 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type initOpts struct {
+	policy.StatementFlagOpts
+	printer        *output.Printer
+	name           string
+	registryScopes []string
+	force          bool
+}
+
+func initCmd() *cobra.Command {
+	opts := initOpts{}
+	command := &cobra.Command{
+		Use:   `init [flags] --name <policy_name> --registry-scope <registry_scope> --trust-store "<store_type>:<store_name>" --trusted-identity "<trusted_identity>"`,
+		Short: "Initialize OCI trust policy configuration",
+		Long: `Initialize OCI trust policy configuration with a single statement.
+
+Example - init an OCI trust policy configuration with a registry scope, a trust store and a trusted identity:
+  notation policy init --name examplePolicy --registry-scope localhost:5000/net-monitor --trust-store ca:exampleStore --trusted-identity "x509.subject: C=US, ST=WA, O=acme-rockets.io"
+
+Example - init an OCI trust policy configuration applying to all the repositories with any trusted identity:
+  notation policy init --name examplePolicy --registry-scope "*" --trust-store ca:exampleStore --trusted-identity "*"
+
+Example - init an OCI trust policy configuration with the permissive verification level, only logging revocation check failures:
+  notation policy init --name examplePolicy --registry-scope "*" --trust-store ca:exampleStore --trusted-identity "*" --verification-level permissive --override revocation=log
+
+Example - init an OCI trust policy configuration without prompt:
+  notation policy init --name examplePolicy --registry-scope "*" --trust-store ca:exampleStore --trusted-identity "*" --force
+`,
+		Args: cobra.ExactArgs(0),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInit(cmd.Flags(), &opts)
+		},
+	}
+
+	command.Flags().StringVarP(&opts.name, "name", "n", "", "name of the OCI trust policy statement")
+	command.Flags().StringArrayVar(&opts.registryScopes, "registry-scope", nil, "registry scope in the format \"<registry>/<repository>\", or \"*\" for all the repositories")
+	command.Flags().BoolVar(&opts.force, "force", false, "override the existing OCI trust policy configuration, never prompt (default --force=false)")
+	opts.StatementFlagOpts.ApplyFlags(command.Flags())
+	command.MarkFlagRequired("name")
+	command.MarkFlagRequired("registry-scope")
+	return command
+}
+
+func runInit(fs *pflag.FlagSet, opts *initOpts) error {
+	doc := &trustpolicy.OCIDocument{Version: "1.0"}
+	statement := trustpolicy.OCITrustPolicy{
+		Name: opts.name,
+		SignatureVerification: trustpolicy.SignatureVerification{
+			VerificationLevel: trustpolicy.LevelStrict.Name,
+		},
+		RegistryScopes: opts.registryScopes,
+	}
+	if err := opts.StatementFlagOpts.Apply(fs, &statement.SignatureVerification, &statement.TrustStores, &statement.TrustedIdentities); err != nil {
+		return err
+	}
+	doc.TrustPolicies = []trustpolicy.OCITrustPolicy{statement}
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("invalid OCI trust policy configuration: %w", err)
+	}
+
+	// optional confirmation
+	if _, err := trustpolicy.LoadOCIDocument(); err == nil {
+		if !opts.force {
+			confirmed, err := display.AskForConfirmation(os.Stdin, "The OCI trust policy configuration already exists, do you want to overwrite it?", opts.force)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return nil
+			}
+		} else {
+			opts.printer.PrintErrorf("Warning: existing OCI trust policy configuration will be overwritten\n")
+		}
+	}
+
+	policyPath, err := dir.ConfigFS().SysPath(dir.PathOCITrustPolicy)
+	if err != nil {
+		return fmt.Errorf("failed to obtain path of OCI trust policy configuration: %w", err)
+	}
+	if err := saveOCIDocument(doc, policyPath, opts.printer); err != nil {
+		return err
+	}
+	return opts.printer.Printf("Successfully initialized OCI trust policy file to %s.\n", policyPath)
+}
@@ -0,0 +1,224 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/dir"
+	notationregistry "github.com/notaryproject/notation-go/registry"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	registryutil "github.com/notaryproject/notation/v2/cmd/notation/internal/registry"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/verify"
+	"github.com/notaryproject/notation/v2/internal/layered"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry"
+)
+
+// maxSignatureAttempts is the maximum number of signatures of the trust
+// configuration verified by `notation policy pull`.
+const maxSignatureAttempts = 100
+
+type pullOpts struct {
+	flag.LoggingFlagOpts
+	flag.SecureFlagOpts
+	reference       string
+	bootstrapPolicy string
+	system          bool
+	force           bool
+}
+
+func pullCmd() *cobra.Command {
+	opts := pullOpts{}
+	command := &cobra.Command{
+		Use:   "pull [flags] <reference>",
+		Short: "Pull, verify and install the trust policy configurations and trust stores from a registry",
+		Long: `Pull, verify and install the trust policy configurations and trust stores pushed by "notation policy push".
+
+The signature of the artifact is verified against the bootstrap OCI trust policy, which is the current effective OCI trust policy configuration unless "--bootstrap-policy" is specified. The trust stores used by the bootstrap OCI trust policy must exist. The pulled trust policy configurations replace the existing ones, and the pulled trust stores replace the existing trust stores of the same type and name. If installing fails, the existing configuration is restored.
+
+Example - Pull and install the trust configuration:
+  notation policy pull <registry>/<repository>:<tag>
+
+Example - Pull the trust configuration on a new host, verifying it against a bootstrap OCI trust policy:
+  notation policy pull --bootstrap-policy bootstrap_policy.json <registry>/<repository>:<tag>
+
+Example - Pull and install the trust configuration into the system level configuration directory without prompt:
+  notation policy pull --system --force <registry>/<repository>:<tag>
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing reference: use `notation policy pull --help` to see what parameters are required")
+			}
+			opts.reference = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPull(cmd, &opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.SecureFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().StringVar(&opts.bootstrapPolicy, "bootstrap-policy", "", "path of the OCI trust policy configuration to verify the artifact against. If not provided, the effective OCI trust policy configuration is used")
+	command.Flags().BoolVar(&opts.system, "system", false, "install into the system level configuration directory")
+	command.Flags().BoolVar(&opts.force, "force", false, "override the existing configuration without prompt")
+	return command
+}
+
+func runPull(command *cobra.Command, opts *pullOpts) error {
+	// set log level
+	ctx := opts.LoggingFlagOpts.InitializeLogger(command.Context())
+
+	ref, err := registry.ParseReference(opts.reference)
+	if err != nil {
+		return fmt.Errorf("%q: %w. Expecting <registry>/<repository>:<tag> or <registry>/<repository>@<digest>", opts.reference, err)
+	}
+	if ref.Reference == "" {
+		return fmt.Errorf("%q: invalid reference: no tag or digest. Expecting <registry>/<repository>:<tag> or <registry>/<repository>@<digest>", opts.reference)
+	}
+	bootstrapDoc, err := loadBootstrapPolicy(opts.bootstrapPolicy)
+	if err != nil {
+		return err
+	}
+
+	// resolve and verify
+	repo, err := registryutil.GetRepositoryClient(ctx, &opts.SecureFlagOpts, ref)
+	if err != nil {
+		return err
+	}
+	manifestDesc, err := repo.Resolve(ctx, ref.Reference)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.reference, err)
+	}
+	resolvedRef := ref.Registry + "/" + ref.Repository + "@" + manifestDesc.Digest.String()
+	sigVerifier, err := verify.GetVerifierWithOCITrustPolicy(ctx, bootstrapDoc)
+	if err != nil {
+		return err
+	}
+	_, outcomes, err := notation.Verify(ctx, sigVerifier, notationregistry.NewRepository(repo), notation.VerifyOptions{
+		ArtifactReference:    resolvedRef,
+		MaxSignatureAttempts: maxSignatureAttempts,
+	})
+	if err := verify.ComposeVerificationFailurePrintout(outcomes, resolvedRef, err); err != nil {
+		return err
+	}
+	if err := checkBootstrapOutcome(outcomes[0], resolvedRef); err != nil {
+		return err
+	}
+	fmt.Printf("Successfully verified signature for %s\n", resolvedRef)
+
+	// fetch and validate
+	files, err := fetchTrustConfig(ctx, repo, manifestDesc)
+	if err != nil {
+		return err
+	}
+	if err := policy.ValidateBundle(files); err != nil {
+		return err
+	}
+
+	// optional confirmation
+	root, err := configRoot(opts.system)
+	if err != nil {
+		return err
+	}
+	var existing []string
+	for _, installedPath := range policy.InstalledPaths(files) {
+		if _, err := os.Lstat(filepath.Join(root, filepath.FromSlash(installedPath))); err == nil {
+			existing = append(existing, installedPath)
+		}
+	}
+	if len(existing) > 0 {
+		if !opts.force {
+			confirmed, err := display.AskForConfirmation(os.Stdin, fmt.Sprintf("The following configurations in %s will be overwritten: %s. Do you want to continue?", root, strings.Join(existing, ", ")), opts.force)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return nil
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: existing configurations %s will be overwritten\n", strings.Join(existing, ", "))
+		}
+	}
+
+	// install
+	if err := policy.InstallBundle(root, files); err != nil {
+		return fmt.Errorf("failed to install the trust configuration: %w", err)
+	}
+	for _, installedPath := range policy.InstalledPaths(files) {
+		fmt.Printf("Installed %s\n", installedPath)
+	}
+	fmt.Printf("Successfully installed the trust configuration to %s\n", root)
+	return nil
+}
+
+// loadBootstrapPolicy loads the OCI trust policy configuration at path, or
+// the effective OCI trust policy configuration if path is empty.
+func loadBootstrapPolicy(path string) (*trustpolicy.OCIDocument, error) {
+	if path == "" {
+		doc, _, err := layered.LoadOCIDocument()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the bootstrap OCI trust policy: %w. Use \"--bootstrap-policy\" to specify one", err)
+		}
+		return doc, nil
+	}
+	policyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the bootstrap OCI trust policy: %w", err)
+	}
+	var doc trustpolicy.OCIDocument
+	if err := json.Unmarshal(policyJSON, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the bootstrap OCI trust policy: %w", err)
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid bootstrap OCI trust policy: %w", err)
+	}
+	return &doc, nil
+}
+
+// configRoot returns the system level configuration directory if system is
+// true, or the user level configuration directory otherwise.
+func configRoot(system bool) (string, error) {
+	if system {
+		return layered.SystemConfigFS().SysPath("")
+	}
+	return dir.ConfigFS().SysPath("")
+}
+
+// checkBootstrapOutcome ensures the signature of the trust configuration is
+// fully verified. Non-strict verification levels only log or skip failed
+// validations, so any failed validation is rejected here.
+func checkBootstrapOutcome(outcome *notation.VerificationOutcome, ref string) error {
+	if outcome.VerificationLevel.Name == trustpolicy.LevelSkip.Name {
+		return fmt.Errorf("signature verification of %s is skipped by the bootstrap OCI trust policy, refusing to install an unverified trust configuration", ref)
+	}
+	if outcome.VerificationLevel.Name == trustpolicy.LevelStrict.Name {
+		return nil
+	}
+	for _, result := range outcome.VerificationResults {
+		if result.Action != trustpolicy.ActionSkip && result.Error != nil {
+			return fmt.Errorf("%s validation of %s failed under the %q bootstrap OCI trust policy level, refusing to install an unverified trust configuration: %w", result.Type, ref, outcome.VerificationLevel.Name, result.Error)
+		}
+	}
+	return nil
+}
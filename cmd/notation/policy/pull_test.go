@@ -0,0 +1,98 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+)
+
+func TestCheckBootstrapOutcome(t *testing.T) {
+	authenticityErr := errors.New("signature is not produced by a trusted signer")
+	tests := []struct {
+		name    string
+		level   *trustpolicy.VerificationLevel
+		results []*notation.ValidationResult
+		wantErr string
+	}{
+		{
+			name:  "strict",
+			level: trustpolicy.LevelStrict,
+			results: []*notation.ValidationResult{
+				{Type: trustpolicy.TypeIntegrity, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeAuthenticity, Action: trustpolicy.ActionEnforce},
+			},
+		},
+		{
+			name:    "skip",
+			level:   trustpolicy.LevelSkip,
+			wantErr: "skipped by the bootstrap OCI trust policy",
+		},
+		{
+			name:  "audit with untrusted signer",
+			level: trustpolicy.LevelAudit,
+			results: []*notation.ValidationResult{
+				{Type: trustpolicy.TypeIntegrity, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeAuthenticity, Action: trustpolicy.ActionLog, Error: authenticityErr},
+			},
+			wantErr: "authenticity validation of localhost:5000/config@sha256:abc failed",
+		},
+		{
+			name:  "audit with all validations passed",
+			level: trustpolicy.LevelAudit,
+			results: []*notation.ValidationResult{
+				{Type: trustpolicy.TypeIntegrity, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeAuthenticity, Action: trustpolicy.ActionLog},
+				{Type: trustpolicy.TypeExpiry, Action: trustpolicy.ActionLog},
+			},
+		},
+		{
+			name:  "permissive with expired signature",
+			level: trustpolicy.LevelPermissive,
+			results: []*notation.ValidationResult{
+				{Type: trustpolicy.TypeExpiry, Action: trustpolicy.ActionLog, Error: errors.New("expired")},
+			},
+			wantErr: "expiry validation",
+		},
+		{
+			name:  "skipped validation error ignored",
+			level: trustpolicy.LevelAudit,
+			results: []*notation.ValidationResult{
+				{Type: trustpolicy.TypeRevocation, Action: trustpolicy.ActionSkip, Error: errors.New("revoked")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome := &notation.VerificationOutcome{
+				VerificationLevel:   tt.level,
+				VerificationResults: tt.results,
+			}
+			err := checkBootstrapOutcome(outcome, "localhost:5000/config@sha256:abc")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/dir"
+	notationregistry "github.com/notaryproject/notation-go/registry"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	registryutil "github.com/notaryproject/notation/v2/cmd/notation/internal/registry"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/sign"
+	"github.com/notaryproject/notation/v2/internal/envelope"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry"
+)
+
+type pushOpts struct {
+	flag.LoggingFlagOpts
+	flag.SignerFlagOpts
+	flag.SecureFlagOpts
+	reference    string
+	trustStores  []string
+	expiry       time.Duration
+	pluginConfig []string
+}
+
+func pushCmd() *cobra.Command {
+	opts := pushOpts{}
+	command := &cobra.Command{
+		Use:   "push [flags] <reference>",
+		Short: "Push the trust policy configurations and trust stores to a registry as a signed artifact",
+		Long: `Push the trust policy configurations and trust stores to a registry as a signed artifact.
+
+The OCI trust policy configuration, the blob trust policy configuration and the trust stores specified by "--trust-store" are packaged into an OCI artifact, which is pushed to the registry and signed with the signing key. Use "notation policy pull" to install the artifact on other hosts.
+
+Example - Push the trust policy configurations and sign the artifact with the default signing key:
+  notation policy push <registry>/<repository>:<tag>
+
+Example - Push the trust policy configurations with the trust stores "ca:acme-rockets" and "tsa:acme-tsa":
+  notation policy push --trust-store ca:acme-rockets --trust-store tsa:acme-tsa <registry>/<repository>:<tag>
+
+Example - Push the trust policy configurations and sign the artifact with a specified key:
+  notation policy push --key <key_name> <registry>/<repository>:<tag>
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing reference: use `notation policy push --help` to see what parameters are required")
+			}
+			opts.reference = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPush(cmd, &opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.SignerFlagOpts.ApplyFlagsToCommand(command)
+	opts.SecureFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().StringArrayVar(&opts.trustStores, "trust-store", nil, "trust store to push in the format of \"<store_type>:<store_name>\", can be used multiple times")
+	flag.SetPflagExpiry(command.Flags(), &opts.expiry)
+	flag.SetPflagPluginConfig(command.Flags(), &opts.pluginConfig)
+	return command
+}
+
+func runPush(command *cobra.Command, opts *pushOpts) error {
+	// set log level
+	ctx := opts.LoggingFlagOpts.InitializeLogger(command.Context())
+
+	ref, err := registry.ParseReference(opts.reference)
+	if err != nil {
+		return fmt.Errorf("%q: %w. Expecting <registry>/<repository>[:<tag>]", opts.reference, err)
+	}
+	if _, err := ref.Digest(); err == nil {
+		return fmt.Errorf("%q: pushing to a digest is not supported. Expecting <registry>/<repository>[:<tag>]", opts.reference)
+	}
+	files, err := policy.CollectBundle(dir.ConfigFS(), opts.trustStores)
+	if err != nil {
+		return err
+	}
+
+	// prepare signing
+	signer, err := sign.GetSigner(ctx, &opts.SignerFlagOpts)
+	if err != nil {
+		return err
+	}
+	mediaType, err := envelope.GetEnvelopeMediaType(opts.SignerFlagOpts.SignatureFormat)
+	if err != nil {
+		return err
+	}
+	pluginConfig, err := flag.ParseFlagMap(opts.pluginConfig, flag.PflagPluginConfig.Name)
+	if err != nil {
+		return err
+	}
+
+	// push and sign
+	repo, err := registryutil.GetRepositoryClient(ctx, &opts.SecureFlagOpts, ref)
+	if err != nil {
+		return err
+	}
+	manifestDesc, err := pushTrustConfig(ctx, repo, files)
+	if err != nil {
+		return err
+	}
+	repositoryRef := ref.Registry + "/" + ref.Repository
+	fmt.Printf("Pushed the trust configuration to %s@%s\n", repositoryRef, manifestDesc.Digest)
+	for _, file := range files {
+		fmt.Printf("  %s\n", file.Path)
+	}
+	_, sigManifestDesc, err := notation.SignOCI(ctx, signer, notationregistry.NewRepository(repo), notation.SignOptions{
+		SignerSignOptions: notation.SignerSignOptions{
+			SignatureMediaType: mediaType,
+			ExpiryDuration:     opts.expiry,
+			PluginConfig:       pluginConfig,
+		},
+		ArtifactReference: manifestDesc.Digest.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sign the trust configuration: %w", err)
+	}
+	fmt.Printf("Successfully signed %s@%s\n", repositoryRef, manifestDesc.Digest)
+	fmt.Printf("Pushed the signature to %s@%s\n", repositoryRef, sigManifestDesc.Digest)
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	registryutil "github.com/notaryproject/notation/v2/cmd/notation/internal/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// maxManifestSize is the maximum size in bytes of the trust configuration
+// manifest fetched by `notation policy pull`.
+const maxManifestSize = 4 << 20
+
+// pushTrustConfig pushes files as a trust configuration artifact to repo and
+// tags the artifact if the reference of repo has a tag.
+func pushTrustConfig(ctx context.Context, repo *remote.Repository, files []policy.BundleFile) (ocispec.Descriptor, error) {
+	layers := make([]ocispec.Descriptor, 0, len(files))
+	for _, file := range files {
+		desc := content.NewDescriptorFromBytes(file.MediaType, file.Content)
+		desc.Annotations = map[string]string{
+			ocispec.AnnotationTitle: file.Path,
+		}
+		if err := registryutil.PushIfNotExist(ctx, repo, desc, file.Content); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to push %s: %w", file.Path, err)
+		}
+		layers = append(layers, desc)
+	}
+	manifestDesc, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, policy.ArtifactTypeTrustConfig, oras.PackManifestOptions{
+		Layers: layers,
+	})
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to push the trust configuration manifest: %w", err)
+	}
+	if tag := repo.Reference.Reference; tag != "" {
+		if err := repo.Tag(ctx, manifestDesc, tag); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to tag the trust configuration: %w", err)
+		}
+	}
+	return manifestDesc, nil
+}
+
+// fetchTrustConfig fetches the files of the trust configuration artifact
+// described by manifestDesc from repo.
+func fetchTrustConfig(ctx context.Context, repo *remote.Repository, manifestDesc ocispec.Descriptor) ([]policy.BundleFile, error) {
+	if manifestDesc.MediaType != ocispec.MediaTypeImageManifest {
+		return nil, fmt.Errorf("%s is not a trust configuration: unsupported manifest media type %q", manifestDesc.Digest, manifestDesc.MediaType)
+	}
+	if manifestDesc.Size > maxManifestSize {
+		return nil, fmt.Errorf("the manifest of the trust configuration exceeds the maximum size of %d bytes", maxManifestSize)
+	}
+	manifestJSON, err := content.FetchAll(ctx, repo, manifestDesc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the trust configuration manifest: %w", err)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse the trust configuration manifest: %w", err)
+	}
+	if manifest.ArtifactType != policy.ArtifactTypeTrustConfig {
+		return nil, fmt.Errorf("%s is not a trust configuration: unexpected artifact type %q", manifestDesc.Digest, manifest.ArtifactType)
+	}
+
+	files := make([]policy.BundleFile, 0, len(manifest.Layers))
+	for _, layer := range manifest.Layers {
+		filePath := layer.Annotations[ocispec.AnnotationTitle]
+		if filePath == "" {
+			return nil, fmt.Errorf("layer %s of the trust configuration has no title", layer.Digest)
+		}
+		if layer.Size > policy.MaxBundleFileSize {
+			return nil, fmt.Errorf("file %s in the trust configuration exceeds the maximum size of %d bytes", filePath, policy.MaxBundleFileSize)
+		}
+		data, err := content.FetchAll(ctx, repo, layer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", filePath, err)
+		}
+		files = append(files, policy.BundleFile{
+			Path:      filePath,
+			MediaType: layer.MediaType,
+			Content:   data,
+		})
+	}
+	return files, nil
+}
@@ -0,0 +1,75 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	registryutil "github.com/notaryproject/notation/v2/cmd/notation/internal/registry"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/registry/registrytest"
+	"oras.land/oras-go/v2/registry"
+)
+
+func TestPushAndFetchTrustConfig(t *testing.T) {
+	ctx := context.Background()
+	reg := registrytest.New(t)
+	ref, err := registry.ParseReference(reg.Host + "/trust/config:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := registryutil.GetRepositoryClient(ctx, &flag.SecureFlagOpts{InsecureRegistry: true}, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []policy.BundleFile{
+		{Path: dir.PathOCITrustPolicy, MediaType: policy.MediaTypeOCITrustPolicy, Content: []byte(`{"version":"1.0"}`)},
+		{Path: dir.PathBlobTrustPolicy, MediaType: policy.MediaTypeBlobTrustPolicy, Content: []byte(`{"version":"1.0"}`)},
+	}
+	manifestDesc, err := pushTrustConfig(ctx, repo, files)
+	if err != nil {
+		t.Fatalf("pushTrustConfig() error = %v", err)
+	}
+
+	resolved, err := repo.Resolve(ctx, "v1")
+	if err != nil {
+		t.Fatalf("failed to resolve the tag: %v", err)
+	}
+	if resolved.Digest != manifestDesc.Digest {
+		t.Fatalf("tag resolves to %s, want %s", resolved.Digest, manifestDesc.Digest)
+	}
+	fetched, err := fetchTrustConfig(ctx, repo, resolved)
+	if err != nil {
+		t.Fatalf("fetchTrustConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(fetched, files) {
+		t.Fatalf("fetchTrustConfig() = %v, want %v", fetched, files)
+	}
+
+	// a manifest of another artifact type is rejected
+	other := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","artifactType":"application/vnd.unknown.artifact.v1","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[]}`)
+	reg.SetManifest("other", "application/vnd.oci.image.manifest.v1+json", other)
+	otherDesc, err := repo.Resolve(ctx, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fetchTrustConfig(ctx, repo, otherDesc); err == nil || !strings.Contains(err.Error(), "unexpected artifact type") {
+		t.Fatalf("fetchTrustConfig() error = %v, want unexpected artifact type", err)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/spf13/cobra"
+)
+
+type removeOpts struct {
+	printer   *output.Printer
+	name      string
+	confirmed bool
+}
+
+func removeCmd() *cobra.Command {
+	opts := removeOpts{}
+	command := &cobra.Command{
+		Use:   "remove [flags] <policy_name>",
+		Short: "Remove a statement from the OCI trust policy configuration",
+		Long: `Remove a statement from the OCI trust policy configuration.
+
+Example - Remove an OCI trust policy statement:
+  notation policy remove examplePolicy
+
+Example - Remove an OCI trust policy statement without prompt for confirmation:
+  notation policy remove --yes examplePolicy
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 || args[0] == "" {
+				return errors.New("missing policy name: use `notation policy remove --help` to see what parameters are required")
+			}
+			opts.name = args[0]
+			return nil
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemove(&opts)
+		},
+	}
+
+	command.Flags().BoolVarP(&opts.confirmed, "yes", "y", false, "do not prompt for confirmation")
+	return command
+}
+
+func runRemove(opts *removeOpts) error {
+	doc, policyPath, err := loadOCIDocument(false)
+	if err != nil {
+		return err
+	}
+	index := findOCITrustPolicy(doc, opts.name)
+	if index < 0 {
+		return fmt.Errorf("OCI trust policy statement %q does not exist", opts.name)
+	}
+	if len(doc.TrustPolicies) == 1 {
+		return fmt.Errorf("cannot remove OCI trust policy statement %q: it is the only statement of the OCI trust policy configuration", opts.name)
+	}
+
+	// optional confirmation
+	confirmed, err := display.AskForConfirmation(os.Stdin, fmt.Sprintf("Are you sure you want to remove OCI trust policy statement %q?", opts.name), opts.confirmed)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	doc.TrustPolicies = slices.Delete(doc.TrustPolicies, index, index+1)
+	if err := saveOCIDocument(doc, policyPath, opts.printer); err != nil {
+		return err
+	}
+	return opts.printer.Printf("Successfully removed OCI trust policy statement %q from %s.\n", opts.name, policyPath)
+}
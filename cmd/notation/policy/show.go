@@ -22,10 +22,12 @@ import (
 
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/v2/internal/layered"
 	"github.com/spf13/cobra"
 )
 
 type showOpts struct {
+	effective bool
 }
 
 func showCmd() *cobra.Command {
@@ -40,16 +42,24 @@ Example - Show current OCI trust policy configuration:
 
 Example - Save current OCI trust policy configuration to a file:
   notation policy show > my_policy.json
+
+Example - Show the effective OCI trust policy configuration merged from the system level and the user level configurations:
+  notation policy show --effective
 `,
 		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runShow(cmd, opts)
 		},
 	}
+	command.Flags().BoolVar(&opts.effective, "effective", false, "show the effective OCI trust policy configuration merged from the system level and the user level configurations")
 	return command
 }
 
 func runShow(command *cobra.Command, opts showOpts) error {
+	if opts.effective {
+		return runShowEffective()
+	}
+
 	// core process
 	policyJSON, err := loadOCITrustPolicy()
 	if err != nil {
@@ -73,6 +83,25 @@ func runShow(command *cobra.Command, opts showOpts) error {
 	return err
 }
 
+func runShowEffective() error {
+	doc, warnings, err := layered.LoadOCIDocument()
+	if err != nil {
+		return fmt.Errorf("failed to show effective OCI trust policy: %w", err)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("effective OCI trust policy configuration is invalid: %w", err)
+	}
+	policyJSON, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(policyJSON))
+	return err
+}
+
 func loadOCITrustPolicy() ([]byte, error) {
 	data, err := fs.ReadFile(dir.ConfigFS(), dir.PathOCITrustPolicy)
 	if err != nil && errors.Is(err, fs.ErrNotExist) {
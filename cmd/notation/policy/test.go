@@ -0,0 +1,106 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/flag"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/notaryproject/notation/v2/internal/layered"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry"
+)
+
+type testOpts struct {
+	outputFormat flag.OutputFormatFlagOpts
+	printer      *output.Printer
+	reference    string
+}
+
+func testCmd() *cobra.Command {
+	opts := testOpts{}
+	command := &cobra.Command{
+		Use:   "test [flags] <reference>",
+		Short: "Show the OCI trust policy statement applying to an artifact reference",
+		Long: `Show the OCI trust policy statement applying to an artifact reference, without contacting the registry.
+
+The statement is selected by the registry scopes the same way as "notation verify" does. The output shows why the statement is selected, the effective action of each validation type, the trusted identities, and the certificates in the trust stores used as trust anchors.
+
+Example - Show the OCI trust policy statement applying to an artifact reference:
+  notation policy test localhost:5000/net-monitor:v1
+
+Example - Show the OCI trust policy statement applying to an artifact reference and output as JSON:
+  notation policy test -o json localhost:5000/net-monitor@sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 || args[0] == "" {
+				return errors.New("missing reference to the artifact: use `notation policy test --help` to see what parameters are required")
+			}
+			opts.reference = args[0]
+			return nil
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.outputFormat.Validate(cmd); err != nil {
+				return err
+			}
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTest(cmd.Context(), &opts)
+		},
+	}
+
+	opts.outputFormat.ApplyFlags(command.Flags(), output.FormatTree, output.FormatJSON)
+	return command
+}
+
+func runTest(ctx context.Context, opts *testOpts) error {
+	// initialize display handler
+	displayHandler, err := display.NewPolicyTestHandler(opts.printer, opts.outputFormat.CurrentFormat)
+	if err != nil {
+		return err
+	}
+
+	ref, err := registry.ParseReference(opts.reference)
+	if err != nil {
+		return fmt.Errorf("%q: %w. Expecting <registry>/<repository>, <registry>/<repository>:<tag> or <registry>/<repository>@<digest>", opts.reference, err)
+	}
+	doc, warnings, err := layered.LoadOCIDocument()
+	if err != nil {
+		return fmt.Errorf("failed to load OCI trust policy configuration: %w", err)
+	}
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("invalid OCI trust policy configuration: %w", err)
+	}
+	for _, warning := range warnings {
+		opts.printer.PrintErrorf("Warning: %s\n", warning)
+	}
+
+	statement, reason, err := policy.SelectOCIStatement(doc, ref.Registry+"/"+ref.Repository)
+	if err != nil {
+		return err
+	}
+	resolution, err := policy.Resolve(ctx, layered.NewX509TrustStore(), statement, reason)
+	if err != nil {
+		return err
+	}
+	displayHandler.OnStatementResolved(opts.reference, resolution)
+	return displayHandler.Render()
+}
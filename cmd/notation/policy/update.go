@@ -0,0 +1,93 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/v2/cmd/notation/internal/policy"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type updateOpts struct {
+	policy.StatementFlagOpts
+	printer        *output.Printer
+	name           string
+	registryScopes []string
+}
+
+func updateCmd() *cobra.Command {
+	opts := updateOpts{}
+	command := &cobra.Command{
+		Use:   "update [flags] <policy_name>",
+		Short: "Update a statement of the OCI trust policy configuration",
+		Long: `Update a statement of the OCI trust policy configuration. Only the properties of the flags set are updated.
+
+Example - Replace the registry scopes of an OCI trust policy statement:
+  notation policy update --registry-scope localhost:5000/net-monitor --registry-scope localhost:5000/net-logger examplePolicy
+
+Example - Replace the trust stores and the trusted identities of an OCI trust policy statement:
+  notation policy update --trust-store ca:exampleStore --trust-store ca:exampleStore2 --trusted-identity "x509.subject: C=US, ST=WA, O=acme-rockets.io" examplePolicy
+
+Example - Change the verification level of an OCI trust policy statement and override the action of the expiry validation:
+  notation policy update --verification-level permissive --override expiry=log examplePolicy
+
+Example - Remove the override of the expiry validation of an OCI trust policy statement:
+  notation policy update --remove-override expiry examplePolicy
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 || args[0] == "" {
+				return errors.New("missing policy name: use `notation policy update --help` to see what parameters are required")
+			}
+			opts.name = args[0]
+			return nil
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			opts.printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpdate(cmd.Flags(), &opts)
+		},
+	}
+
+	command.Flags().StringArrayVar(&opts.registryScopes, "registry-scope", nil, "registry scope in the format \"<registry>/<repository>\", or \"*\" for all the repositories. Replaces the registry scopes of the statement")
+	opts.StatementFlagOpts.ApplyFlags(command.Flags())
+	return command
+}
+
+func runUpdate(fs *pflag.FlagSet, opts *updateOpts) error {
+	doc, policyPath, err := loadOCIDocument(false)
+	if err != nil {
+		return err
+	}
+	index := findOCITrustPolicy(doc, opts.name)
+	if index < 0 {
+		return fmt.Errorf("OCI trust policy statement %q does not exist", opts.name)
+	}
+
+	statement := &doc.TrustPolicies[index]
+	if fs.Changed("registry-scope") {
+		statement.RegistryScopes = opts.registryScopes
+	}
+	if err := opts.StatementFlagOpts.Apply(fs, &statement.SignatureVerification, &statement.TrustStores, &statement.TrustedIdentities); err != nil {
+		return err
+	}
+	if err := saveOCIDocument(doc, policyPath, opts.printer); err != nil {
+		return err
+	}
+	return opts.printer.Printf("Successfully updated OCI trust policy statement %q in %s.\n", opts.name, policyPath)
+}
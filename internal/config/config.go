@@ -21,6 +21,7 @@ import (
 
 	"github.com/notaryproject/notation-go/config"
 	"github.com/notaryproject/notation/v2/internal/envelope"
+	"github.com/notaryproject/notation/v2/internal/layered"
 )
 
 // loadConfigOnce is a function that invokes loadConfig only once.
@@ -34,10 +35,10 @@ func LoadConfigOnce() (*config.Config, error) {
 	return loadConfigOnce()
 }
 
-// loadConfig reads the config from file or return a default config if not
-// found.
+// loadConfig reads the config layered from the system level and the user level
+// files or return a default config if not found.
 func loadConfig() (*config.Config, error) {
-	configInfo, err := config.LoadConfig()
+	configInfo, err := layered.LoadConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -14,6 +14,7 @@
 package envelope
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,6 +32,15 @@ const (
 
 	// MediaTypePayloadV1 is the supported content type for signature's payload.
 	MediaTypePayloadV1 = "application/vnd.cncf.notary.payload.v1+json"
+
+	// AnnotationX509ChainThumbprint is the annotation key of the signature
+	// manifest whose value is the list of SHA-256 thumbprints of the signing
+	// certificate chain.
+	AnnotationX509ChainThumbprint = "io.cncf.notary.x509chain.thumbprint#S256"
+
+	// MediaTypeSignatureBundle is the media type of the signature bundle
+	// produced by `notation blob sign --bundle`.
+	MediaTypeSignatureBundle = "application/vnd.cncf.notary.signature-bundle.v1+json"
 )
 
 // Payload describes the content that gets signed.
@@ -49,6 +59,30 @@ func GetEnvelopeMediaType(sigFormat string) (string, error) {
 	return "", fmt.Errorf("signature format %q not supported\nSupported signature envelope formats are \"jws\" and \"cose\"", sigFormat)
 }
 
+// coseSign1Prefix is the CBOR encoding of tag 18 (COSE_Sign1_Tagged) followed
+// by the header of an array of 4 items.
+var coseSign1Prefix = []byte{0xd2, 0x84}
+
+// DetectMediaType returns the envelope media type of a signature by
+// inspecting its content. A JWS envelope is a JSON object with protected,
+// payload and signature fields, and a COSE envelope is a CBOR tagged
+// COSE_Sign1 message.
+func DetectMediaType(envelopeBytes []byte) (string, error) {
+	if bytes.HasPrefix(envelopeBytes, coseSign1Prefix) {
+		return cose.MediaTypeEnvelope, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(envelopeBytes, &fields); err == nil {
+		_, hasProtected := fields["protected"]
+		_, hasPayload := fields["payload"]
+		_, hasSignature := fields["signature"]
+		if hasProtected && hasPayload && hasSignature {
+			return jws.MediaTypeEnvelope, nil
+		}
+	}
+	return "", errors.New("unable to detect the signature envelope format from the content. Supported signature envelope formats are \"jws\" and \"cose\"")
+}
+
 // ValidatePayloadContentType validates signature payload's content type.
 func ValidatePayloadContentType(payload *signature.Payload) error {
 	switch payload.ContentType {
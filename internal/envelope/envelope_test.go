@@ -135,3 +135,50 @@ func TestDescriptorFromSignaturePayload(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectMediaType(t *testing.T) {
+	tests := []struct {
+		name          string
+		envelopeBytes []byte
+		want          string
+		wantErr       bool
+	}{
+		{
+			name:          "jws envelope",
+			envelopeBytes: []byte(`{"payload":"eyJ9","protected":"eyJ9","header":{},"signature":"c2ln"}`),
+			want:          "application/jose+json",
+		},
+		{
+			name:          "cose envelope",
+			envelopeBytes: []byte{0xd2, 0x84, 0x40, 0xa0, 0x40, 0x40},
+			want:          "application/cose",
+		},
+		{
+			name:          "json without signature",
+			envelopeBytes: []byte(`{"payload":"eyJ9","protected":"eyJ9"}`),
+			wantErr:       true,
+		},
+		{
+			name:          "untagged cbor",
+			envelopeBytes: []byte{0x84, 0x40, 0xa0, 0x40, 0x40},
+			wantErr:       true,
+		},
+		{
+			name:          "empty content",
+			envelopeBytes: nil,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectMediaType(tt.envelopeBytes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DetectMediaType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DetectMediaType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
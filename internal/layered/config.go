@@ -0,0 +1,65 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layered
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation-go/dir"
+)
+
+// LoadConfig loads config.json of the user level configuration directory
+// layered over config.json of the system level configuration directory.
+//
+// The insecure registries of both levels are combined, and the signature
+// format of the user level overrides the system level one. The credential
+// settings are always read from the user level.
+func LoadConfig() (*config.Config, error) {
+	userConfig, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	data, err := fs.ReadFile(SystemConfigFS(), dir.PathConfigFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return userConfig, nil
+		}
+		return nil, fmt.Errorf("failed to read system configuration: %w", err)
+	}
+	var systemConfig config.Config
+	if err := json.Unmarshal(data, &systemConfig); err != nil {
+		return nil, fmt.Errorf("malformed system configuration %s: %w", dir.PathConfigFile, err)
+	}
+	return mergeConfigs(&systemConfig, userConfig), nil
+}
+
+// mergeConfigs merges the user level config into the system level config.
+func mergeConfigs(system, user *config.Config) *config.Config {
+	merged := *user
+	merged.InsecureRegistries = slices.Clone(system.InsecureRegistries)
+	for _, registry := range user.InsecureRegistries {
+		if !slices.Contains(merged.InsecureRegistries, registry) {
+			merged.InsecureRegistries = append(merged.InsecureRegistries, registry)
+		}
+	}
+	if merged.SignatureFormat == "" {
+		merged.SignatureFormat = system.SignatureFormat
+	}
+	return &merged
+}
@@ -0,0 +1,61 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layered
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+func TestLoadConfig(t *testing.T) {
+	SystemConfigDir = t.TempDir()
+	dir.UserConfigDir = t.TempDir()
+	defer func() {
+		SystemConfigDir = ""
+		dir.UserConfigDir = ""
+	}()
+	systemConfig := `{"insecureRegistries":["registry.io","shared.io"],"signatureFormat":"cose"}`
+	if err := os.WriteFile(filepath.Join(SystemConfigDir, dir.PathConfigFile), []byte(systemConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	userConfig := `{"insecureRegistries":["shared.io","localhost:5000"],"credsStore":"pass"}`
+	if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathConfigFile), []byte(userConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if want := []string{"registry.io", "shared.io", "localhost:5000"}; !reflect.DeepEqual(config.InsecureRegistries, want) {
+		t.Fatalf("InsecureRegistries = %v, want %v", config.InsecureRegistries, want)
+	}
+	if config.SignatureFormat != "cose" {
+		t.Fatalf("SignatureFormat = %q, want cose", config.SignatureFormat)
+	}
+	if config.CredentialsStore != "pass" {
+		t.Fatalf("CredentialsStore = %q, want pass", config.CredentialsStore)
+	}
+
+	if err := os.WriteFile(filepath.Join(SystemConfigDir, dir.PathConfigFile), []byte("invalid json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(); err == nil {
+		t.Fatal("LoadConfig() expected error for malformed system level configuration")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package layered provides the Notation configuration layered from the system
+// level configuration directory and the user level configuration directory.
+//
+// The system level directory is managed by administrators of a host, and its
+// trust policy statements and trust stores are mandatory. Users can only add
+// trust policy statements for registry scopes or blob policy names that the
+// system level configuration does not govern.
+package layered
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+// SystemConfigDir is the absolute path of the system level configuration
+// directory. The default directory of the platform is used if it is empty.
+var SystemConfigDir string
+
+// SystemConfigFS returns the file system of the system level configuration
+// directory.
+func SystemConfigFS() dir.SysFS {
+	return dir.NewSysFS(systemConfigDirPath())
+}
+
+// systemConfigDirPath returns the system level configuration directory path.
+func systemConfigDirPath() string {
+	if SystemConfigDir != "" {
+		return SystemConfigDir
+	}
+	if runtime.GOOS == "windows" {
+		programData := os.Getenv("ProgramData")
+		if programData == "" {
+			programData = `C:\ProgramData`
+		}
+		return filepath.Join(programData, "notation")
+	}
+	return "/etc/notation"
+}
+
+// ConfigPath returns the path of the first of the configuration files paths
+// that exists in the user level configuration directory, or in the system
+// level configuration directory if none of them exists there. The path of the
+// first configuration file in the user level configuration directory is
+// returned if none of them exists.
+func ConfigPath(paths ...string) (string, error) {
+	for _, fsys := range []dir.SysFS{dir.ConfigFS(), SystemConfigFS()} {
+		for _, path := range paths {
+			if exists(fsys, path) {
+				return fsys.SysPath(path)
+			}
+		}
+	}
+	return dir.ConfigFS().SysPath(paths[0])
+}
+
+// exists reports whether the file of path exists in fsys.
+func exists(fsys dir.SysFS, path string) bool {
+	sysPath, err := fsys.SysPath(path)
+	if err != nil {
+		return false
+	}
+	_, err = os.Lstat(sysPath)
+	return err == nil
+}
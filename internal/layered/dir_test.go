@@ -0,0 +1,64 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layered
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+func TestConfigPath(t *testing.T) {
+	SystemConfigDir = t.TempDir()
+	dir.UserConfigDir = t.TempDir()
+	defer func() {
+		SystemConfigDir = ""
+		dir.UserConfigDir = ""
+	}()
+
+	// neither level has the configuration
+	path, err := ConfigPath(dir.PathOCITrustPolicy, dir.PathTrustPolicy)
+	if err != nil {
+		t.Fatalf("ConfigPath() error = %v", err)
+	}
+	if want := filepath.Join(dir.UserConfigDir, dir.PathOCITrustPolicy); path != want {
+		t.Fatalf("ConfigPath() = %s, want %s", path, want)
+	}
+
+	// the system level configuration
+	if err := os.WriteFile(filepath.Join(SystemConfigDir, dir.PathTrustPolicy), []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	path, err = ConfigPath(dir.PathOCITrustPolicy, dir.PathTrustPolicy)
+	if err != nil {
+		t.Fatalf("ConfigPath() error = %v", err)
+	}
+	if want := filepath.Join(SystemConfigDir, dir.PathTrustPolicy); path != want {
+		t.Fatalf("ConfigPath() = %s, want %s", path, want)
+	}
+
+	// the user level configuration takes precedence
+	if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathTrustPolicy), []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	path, err = ConfigPath(dir.PathOCITrustPolicy, dir.PathTrustPolicy)
+	if err != nil {
+		t.Fatalf("ConfigPath() error = %v", err)
+	}
+	if want := filepath.Join(dir.UserConfigDir, dir.PathTrustPolicy); path != want {
+		t.Fatalf("ConfigPath() = %s, want %s", path, want)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layered
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+)
+
+// wildcard is the registry scope matching all repositories.
+const wildcard = "*"
+
+// LoadOCIDocument loads the effective OCI trust policy document merged from
+// the system level and the user level OCI trust policy configurations. It
+// returns warnings about the user level statements that are ignored as they
+// conflict with the system level statements.
+func LoadOCIDocument() (*trustpolicy.OCIDocument, []string, error) {
+	systemDoc, err := LoadSystemOCIDocument()
+	if err != nil {
+		return nil, nil, err
+	}
+	if systemDoc == nil {
+		doc, err := trustpolicy.LoadOCIDocument()
+		return doc, nil, err
+	}
+	if !exists(dir.ConfigFS(), dir.PathOCITrustPolicy) && !exists(dir.ConfigFS(), dir.PathTrustPolicy) {
+		return systemDoc, nil, nil
+	}
+	userDoc, err := trustpolicy.LoadOCIDocument()
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, warnings := mergeOCIDocuments(systemDoc, userDoc)
+	return doc, warnings, nil
+}
+
+// LoadBlobDocument loads the effective blob trust policy document merged from
+// the system level and the user level blob trust policy configurations. It
+// returns warnings about the user level statements that are ignored as they
+// conflict with the system level statements.
+func LoadBlobDocument() (*trustpolicy.BlobDocument, []string, error) {
+	systemDoc, err := LoadSystemBlobDocument()
+	if err != nil {
+		return nil, nil, err
+	}
+	if systemDoc == nil {
+		doc, err := trustpolicy.LoadBlobDocument()
+		return doc, nil, err
+	}
+	if !exists(dir.ConfigFS(), dir.PathBlobTrustPolicy) {
+		return systemDoc, nil, nil
+	}
+	userDoc, err := trustpolicy.LoadBlobDocument()
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, warnings := mergeBlobDocuments(systemDoc, userDoc)
+	return doc, warnings, nil
+}
+
+// LoadSystemOCIDocument loads the system level OCI trust policy document. It
+// returns nil if the system level OCI trust policy configuration does not
+// exist.
+func LoadSystemOCIDocument() (*trustpolicy.OCIDocument, error) {
+	return loadSystemOCIDocument(SystemConfigFS())
+}
+
+// LoadSystemBlobDocument loads the system level blob trust policy document.
+// It returns nil if the system level blob trust policy configuration does not
+// exist.
+func LoadSystemBlobDocument() (*trustpolicy.BlobDocument, error) {
+	return loadSystemBlobDocument(SystemConfigFS())
+}
+
+// loadSystemOCIDocument loads the OCI trust policy document of the system
+// level configuration directory fsys.
+func loadSystemOCIDocument(fsys dir.SysFS) (*trustpolicy.OCIDocument, error) {
+	for _, path := range []string{dir.PathOCITrustPolicy, dir.PathTrustPolicy} {
+		var doc trustpolicy.OCIDocument
+		found, err := loadSystemDocument(fsys, path, &doc)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			if err := doc.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid system OCI trust policy configuration: %w", err)
+			}
+			return &doc, nil
+		}
+	}
+	return nil, nil
+}
+
+// loadSystemBlobDocument loads the blob trust policy document of the system
+// level configuration directory fsys.
+func loadSystemBlobDocument(fsys dir.SysFS) (*trustpolicy.BlobDocument, error) {
+	var doc trustpolicy.BlobDocument
+	found, err := loadSystemDocument(fsys, dir.PathBlobTrustPolicy, &doc)
+	if err != nil || !found {
+		return nil, err
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid system blob trust policy configuration: %w", err)
+	}
+	return &doc, nil
+}
+
+// loadSystemDocument decodes the trust policy document of path in the system
+// level configuration directory fsys into v. It returns false if the document
+// does not exist.
+func loadSystemDocument(fsys dir.SysFS, path string, v any) (bool, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read system trust policy configuration: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("malformed system trust policy configuration %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// mergeOCIDocuments merges the user level OCI trust policy document into the
+// system level one.
+//
+// The system level statements are kept as they are. A user level statement is
+// ignored if its name is used by a system level statement, and its registry
+// scopes governed by the system level statements are dropped. As the wildcard
+// registry scope governs all repositories, every user level registry scope is
+// dropped if a system level statement has the wildcard registry scope.
+func mergeOCIDocuments(system, user *trustpolicy.OCIDocument) (*trustpolicy.OCIDocument, []string) {
+	merged := &trustpolicy.OCIDocument{
+		Version:       system.Version,
+		TrustPolicies: slices.Clone(system.TrustPolicies),
+	}
+	names := make(map[string]bool)
+	scopes := make(map[string]bool)
+	for _, statement := range system.TrustPolicies {
+		names[statement.Name] = true
+		for _, scope := range statement.RegistryScopes {
+			scopes[scope] = true
+		}
+	}
+
+	var warnings []string
+	for _, statement := range user.TrustPolicies {
+		if names[statement.Name] {
+			warnings = append(warnings, fmt.Sprintf("user OCI trust policy statement %q is ignored as a system OCI trust policy statement has the same name", statement.Name))
+			continue
+		}
+		var kept []string
+		for _, scope := range statement.RegistryScopes {
+			if scopes[scope] || scopes[wildcard] {
+				warnings = append(warnings, fmt.Sprintf("registry scope %q of user OCI trust policy statement %q is ignored as it is governed by the system OCI trust policy", scope, statement.Name))
+				continue
+			}
+			kept = append(kept, scope)
+		}
+		if len(kept) == 0 {
+			warnings = append(warnings, fmt.Sprintf("user OCI trust policy statement %q is ignored as it has no registry scope left", statement.Name))
+			continue
+		}
+		statement.RegistryScopes = kept
+		merged.TrustPolicies = append(merged.TrustPolicies, statement)
+	}
+	return merged, warnings
+}
+
+// mergeBlobDocuments merges the user level blob trust policy document into
+// the system level one.
+//
+// The system level statements are kept as they are. A user level statement is
+// ignored if its name is used by a system level statement, and it is no
+// longer the global policy if a system level statement is the global policy.
+func mergeBlobDocuments(system, user *trustpolicy.BlobDocument) (*trustpolicy.BlobDocument, []string) {
+	merged := &trustpolicy.BlobDocument{
+		Version:       system.Version,
+		TrustPolicies: slices.Clone(system.TrustPolicies),
+	}
+	names := make(map[string]bool)
+	var hasGlobalPolicy bool
+	for _, statement := range system.TrustPolicies {
+		names[statement.Name] = true
+		hasGlobalPolicy = hasGlobalPolicy || statement.GlobalPolicy
+	}
+
+	var warnings []string
+	for _, statement := range user.TrustPolicies {
+		if names[statement.Name] {
+			warnings = append(warnings, fmt.Sprintf("user blob trust policy statement %q is ignored as a system blob trust policy statement has the same name", statement.Name))
+			continue
+		}
+		if statement.GlobalPolicy && hasGlobalPolicy {
+			warnings = append(warnings, fmt.Sprintf("user blob trust policy statement %q is not the global policy as the system blob trust policy has a global policy", statement.Name))
+			statement.GlobalPolicy = false
+		}
+		merged.TrustPolicies = append(merged.TrustPolicies, statement)
+	}
+	return merged, warnings
+}
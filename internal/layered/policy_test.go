@@ -0,0 +1,171 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layered
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+)
+
+func TestMergeOCIDocuments(t *testing.T) {
+	system := &trustpolicy.OCIDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.OCITrustPolicy{
+			{Name: "prod", RegistryScopes: []string{"registry.io/prod"}},
+		},
+	}
+	user := &trustpolicy.OCIDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.OCITrustPolicy{
+			{Name: "prod", RegistryScopes: []string{"registry.io/other"}},
+			{Name: "mixed", RegistryScopes: []string{"registry.io/prod", "registry.io/dev"}},
+			{Name: "governed", RegistryScopes: []string{"registry.io/prod"}},
+			{Name: "wildcard", RegistryScopes: []string{"*"}},
+		},
+	}
+	merged, warnings := mergeOCIDocuments(system, user)
+	var got []string
+	for _, statement := range merged.TrustPolicies {
+		got = append(got, statement.Name)
+	}
+	if want := []string{"prod", "mixed", "wildcard"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("merged statements = %v, want %v", got, want)
+	}
+	if want := []string{"registry.io/dev"}; !reflect.DeepEqual(merged.TrustPolicies[1].RegistryScopes, want) {
+		t.Fatalf("registry scopes = %v, want %v", merged.TrustPolicies[1].RegistryScopes, want)
+	}
+	if len(warnings) != 4 {
+		t.Fatalf("got %d warnings, want 4: %v", len(warnings), warnings)
+	}
+	if user.TrustPolicies[1].RegistryScopes[0] != "registry.io/prod" {
+		t.Fatal("mergeOCIDocuments() should not modify the user document")
+	}
+
+	// the system level wildcard registry scope governs all repositories
+	system.TrustPolicies = append(system.TrustPolicies, trustpolicy.OCITrustPolicy{Name: "default", RegistryScopes: []string{"*"}})
+	merged, _ = mergeOCIDocuments(system, user)
+	if len(merged.TrustPolicies) != 2 {
+		t.Fatalf("got %d statements, want the 2 system level statements", len(merged.TrustPolicies))
+	}
+}
+
+func TestMergeBlobDocuments(t *testing.T) {
+	system := &trustpolicy.BlobDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.BlobTrustPolicy{
+			{Name: "release", GlobalPolicy: true},
+		},
+	}
+	user := &trustpolicy.BlobDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.BlobTrustPolicy{
+			{Name: "release"},
+			{Name: "mine", GlobalPolicy: true},
+		},
+	}
+	merged, warnings := mergeBlobDocuments(system, user)
+	if len(merged.TrustPolicies) != 2 || merged.TrustPolicies[1].Name != "mine" {
+		t.Fatalf("merged statements = %v, want release and mine", merged.TrustPolicies)
+	}
+	if merged.TrustPolicies[1].GlobalPolicy {
+		t.Fatal("user level statement should not be the global policy")
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("got %d warnings, want 2: %v", len(warnings), warnings)
+	}
+}
+
+func TestLoadOCIDocument(t *testing.T) {
+	SystemConfigDir = t.TempDir()
+	dir.UserConfigDir = t.TempDir()
+	defer func() {
+		SystemConfigDir = ""
+		dir.UserConfigDir = ""
+	}()
+
+	// neither level exists
+	if _, _, err := LoadOCIDocument(); err == nil {
+		t.Fatal("LoadOCIDocument() expected error when no trust policy exists")
+	}
+
+	// only the system level exists
+	systemPolicy := `{"version":"1.0","trustPolicies":[{"name":"prod","registryScopes":["registry.io/prod"],"signatureVerification":{"level":"strict"},"trustStores":["ca:prod"],"trustedIdentities":["*"]}]}`
+	if err := os.WriteFile(filepath.Join(SystemConfigDir, dir.PathOCITrustPolicy), []byte(systemPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	doc, warnings, err := LoadOCIDocument()
+	if err != nil {
+		t.Fatalf("LoadOCIDocument() error = %v", err)
+	}
+	if len(doc.TrustPolicies) != 1 || len(warnings) != 0 {
+		t.Fatalf("LoadOCIDocument() = %v, %v, want the system level statement", doc.TrustPolicies, warnings)
+	}
+
+	// both levels exist
+	userPolicy := `{"version":"1.0","trustPolicies":[{"name":"dev","registryScopes":["registry.io/dev"],"signatureVerification":{"level":"skip"}}]}`
+	if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathOCITrustPolicy), []byte(userPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	doc, _, err = LoadOCIDocument()
+	if err != nil {
+		t.Fatalf("LoadOCIDocument() error = %v", err)
+	}
+	if len(doc.TrustPolicies) != 2 {
+		t.Fatalf("got %d statements, want 2", len(doc.TrustPolicies))
+	}
+
+	// invalid system level configuration
+	if err := os.WriteFile(filepath.Join(SystemConfigDir, dir.PathOCITrustPolicy), []byte(`{"version":"1.0"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := LoadOCIDocument(); err == nil {
+		t.Fatal("LoadOCIDocument() expected error for invalid system level configuration")
+	}
+}
+
+func TestLoadSystemBlobDocument(t *testing.T) {
+	SystemConfigDir = t.TempDir()
+	defer func() {
+		SystemConfigDir = ""
+	}()
+
+	doc, err := LoadSystemBlobDocument()
+	if err != nil || doc != nil {
+		t.Fatalf("LoadSystemBlobDocument() = %v, %v, want nil, nil", doc, err)
+	}
+
+	systemPolicy := `{"version":"1.0","trustPolicies":[{"name":"system","signatureVerification":{"level":"strict"},"trustStores":["ca:system"],"trustedIdentities":["*"]}]}`
+	if err := os.WriteFile(filepath.Join(SystemConfigDir, dir.PathBlobTrustPolicy), []byte(systemPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	doc, err = LoadSystemBlobDocument()
+	if err != nil {
+		t.Fatalf("LoadSystemBlobDocument() error = %v", err)
+	}
+	if len(doc.TrustPolicies) != 1 || doc.TrustPolicies[0].Name != "system" {
+		t.Fatalf("unexpected system blob trust policy %+v", doc)
+	}
+
+	if err := os.WriteFile(filepath.Join(SystemConfigDir, dir.PathBlobTrustPolicy), []byte(`{"version":"1.0"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSystemBlobDocument(); err == nil {
+		t.Fatal("LoadSystemBlobDocument() expected error, but ok")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layered
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+)
+
+// trustStore is a truststore.X509TrustStore reading a named trust store from
+// the system level configuration directory if it exists there, and from the
+// user level configuration directory otherwise. The trust stores referenced
+// by the system level trust policy statements are only read from the system
+// level configuration directory.
+type trustStore struct {
+	systemFS dir.SysFS
+	system   truststore.X509TrustStore
+	user     truststore.X509TrustStore
+
+	systemRefsOnce sync.Once
+	systemRefs     map[string]bool
+	systemRefsErr  error
+}
+
+// NewX509TrustStore returns the X509TrustStore layered from the system level
+// and the user level trust stores.
+//
+// A system level trust store shadows the user level trust store of the same
+// type and name, so that users cannot add certificates to the trust stores
+// that the system level trust policy statements rely on. A trust store
+// referenced by a system level statement must exist in the system level
+// configuration directory, so that users cannot provide it either.
+func NewX509TrustStore() truststore.X509TrustStore {
+	return newX509TrustStore(SystemConfigFS(), dir.ConfigFS())
+}
+
+func newX509TrustStore(systemFS, userFS dir.SysFS) truststore.X509TrustStore {
+	return &trustStore{
+		systemFS: systemFS,
+		system:   truststore.NewX509TrustStore(systemFS),
+		user:     truststore.NewX509TrustStore(userFS),
+	}
+}
+
+// GetCertificates returns the certificates of the named trust store of
+// storeType.
+func (s *trustStore) GetCertificates(ctx context.Context, storeType truststore.Type, namedStore string) ([]*x509.Certificate, error) {
+	if exists(s.systemFS, dir.X509TrustStoreDir(string(storeType), namedStore)) {
+		return s.system.GetCertificates(ctx, storeType, namedStore)
+	}
+	systemRefs, err := s.systemReferences()
+	if err != nil {
+		return nil, err
+	}
+	if name := string(storeType) + ":" + namedStore; systemRefs[name] {
+		return nil, truststore.TrustStoreError{
+			Msg:        fmt.Sprintf("the trust store %q of type %q referenced by the system trust policy does not exist in the system configuration directory", namedStore, storeType),
+			InnerError: fs.ErrNotExist,
+		}
+	}
+	return s.user.GetCertificates(ctx, storeType, namedStore)
+}
+
+// systemReferences returns the trust stores in the format
+// "<store_type>:<store_name>" referenced by the system level OCI and blob
+// trust policy statements.
+func (s *trustStore) systemReferences() (map[string]bool, error) {
+	s.systemRefsOnce.Do(func() {
+		refs := make(map[string]bool)
+		ociDoc, err := loadSystemOCIDocument(s.systemFS)
+		if err != nil {
+			s.systemRefsErr = err
+			return
+		}
+		if ociDoc != nil {
+			for _, statement := range ociDoc.TrustPolicies {
+				for _, name := range statement.TrustStores {
+					refs[name] = true
+				}
+			}
+		}
+		blobDoc, err := loadSystemBlobDocument(s.systemFS)
+		if err != nil {
+			s.systemRefsErr = err
+			return
+		}
+		if blobDoc != nil {
+			for _, statement := range blobDoc.TrustPolicies {
+				for _, name := range statement.TrustStores {
+					refs[name] = true
+				}
+			}
+		}
+		s.systemRefs = refs
+	})
+	return s.systemRefs, s.systemRefsErr
+}
@@ -0,0 +1,194 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layered
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/signer"
+	"github.com/notaryproject/notation-go/verifier"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+)
+
+func copyCert(t *testing.T, root, storeType, storeName, certPath string) {
+	t.Helper()
+	data, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storePath := filepath.Join(root, dir.X509TrustStoreDir(storeType, storeName))
+	if err := os.MkdirAll(storePath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(storePath, filepath.Base(certPath)), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrustStoreGetCertificates(t *testing.T) {
+	systemRoot := t.TempDir()
+	userRoot := t.TempDir()
+	copyCert(t, systemRoot, "ca", "shared", "../testdata/GlobalSignRootCA.crt")
+	copyCert(t, userRoot, "ca", "shared", "../testdata/self-signed.crt")
+	copyCert(t, userRoot, "ca", "user", "../testdata/self-signed.crt")
+	x509TrustStore := newX509TrustStore(dir.NewSysFS(systemRoot), dir.NewSysFS(userRoot))
+	ctx := context.Background()
+
+	// the system level trust store shadows the user level one
+	certs, err := x509TrustStore.GetCertificates(ctx, truststore.TypeCA, "shared")
+	if err != nil {
+		t.Fatalf("GetCertificates() error = %v", err)
+	}
+	if len(certs) != 1 || certs[0].Subject.CommonName != "GlobalSign" {
+		t.Fatalf("GetCertificates() = %v, want the system level certificate", certs)
+	}
+
+	// the user level trust store is used if the system level one does not
+	// exist
+	certs, err = x509TrustStore.GetCertificates(ctx, truststore.TypeCA, "user")
+	if err != nil {
+		t.Fatalf("GetCertificates() error = %v", err)
+	}
+	if len(certs) != 1 {
+		t.Fatalf("GetCertificates() returned %d certificates, want 1", len(certs))
+	}
+
+	if _, err := x509TrustStore.GetCertificates(ctx, truststore.TypeCA, "missing"); err == nil {
+		t.Fatal("GetCertificates() expected error for missing trust store")
+	}
+}
+
+func TestTrustStoreGetCertificates_SystemReference(t *testing.T) {
+	systemRoot := t.TempDir()
+	userRoot := t.TempDir()
+	systemPolicy := `{"version":"1.0","trustPolicies":[{"name":"system","signatureVerification":{"level":"strict"},"trustStores":["ca:corp"],"trustedIdentities":["*"],"globalPolicy":true}]}`
+	if err := os.WriteFile(filepath.Join(systemRoot, dir.PathBlobTrustPolicy), []byte(systemPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	copyCert(t, userRoot, "ca", "user", "../testdata/self-signed.crt")
+	ctx := context.Background()
+
+	// a user cannot provide the trust store referenced by a system level
+	// statement
+	signature, certPEM := newTestBlobSignature(t, "blob")
+	writeTestCert(t, userRoot, "ca", "corp", certPEM)
+	x509TrustStore := newX509TrustStore(dir.NewSysFS(systemRoot), dir.NewSysFS(userRoot))
+	if _, err := x509TrustStore.GetCertificates(ctx, truststore.TypeCA, "corp"); err == nil {
+		t.Fatal("GetCertificates() expected error for the trust store missing in the system level")
+	}
+	if err := verifyTestBlob(t, x509TrustStore, systemPolicy, "blob", signature); err == nil {
+		t.Fatal("VerifyBlob() expected error, but ok")
+	}
+
+	// user level trust stores not referenced by the system level statements
+	// are still used
+	if _, err := x509TrustStore.GetCertificates(ctx, truststore.TypeCA, "user"); err != nil {
+		t.Fatalf("GetCertificates() error = %v", err)
+	}
+
+	// the trust store provided by the system level configuration directory
+	writeTestCert(t, systemRoot, "ca", "corp", certPEM)
+	x509TrustStore = newX509TrustStore(dir.NewSysFS(systemRoot), dir.NewSysFS(userRoot))
+	if err := verifyTestBlob(t, x509TrustStore, systemPolicy, "blob", signature); err != nil {
+		t.Fatalf("VerifyBlob() error = %v", err)
+	}
+}
+
+// newTestBlobSignature signs content with a new self-signed code signing
+// certificate, and returns the JWS signature and the PEM of the certificate.
+func newTestBlobSignature(t *testing.T, content string) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test", Organization: []string{"Notary"}, Country: []string{"US"}, Province: []string{"WA"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blobSigner, err := signer.NewGenericSigner(key, []*x509.Certificate{cert})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, _, err := notation.SignBlob(context.Background(), blobSigner, strings.NewReader(content), notation.SignBlobOptions{
+		SignerSignOptions: notation.SignerSignOptions{SignatureMediaType: jws.MediaTypeEnvelope},
+		ContentMediaType:  "application/octet-stream",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+}
+
+// writeTestCert writes the certificate certPEM to the trust store
+// storeType:storeName under root.
+func writeTestCert(t *testing.T, root, storeType, storeName string, certPEM []byte) {
+	t.Helper()
+	storePath := filepath.Join(root, dir.X509TrustStoreDir(storeType, storeName))
+	if err := os.MkdirAll(storePath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(storePath, "test.crt"), certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// verifyTestBlob verifies signature of content against the blob trust policy
+// blobPolicy with x509TrustStore.
+func verifyTestBlob(t *testing.T, x509TrustStore truststore.X509TrustStore, blobPolicy, content string, signature []byte) error {
+	t.Helper()
+	var doc trustpolicy.BlobDocument
+	if err := json.Unmarshal([]byte(blobPolicy), &doc); err != nil {
+		t.Fatal(err)
+	}
+	blobVerifier, err := verifier.NewVerifierWithOptions(x509TrustStore, verifier.VerifierOptions{BlobTrustPolicy: &doc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = notation.VerifyBlob(context.Background(), blobVerifier, strings.NewReader(content), signature, notation.VerifyBlobOptions{
+		BlobVerifierVerifyOptions: notation.BlobVerifierVerifyOptions{SignatureMediaType: jws.MediaTypeEnvelope},
+	})
+	return err
+}
@@ -38,6 +38,40 @@ func WriteFile(path string, data []byte) error {
 	return os.WriteFile(path, data, 0600)
 }
 
+// WriteFileAtomic writes to a path with all parent directories created. The
+// data is written to a temporary file in the same directory, which then
+// replaces the file at path, so that readers never observe a partially
+// written file.
+func WriteFileAtomic(path string, data []byte) (err error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmpFile.Close()
+			os.Remove(tmpFile.Name())
+		}
+	}()
+	if err := tmpFile.Chmod(0600); err != nil {
+		return err
+	}
+	if _, err := tmpFile.Write(data); err != nil {
+		return err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
+
 // WriteFileWithPermission writes to a path with all parent directories created.
 func WriteFileWithPermission(path string, data []byte, perm fs.FileMode, overwrite bool) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
@@ -70,6 +70,43 @@ func TestWriteFile(t *testing.T) {
 	})
 }
 
+func TestWriteFileAtomic(t *testing.T) {
+	tempDir := t.TempDir()
+	filename := filepath.Join(tempDir, "a", "file.txt")
+	if err := WriteFileAtomic(filename, []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	validFileContent(t, filename, []byte("data"))
+
+	// overwrite the existing file
+	if err := WriteFileAtomic(filename, []byte("new data")); err != nil {
+		t.Fatal(err)
+	}
+	validFileContent(t, filename, []byte("new data"))
+	entries, err := os.ReadDir(filepath.Dir(filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected no temporary file left, got %d entries", len(entries))
+	}
+
+	t.Run("target is a directory", func(t *testing.T) {
+		if err := WriteFileAtomic(tempDir, []byte("data")); err == nil {
+			t.Fatal("should write failed")
+		}
+		entries, err := os.ReadDir(filepath.Dir(tempDir))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), "."+filepath.Base(tempDir)+".tmp-") {
+				t.Fatalf("temporary file %s is not removed", entry.Name())
+			}
+		}
+	})
+}
+
 func TestWriteFileWithPermission(t *testing.T) {
 	t.Run("write without override", func(t *testing.T) {
 		tempDir := t.TempDir()
@@ -78,3 +78,79 @@ func (c *CacheWithLog) Set(ctx context.Context, url string, bundle *corecrl.Bund
 func (c *CacheWithLog) logDiscardCrlCacheError() {
 	fmt.Fprintln(os.Stderr, "Warning: CRL cache error discarded. Enable debug log through '-d' for error details.")
 }
+
+// CacheRecorder implements corecrl.Cache and records whether the CRLs are
+// served from the underlying Cache.
+type CacheRecorder struct {
+	corecrl.Cache
+
+	mu   sync.Mutex
+	hits map[string]bool
+}
+
+// Get retrieves the CRL bundle with the given url, and records it as a cache
+// hit on success
+func (c *CacheRecorder) Get(ctx context.Context, url string) (*corecrl.Bundle, error) {
+	if c.Cache == nil {
+		return nil, errors.New("cache cannot be nil")
+	}
+	bundle, err := c.Cache.Get(ctx, url)
+	if err == nil {
+		c.record(url, true)
+	}
+	return bundle, err
+}
+
+// Set stores the CRL bundle with the given url. The CRL is recorded as a
+// cache miss, as it is fetched from the url instead of served from the cache,
+// for example, when the cached CRL is expired.
+func (c *CacheRecorder) Set(ctx context.Context, url string, bundle *corecrl.Bundle) error {
+	if c.Cache == nil {
+		return errors.New("cache cannot be nil")
+	}
+	c.record(url, false)
+	return c.Cache.Set(ctx, url, bundle)
+}
+
+// Hit reports whether the CRL with the given url was served from the cache
+// the last time it was retrieved.
+func (c *CacheRecorder) Hit(url string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits[url]
+}
+
+func (c *CacheRecorder) record(url string, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.hits == nil {
+		c.hits = make(map[string]bool)
+	}
+	c.hits[url] = hit
+}
+
+// FetcherWithFallback implements corecrl.Fetcher. It returns the CRL from
+// Fallback if fetching the CRL with the underlying Fetcher fails, for
+// example, when verifying offline.
+type FetcherWithFallback struct {
+	corecrl.Fetcher
+
+	// Fallback maps the CRL distribution point URL to the CRL bundle.
+	Fallback map[string]*corecrl.Bundle
+}
+
+func (f *FetcherWithFallback) Fetch(ctx context.Context, url string) (*corecrl.Bundle, error) {
+	if f.Fetcher == nil {
+		return nil, errors.New("fetcher cannot be nil")
+	}
+	bundle, err := f.Fetcher.Fetch(ctx, url)
+	if err != nil {
+		fallback, ok := f.Fallback[url]
+		if !ok {
+			return nil, err
+		}
+		log.GetLogger(ctx).Infof("Failed to fetch CRL from %s, using the bundled CRL instead: %v", url, err)
+		return fallback, nil
+	}
+	return bundle, nil
+}
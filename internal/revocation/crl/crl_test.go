@@ -133,3 +133,119 @@ func (d *dummyCache) Set(ctx context.Context, url string, bundle *corecrl.Bundle
 	}
 	return errors.New("cache set failed")
 }
+
+func TestFetcherWithFallback(t *testing.T) {
+	fallbackBundle := &corecrl.Bundle{}
+	fetchedBundle := &corecrl.Bundle{}
+	t.Run("fetch succeeded", func(t *testing.T) {
+		fetcher := &FetcherWithFallback{
+			Fetcher:  &dummyFetcher{bundle: fetchedBundle},
+			Fallback: map[string]*corecrl.Bundle{"http://example.com": fallbackBundle},
+		}
+		bundle, err := fetcher.Fetch(context.Background(), "http://example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bundle != fetchedBundle {
+			t.Fatal("expected to get the fetched bundle")
+		}
+	})
+
+	t.Run("fetch failed with fallback", func(t *testing.T) {
+		fetcher := &FetcherWithFallback{
+			Fetcher:  &dummyFetcher{},
+			Fallback: map[string]*corecrl.Bundle{"http://example.com": fallbackBundle},
+		}
+		bundle, err := fetcher.Fetch(context.Background(), "http://example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bundle != fallbackBundle {
+			t.Fatal("expected to get the fallback bundle")
+		}
+	})
+
+	t.Run("fetch failed without fallback", func(t *testing.T) {
+		fetcher := &FetcherWithFallback{
+			Fetcher:  &dummyFetcher{},
+			Fallback: map[string]*corecrl.Bundle{"http://example.com": fallbackBundle},
+		}
+		expectedErrMsg := "fetch failed"
+		_, err := fetcher.Fetch(context.Background(), "http://other.example.com")
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("nil fetcher", func(t *testing.T) {
+		fetcher := &FetcherWithFallback{}
+		expectedErrMsg := "fetcher cannot be nil"
+		_, err := fetcher.Fetch(context.Background(), "http://example.com")
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got %v", expectedErrMsg, err)
+		}
+	})
+}
+
+type dummyFetcher struct {
+	bundle *corecrl.Bundle
+}
+
+func (d *dummyFetcher) Fetch(ctx context.Context, url string) (*corecrl.Bundle, error) {
+	if d.bundle == nil {
+		return nil, errors.New("fetch failed")
+	}
+	return d.bundle, nil
+}
+
+func TestCacheRecorder(t *testing.T) {
+	recorder := &CacheRecorder{}
+	expectedErrMsg := "cache cannot be nil"
+	if _, err := recorder.Get(context.Background(), ""); err == nil || err.Error() != expectedErrMsg {
+		t.Fatalf("expected error %q, but got %q", expectedErrMsg, err)
+	}
+	if err := recorder.Set(context.Background(), "", nil); err == nil || err.Error() != expectedErrMsg {
+		t.Fatalf("expected error %q, but got %q", expectedErrMsg, err)
+	}
+
+	const url = "http://example.com/crl"
+	recorder = &CacheRecorder{Cache: &memoryCache{}}
+	if _, err := recorder.Get(context.Background(), url); !errors.Is(err, corecrl.ErrCacheMiss) {
+		t.Fatalf("expected error %q, but got %q", corecrl.ErrCacheMiss, err)
+	}
+	if recorder.Hit(url) {
+		t.Fatal("expected cache miss to be recorded")
+	}
+	if err := recorder.Set(context.Background(), url, &corecrl.Bundle{}); err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Hit(url) {
+		t.Fatal("expected fetched CRL to be recorded as cache miss")
+	}
+	if _, err := recorder.Get(context.Background(), url); err != nil {
+		t.Fatal(err)
+	}
+	if !recorder.Hit(url) {
+		t.Fatal("expected cache hit to be recorded")
+	}
+}
+
+type memoryCache struct {
+	bundles map[string]*corecrl.Bundle
+}
+
+func (m *memoryCache) Get(ctx context.Context, url string) (*corecrl.Bundle, error) {
+	bundle, ok := m.bundles[url]
+	if !ok {
+		return nil, corecrl.ErrCacheMiss
+	}
+	return bundle, nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, url string, bundle *corecrl.Bundle) error {
+	if m.bundles == nil {
+		m.bundles = make(map[string]*corecrl.Bundle)
+	}
+	m.bundles[url] = bundle
+	return nil
+}
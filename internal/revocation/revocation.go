@@ -23,6 +23,7 @@ import (
 	"github.com/notaryproject/notation-core-go/revocation"
 	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
 	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/revocation/result"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/verifier/crl"
 	"github.com/notaryproject/notation/v2/internal/httputil"
@@ -32,6 +33,70 @@ import (
 // NewRevocationValidator returns a revocation.Validator given the certificate
 // purpose
 func NewRevocationValidator(ctx context.Context, purpose purpose.Purpose) (revocation.Validator, error) {
+	return NewRevocationValidatorWithFallbackCRLs(ctx, purpose, nil)
+}
+
+// NewRevocationValidatorWithFallbackCRLs returns a revocation.Validator
+// given the certificate chain purpose. The CRLs in fallbackCRLs, keyed by
+// the CRL distribution point URL, are used when fetching the CRL fails.
+func NewRevocationValidatorWithFallbackCRLs(ctx context.Context, purpose purpose.Purpose, fallbackCRLs map[string]*corecrl.Bundle) (revocation.Validator, error) {
+	var crlFetcher corecrl.Fetcher = NewCRLFetcher(ctx)
+	if len(fallbackCRLs) > 0 {
+		crlFetcher = &clicrl.FetcherWithFallback{
+			Fetcher:  crlFetcher,
+			Fallback: fallbackCRLs,
+		}
+	}
+	return newRevocationValidator(ctx, purpose, crlFetcher)
+}
+
+// NewRevocationValidatorWithCacheRecorder returns a revocation.Validator
+// given the certificate chain purpose, and the recorder of whether the CRLs
+// are served from the CRL file cache.
+func NewRevocationValidatorWithCacheRecorder(ctx context.Context, purpose purpose.Purpose) (revocation.Validator, *clicrl.CacheRecorder, error) {
+	crlFetcher := NewCRLFetcher(ctx)
+	recorder := &clicrl.CacheRecorder{Cache: crlFetcher.Cache}
+	if crlFetcher.Cache != nil {
+		crlFetcher.Cache = recorder
+	}
+	validator, err := newRevocationValidator(ctx, purpose, crlFetcher)
+	if err != nil {
+		return nil, nil, err
+	}
+	return validator, recorder, nil
+}
+
+func newRevocationValidator(ctx context.Context, purpose purpose.Purpose, crlFetcher corecrl.Fetcher) (revocation.Validator, error) {
+	return revocation.NewWithOptions(revocation.Options{
+		OCSPHTTPClient:   httputil.NewClient(ctx, &http.Client{Timeout: 2 * time.Second}),
+		CRLFetcher:       crlFetcher,
+		CertChainPurpose: purpose,
+	})
+}
+
+// ChainResult returns the revocation result of a certificate chain given the
+// results of its certificates: Revoked if any certificate is revoked,
+// otherwise Unknown if the status of any certificate is unknown, otherwise OK
+// if any certificate is checked, otherwise NonRevokable.
+func ChainResult(results []*result.CertRevocationResult) result.Result {
+	chainResult := result.ResultNonRevokable
+	for _, r := range results {
+		switch r.Result {
+		case result.ResultRevoked:
+			return result.ResultRevoked
+		case result.ResultUnknown:
+			chainResult = result.ResultUnknown
+		case result.ResultOK:
+			if chainResult == result.ResultNonRevokable {
+				chainResult = result.ResultOK
+			}
+		}
+	}
+	return chainResult
+}
+
+// NewCRLFetcher returns a CRL fetcher backed by the CRL file cache.
+func NewCRLFetcher(ctx context.Context) *corecrl.HTTPFetcher {
 	// err is always nil
 	crlFetcher, _ := corecrl.NewHTTPFetcher(httputil.NewClient(ctx, &http.Client{Timeout: 5 * time.Second}))
 	crlFetcher.DiscardCacheError = true                     // discard crl cache error
@@ -46,9 +111,5 @@ func NewRevocationValidator(ctx context.Context, purpose purpose.Purpose) (revoc
 			DiscardCacheError: crlFetcher.DiscardCacheError,
 		}
 	}
-	return revocation.NewWithOptions(revocation.Options{
-		OCSPHTTPClient:   httputil.NewClient(ctx, &http.Client{Timeout: 2 * time.Second}),
-		CRLFetcher:       crlFetcher,
-		CertChainPurpose: purpose,
-	})
+	return crlFetcher
 }
@@ -23,6 +23,7 @@ import (
 
 	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
 	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/revocation/result"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation/v2/internal/httputil"
 )
@@ -73,3 +74,53 @@ func TestNilError(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestNewRevocationValidatorWithFallbackCRLs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows")
+	}
+	fallbackCRLs := map[string]*corecrl.Bundle{
+		"http://example.com/crl": {},
+	}
+	if _, err := NewRevocationValidatorWithFallbackCRLs(context.Background(), purpose.CodeSigning, fallbackCRLs); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewRevocationValidatorWithCacheRecorder(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows")
+	}
+	validator, recorder, err := NewRevocationValidatorWithCacheRecorder(context.Background(), purpose.CodeSigning)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if validator == nil || recorder == nil {
+		t.Fatal("expected validator and cache recorder")
+	}
+}
+
+func TestChainResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []result.Result
+		want    result.Result
+	}{
+		{name: "empty", want: result.ResultNonRevokable},
+		{name: "non-revokable", results: []result.Result{result.ResultNonRevokable}, want: result.ResultNonRevokable},
+		{name: "ok", results: []result.Result{result.ResultOK, result.ResultNonRevokable}, want: result.ResultOK},
+		{name: "unknown", results: []result.Result{result.ResultOK, result.ResultUnknown, result.ResultOK}, want: result.ResultUnknown},
+		{name: "revoked", results: []result.Result{result.ResultUnknown, result.ResultRevoked, result.ResultOK}, want: result.ResultRevoked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var certResults []*result.CertRevocationResult
+			for _, r := range tt.results {
+				certResults = append(certResults, &result.CertRevocationResult{Result: r})
+			}
+			if got := ChainResult(certResults); got != tt.want {
+				t.Fatalf("ChainResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
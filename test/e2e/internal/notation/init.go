@@ -26,6 +26,7 @@ const (
 	NotationDirName     = "notation"
 	BlobTrustPolicyName = "trustpolicy.blob.json"
 	TrustPolicyName     = "trustpolicy.json"
+	OCITrustPolicyName  = "trustpolicy.oci.json"
 	TrustStoreDirName   = "truststore"
 	TrustStoreTypeCA    = "ca"
 	PluginDirName       = "plugins"
@@ -67,6 +67,15 @@ func (m *Matcher) MatchErrKeyWords(keywords ...string) *Matcher {
 	return m
 }
 
+// NoMatchKeyWords guarantees that the given keywords do not match with the
+// stdout.
+func (m *Matcher) NoMatchKeyWords(keywords ...string) *Matcher {
+	for _, w := range keywords {
+		Expect(m.stdout).ShouldNot(ContainSubstring(w))
+	}
+	return m
+}
+
 // NoMatchErrKeyWords guarantees that the given keywords do not match with
 // the stderr.
 func (m *Matcher) NoMatchErrKeyWords(keywords ...string) *Matcher {
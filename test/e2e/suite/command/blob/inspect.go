@@ -52,10 +52,14 @@ var _ = Describe("notation blob inspect", func() {
 	It("unknown signature file name", func() {
 		Host(BaseOptions(), func(notation *utils.ExecOpts, _ *Artifact, vhost *utils.VirtualHost) {
 			notation.ExpectFailure().Exec("blob", "inspect", "unknown.sig").
-				MatchErrKeyWords("invalid signature filename")
+				MatchErrKeyWords("failed to read signature file")
 
-			notation.ExpectFailure().Exec("blob", "inspect", "hello.unknown.sig").
-				MatchErrKeyWords("signature format \"unknown\" not supported")
+			invalidSignaturePath := vhost.AbsolutePath("hello.unknown.sig")
+			if err := os.WriteFile(invalidSignaturePath, []byte("invalid signature"), 0600); err != nil {
+				Fail(err.Error())
+			}
+			notation.ExpectFailure().Exec("blob", "inspect", invalidSignaturePath).
+				MatchErrKeyWords("failed to determine the format of signature file hello.unknown.sig")
 		})
 	})
 
@@ -300,4 +304,69 @@ var _ = Describe("notation blob inspect", func() {
 					"signed artifact")
 		})
 	})
+
+	It("with signature file name without signature format", func() {
+		HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			notation.Exec("blob", "sign", "--signature-format", "cose", blobPath).
+				MatchKeyWords(SignSuccessfully).
+				MatchKeyWords("Signature file written to")
+
+			blobDir := filepath.Dir(blobPath)
+			renamedSignaturePath := filepath.Join(blobDir, "blob.sig")
+			if err := os.Rename(signatureFilepath(blobDir, blobPath, "cose"), renamedSignaturePath); err != nil {
+				Fail(err.Error())
+			}
+			notation.Exec("blob", "inspect", renamedSignaturePath).
+				MatchKeyWords(
+					"signature envelope type: application/cose",
+					"signed artifact")
+		})
+	})
+
+	It("with --blob matching the signed artifact", func() {
+		HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			notation.Exec("blob", "sign", blobPath).
+				MatchKeyWords(SignSuccessfully).
+				MatchKeyWords("Signature file written to")
+
+			blobDir := filepath.Dir(blobPath)
+			signaturePath := signatureFilepath(blobDir, blobPath, "jws")
+			notation.Exec("blob", "inspect", "--blob", blobPath, signaturePath).
+				MatchKeyWords(
+					"signed artifact",
+					"blob check",
+					"path: "+blobPath,
+					"result: match")
+			notation.Exec("blob", "inspect", "-o", "json", "--blob", blobPath, signaturePath).
+				MatchKeyWords(`"blobCheck"`, `"matched": true`)
+		})
+	})
+
+	It("with --blob not matching the signed artifact", func() {
+		HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			notation.Exec("blob", "sign", blobPath).
+				MatchKeyWords(SignSuccessfully).
+				MatchKeyWords("Signature file written to")
+
+			blobDir := filepath.Dir(blobPath)
+			otherBlobPath := filepath.Join(blobDir, "other-blob")
+			if err := os.WriteFile(otherBlobPath, []byte("other blob content"), 0600); err != nil {
+				Fail(err.Error())
+			}
+			notation.Exec("blob", "inspect", "--blob", otherBlobPath, signatureFilepath(blobDir, blobPath, "jws")).
+				MatchKeyWords("blob check", "result: mismatch")
+		})
+	})
+
+	It("with --blob that does not exist", func() {
+		HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			notation.Exec("blob", "sign", blobPath).
+				MatchKeyWords(SignSuccessfully).
+				MatchKeyWords("Signature file written to")
+
+			blobDir := filepath.Dir(blobPath)
+			notation.ExpectFailure().Exec("blob", "inspect", "--blob", filepath.Join(blobDir, "missing"), signatureFilepath(blobDir, blobPath, "jws")).
+				MatchErrKeyWords("failed to check blob")
+		})
+	})
 })
@@ -353,4 +353,188 @@ var _ = Describe("blob trust policy maintainer", func() {
 			})
 		})
 	})
+
+	When("adding a statement", func() {
+		It("should create the configuration if it does not exist", func() {
+			Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("blob", "policy", "add",
+					"--name", "example-policy",
+					"--trust-store", "ca:example-store",
+					"--trusted-identity", "*",
+					"--verification-level", "permissive",
+					"--override", "revocation=log").
+					MatchKeyWords(`Successfully added blob trust policy statement "example-policy"`)
+
+				notation.Exec("blob", "policy", "show").
+					MatchContent(`{
+  "version": "1.0",
+  "trustPolicies": [
+    {
+      "name": "example-policy",
+      "signatureVerification": {
+        "level": "permissive",
+        "override": {
+          "revocation": "log"
+        }
+      },
+      "trustStores": [
+        "ca:example-store"
+      ],
+      "trustedIdentities": [
+        "*"
+      ]
+    }
+  ]
+}`)
+			})
+		})
+
+		It("should add a statement to the existing configuration", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("blob", "policy", "add", "--name", "new-skip-policy", "--verification-level", "skip").
+					MatchKeyWords(`Successfully added blob trust policy statement "new-skip-policy"`)
+				notation.Exec("blob", "policy", "show").
+					MatchKeyWords("wabbit-networks-policy", "global-verification-policy", "new-skip-policy")
+			})
+		})
+
+		It("should fail if the statement already exists", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("blob", "policy", "add", "--name", "wabbit-networks-policy", "--verification-level", "skip").
+					MatchErrKeyWords(`blob trust policy statement "wabbit-networks-policy" already exists`)
+			})
+		})
+
+		It("should fail if a second global statement is added", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("blob", "policy", "add", "--name", "new-policy", "--trust-store", "ca:example-store", "--trusted-identity", "*", "--global").
+					MatchErrKeyWords("invalid blob trust policy configuration", "Only one trust policy statement can be marked as global policy")
+			})
+		})
+	})
+
+	When("updating a statement", func() {
+		It("should update the flags set only", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("blob", "policy", "update", "--trust-store", "ca:new-store", "--override", "expiry=log", "wabbit-networks-policy").
+					MatchKeyWords(`Successfully updated blob trust policy statement "wabbit-networks-policy"`)
+				notation.Exec("blob", "policy", "show").
+					MatchKeyWords(`"ca:new-store"`, `"expiry": "log"`, "x509.subject: C=US, ST=WA, L=Seattle, O=wabbit-networks.io, OU=Security Tools")
+			})
+		})
+
+		It("should move the global flag", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("blob", "policy", "update", "--global=false", "global-verification-policy").
+					MatchKeyWords("Successfully updated blob trust policy statement")
+				notation.Exec("blob", "policy", "update", "--global", "wabbit-networks-policy").
+					MatchKeyWords("Successfully updated blob trust policy statement")
+			})
+		})
+
+		It("should fail if the statement does not exist", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("blob", "policy", "update", "--global", "non-existing-policy").
+					MatchErrKeyWords(`blob trust policy statement "non-existing-policy" does not exist`)
+			})
+		})
+
+		It("should fail if the configuration does not exist", func() {
+			Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("blob", "policy", "update", "--global", "example-policy").
+					MatchErrKeyWords("trust policy is not present")
+			})
+		})
+	})
+
+	When("removing a statement", func() {
+		It("should remove the statement", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("blob", "policy", "remove", "--yes", "skip-verification-policy").
+					MatchKeyWords(`Successfully removed blob trust policy statement "skip-verification-policy"`)
+				content, err := os.ReadFile(vhost.AbsolutePath(NotationDirName, BlobTrustPolicyName))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strings.Contains(string(content), "skip-verification-policy")).To(BeFalse())
+			})
+		})
+
+		It("should cancel removing by default", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("blob", "policy", "remove", "skip-verification-policy").
+					MatchKeyWords("Are you sure you want to remove blob trust policy statement")
+				notation.Exec("blob", "policy", "show").
+					MatchKeyWords("skip-verification-policy")
+			})
+		})
+	})
+
+	When("linting configuration", func() {
+		It("should report the findings", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("blob", "policy", "lint").
+					MatchKeyWords(
+						`error: statement "wabbit-networks-policy", trust store "ca:wabbit-networks": the trust store does not exist`,
+						`warning: statement "global-verification-policy": verification level "audit" applies to all the blobs as the global policy`,
+						`warning: statement "global-verification-policy": the wildcard trusted identity "*"`,
+						`error: statement "global-verification-policy", trust store "ca:acme-rockets": the trust store does not exist`,
+						"Found 2 error(s) and 2 warning(s) in",
+					).
+					MatchErrKeyWords("found 2 error(s) in the blob trust policy configuration")
+			})
+		})
+
+		It("should output the findings as JSON", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("blob", "policy", "lint", "--output", "json").
+					MatchKeyWords(`"errors": 2`, `"warnings": 2`, `"rule": "trust-store-missing"`, `"trustStore": "ca:acme-rockets"`)
+			})
+		})
+	})
+
+	When("showing the effective configuration", func() {
+		It("should merge the system and user statements", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				systemDir := vhost.AbsolutePath("system")
+				Expect(os.MkdirAll(systemDir, 0700)).To(Succeed())
+				systemPolicy := `{"version":"1.0","trustPolicies":[{"name":"platform","globalPolicy":true,"signatureVerification":{"level":"strict"},"trustStores":["ca:platform"],"trustedIdentities":["*"]}]}`
+				Expect(os.WriteFile(filepath.Join(systemDir, BlobTrustPolicyName), []byte(systemPolicy), 0600)).To(Succeed())
+				vhost.UpdateEnv(map[string]string{"NOTATION_SYSTEM_CONFIG": systemDir})
+
+				notation.Exec("blob", "policy", "show", "--effective").
+					MatchKeyWords(`"name": "platform"`, `"name": "wabbit-networks-policy"`).
+					MatchErrKeyWords(`user blob trust policy statement "global-verification-policy" is not the global policy as the system blob trust policy has a global policy`)
+				notation.Exec("blob", "policy", "test").
+					MatchKeyWords("trust policy statement: platform")
+			})
+		})
+	})
+
+	When("testing a policy name", func() {
+		It("should show the global statement", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("blob", "policy", "test").
+					MatchKeyWords(
+						"trust policy statement: global-verification-policy",
+						"reason: no policy name is specified, the global policy applies",
+						"verification level: audit",
+						"authenticity: log",
+						`error: the trust store "acme-rockets" of type "ca" does not exist`,
+					)
+			})
+		})
+
+		It("should show the named statement as JSON", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("blob", "policy", "test", "--policy-name", "skip-verification-policy", "-o", "json").
+					MatchKeyWords(`"statement": "skip-verification-policy"`, `"verificationLevel": "skip"`)
+			})
+		})
+
+		It("should fail if the statement does not exist", func() {
+			Host(Opts(AddBlobTrustPolicyOption(validBlobTrustPolicyName)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("blob", "policy", "test", "--policy-name", "non-existing").
+					MatchErrKeyWords(`no applicable blob trust policy with name "non-existing"`)
+			})
+		})
+	})
 })
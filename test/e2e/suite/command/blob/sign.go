@@ -97,6 +97,15 @@ var _ = Describe("notation blob sign", func() {
 		})
 	})
 
+	It("with --bundle flag", func() {
+		HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			blobDir := filepath.Dir(blobPath)
+			notation.Exec("blob", "sign", "--bundle", blobPath).
+				MatchKeyWords(SignSuccessfully).
+				MatchKeyWords(fmt.Sprintf("Signature file written to %s", filepath.Join(blobDir, filepath.Base(blobPath)+".jws.bundle.json")))
+		})
+	})
+
 	It("with --force flag", func() {
 		HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
 			sigDir := vhost.AbsolutePath()
@@ -14,14 +14,17 @@
 package blob
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	. "github.com/notaryproject/notation/test/e2e/internal/notation"
 	"github.com/notaryproject/notation/test/e2e/internal/utils"
 	. "github.com/notaryproject/notation/test/e2e/suite/common"
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/opencontainers/go-digest"
 )
 
 var _ = Describe("notation blob verify", func() {
@@ -117,10 +120,150 @@ var _ = Describe("notation blob verify", func() {
 		})
 	})
 
+	It("with signature bundle", func() {
+		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			notation.Exec("blob", "sign", "--bundle", "--signature-format", "cose", blobPath).
+				MatchKeyWords(SignSuccessfully)
+
+			bundlePath := filepath.Join(filepath.Dir(blobPath), filepath.Base(blobPath)+".cose.bundle.json")
+			notation.Exec("blob", "verify", "--signature", bundlePath, blobPath).
+				MatchKeyWords(VerifySuccessfully)
+			notation.Exec("blob", "verify", blobPath).
+				MatchKeyWords(VerifySuccessfully, "using signature file "+bundlePath)
+			notation.Exec("blob", "inspect", bundlePath).
+				MatchKeyWords("signature envelope type: application/cose")
+		})
+	})
+
+	It("with signature signed by digest", func() {
+		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			content, err := os.ReadFile(blobPath)
+			if err != nil {
+				Fail(err.Error())
+			}
+			blobDigest := digest.FromBytes(content)
+			notation.Exec("blob", "sign", "--digest", blobDigest.String(), "--size", strconv.Itoa(len(content)), blobPath).
+				MatchKeyWords(SignSuccessfully)
+
+			notation.Exec("blob", "verify", blobPath).
+				MatchKeyWords(VerifySuccessfully)
+		})
+	})
+
+	It("with signatures signed from checksum file", func() {
+		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			content, err := os.ReadFile(blobPath)
+			if err != nil {
+				Fail(err.Error())
+			}
+			checksumsPath := filepath.Join(filepath.Dir(blobPath), "SHA256SUMS")
+			checksums := digest.FromBytes(content).Encoded() + "  " + filepath.Base(blobPath) + "\n"
+			if err := os.WriteFile(checksumsPath, []byte(checksums), 0600); err != nil {
+				Fail(err.Error())
+			}
+			notation.Exec("blob", "sign", "--checksums", checksumsPath).
+				MatchKeyWords("Successfully signed 1 of 1 blobs listed in " + checksumsPath)
+
+			notation.Exec("blob", "verify", blobPath).
+				MatchKeyWords(VerifySuccessfully)
+		})
+	})
+
+	It("with --recursive", func() {
+		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			bundleDir := vhost.AbsolutePath("bundle")
+			for _, name := range []string{"a.txt", filepath.Join("sub", "b.txt")} {
+				path := filepath.Join(bundleDir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+					Fail(err.Error())
+				}
+				if err := os.WriteFile(path, []byte(name), 0600); err != nil {
+					Fail(err.Error())
+				}
+			}
+			notation.Exec("blob", "sign", "--recursive", bundleDir).
+				MatchKeyWords("Successfully signed 2 files in").
+				MatchKeyWords("Manifest file written to " + vhost.AbsolutePath("bundle.manifest.json"))
+
+			signaturePath := vhost.AbsolutePath("bundle.manifest.json.jws.sig")
+			notation.Exec("blob", "verify", "--recursive", "--signature", signaturePath, bundleDir).
+				MatchKeyWords(VerifySuccessfully).
+				MatchKeyWords("Verified 2 files against manifest")
+			notation.Exec("blob", "verify", "--recursive", "--signature-directory", filepath.Dir(bundleDir), bundleDir).
+				MatchKeyWords(VerifySuccessfully).
+				MatchKeyWords("Verified 2 files against manifest")
+
+			if err := os.WriteFile(filepath.Join(bundleDir, "a.txt"), []byte("modified"), 0600); err != nil {
+				Fail(err.Error())
+			}
+			notation.ExpectFailure().Exec("blob", "verify", "--recursive", "--signature", signaturePath, bundleDir).
+				MatchErrKeyWords("does not match the signed manifest", "modified: a.txt")
+		})
+	})
+
+	It("without --signature flag", func() {
+		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			notation.Exec("blob", "sign", blobPath).
+				MatchKeyWords(SignSuccessfully)
+			notation.Exec("blob", "sign", "--signature-format", "cose", blobPath).
+				MatchKeyWords(SignSuccessfully)
+
+			blobDir := filepath.Dir(blobPath)
+			notation.Exec("blob", "verify", blobPath).
+				MatchKeyWords(
+					VerifySuccessfully,
+					"using signature file "+signatureFilepath(blobDir, blobPath, "jws"),
+					"using signature file "+signatureFilepath(blobDir, blobPath, "cose"),
+				)
+		})
+	})
+
+	It("with --signature-directory flag", func() {
+		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			signatureDirectory := vhost.AbsolutePath("signatures")
+			if err := os.MkdirAll(signatureDirectory, 0700); err != nil {
+				Fail(err.Error())
+			}
+			notation.Exec("blob", "sign", "--signature-directory", signatureDirectory, blobPath).
+				MatchKeyWords(SignSuccessfully)
+
+			notation.Exec("blob", "verify", "--signature-directory", signatureDirectory, blobPath).
+				MatchKeyWords(
+					VerifySuccessfully,
+					"using signature file "+signatureFilepath(signatureDirectory, blobPath, "jws"),
+				)
+		})
+	})
+
+	It("with --from a blob pushed by blob sign --push", func() {
+		HostWithBlob(append(BaseBlobOptions(), AuthOption("", "")), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			pushReference := fmt.Sprintf("%s/blob-push-%d:v1", TestRegistry.Host, GinkgoRandomSeed())
+			notation.Exec("blob", "sign", "--force", "--push", pushReference, blobPath).
+				MatchKeyWords(SignSuccessfully).
+				MatchKeyWords("Blob artifact pushed to", "attached to")
+			notation.Exec("blob", "sign", "--force", "--signature-format", "cose", "--push", pushReference, blobPath).
+				MatchKeyWords(SignSuccessfully).
+				MatchKeyWords("Blob artifact pushed to", "attached to")
+
+			notation.Exec("blob", "verify", "--from", pushReference).
+				MatchKeyWords(VerifySuccessfully).
+				MatchErrKeyWords("Always verify the artifact using digest(@sha256:...) rather than a tag(:v1)")
+			notation.ExpectFailure().Exec("blob", "verify", "--media-type", "image/jpeg", "--from", pushReference).
+				MatchErrKeyWords("signature verification failed for all the signatures associated with")
+		})
+	})
+
 	// Failure cases
-	It("with missing --signature flag", func() {
+	It("with no signature file found", func() {
 		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
 			notation.ExpectFailure().Exec("blob", "verify", blobPath).
+				MatchErrKeyWords("no signature file found for " + blobPath)
+		})
+	})
+
+	It("with empty --signature flag", func() {
+		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			notation.ExpectFailure().Exec("blob", "verify", "--signature", "", blobPath).
 				MatchErrKeyWords("filepath of the signature cannot be empty")
 		})
 	})
@@ -194,24 +337,25 @@ var _ = Describe("notation blob verify", func() {
 		})
 	})
 
-	It("with invalid signature file extension", func() {
+	It("with signature file name without signature format", func() {
 		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
 			blobDir := filepath.Dir(blobPath)
-			notation.Exec("blob", "sign", blobPath).
+			notation.Exec("blob", "sign", "--signature-format", "cose", blobPath).
 				MatchKeyWords(SignSuccessfully).
 				MatchKeyWords("Signature file written to")
 
-			signaturePath := signatureFilepath(blobDir, blobPath, "jws")
-			invalidSignaturePath := strings.TrimSuffix(signaturePath, ".sig") + "." + "invalid"
-			if err := os.Rename(signaturePath, invalidSignaturePath); err != nil {
+			signaturePath := signatureFilepath(blobDir, blobPath, "cose")
+			renamedSignaturePath := filepath.Join(blobDir, "blob.sig")
+			if err := os.Rename(signaturePath, renamedSignaturePath); err != nil {
 				Fail(err.Error())
 			}
-			notation.ExpectFailure().Exec("blob", "verify", "--signature", invalidSignaturePath, blobPath).
-				MatchErrKeyWords(`invalid signature filename blobFile.txt.jws.invalid. The file extension must be .sig`)
+			notation.Exec("blob", "verify", "-d", "--signature", renamedSignaturePath, blobPath).
+				MatchKeyWords(VerifySuccessfully).
+				MatchErrKeyWords("Verify signature of media type application/cose")
 		})
 	})
 
-	It("with invalid signature file name", func() {
+	It("with signature file name without .sig extension", func() {
 		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
 			blobDir := filepath.Dir(blobPath)
 			notation.Exec("blob", "sign", blobPath).
@@ -219,16 +363,29 @@ var _ = Describe("notation blob verify", func() {
 				MatchKeyWords("Signature file written to")
 
 			signaturePath := signatureFilepath(blobDir, blobPath, "jws")
-			invalidSignaturePath := strings.TrimSuffix(signaturePath, ".txt.jws.sig") + ".sig"
-			if err := os.Rename(signaturePath, invalidSignaturePath); err != nil {
+			renamedSignaturePath := strings.TrimSuffix(signaturePath, ".sig") + ".invalid"
+			if err := os.Rename(signaturePath, renamedSignaturePath); err != nil {
+				Fail(err.Error())
+			}
+			notation.Exec("blob", "verify", "-d", "--signature", renamedSignaturePath, blobPath).
+				MatchKeyWords(VerifySuccessfully).
+				MatchErrKeyWords("Verify signature of media type application/jose+json")
+		})
+	})
+
+	It("with signature file that is not a signature envelope", func() {
+		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			invalidSignaturePath := vhost.AbsolutePath("blobFile.sig")
+			if err := os.WriteFile(invalidSignaturePath, []byte("invalid signature"), 0600); err != nil {
 				Fail(err.Error())
 			}
 			notation.ExpectFailure().Exec("blob", "verify", "--signature", invalidSignaturePath, blobPath).
-				MatchErrKeyWords(`invalid signature filename blobFile.sig. A valid signature file name must contain signature format and .sig file extension`)
+				MatchErrKeyWords("failed to determine the format of signature file blobFile.sig").
+				MatchErrKeyWords(`Supported signature envelope formats are "jws" and "cose"`)
 		})
 	})
 
-	It("with invalid signature format", func() {
+	It("with signature file name conflicting with its content", func() {
 		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
 			blobDir := filepath.Dir(blobPath)
 			notation.Exec("blob", "sign", blobPath).
@@ -236,13 +393,12 @@ var _ = Describe("notation blob verify", func() {
 				MatchKeyWords("Signature file written to")
 
 			signaturePath := signatureFilepath(blobDir, blobPath, "jws")
-			invalidSignaturePath := signatureFilepath(blobDir, blobPath, "invalid")
+			invalidSignaturePath := signatureFilepath(blobDir, blobPath, "cose")
 			if err := os.Rename(signaturePath, invalidSignaturePath); err != nil {
 				Fail(err.Error())
 			}
 			notation.ExpectFailure().Exec("blob", "verify", "--signature", invalidSignaturePath, blobPath).
-				MatchErrKeyWords(`signature format "invalid" not supported`).
-				MatchErrKeyWords(`Supported signature envelope formats are "jws" and "cose"`)
+				MatchErrKeyWords("is named as a signature of media type application/cose, but its content is a signature of media type application/jose+json")
 		})
 	})
 
@@ -259,6 +415,13 @@ var _ = Describe("notation blob verify", func() {
 		})
 	})
 
+	It("with --from and blob path", func() {
+		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			notation.ExpectFailure().Exec("blob", "verify", "--from", TestRegistry.Host+"/blob:v1", blobPath).
+				MatchErrKeyWords("blob path cannot be used with --from")
+		})
+	})
+
 	It("with no trust policy", func() {
 		HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
 			blobDir := filepath.Dir(blobPath)
@@ -14,12 +14,16 @@
 package command
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	. "github.com/notaryproject/notation/test/e2e/internal/notation"
 	"github.com/notaryproject/notation/test/e2e/internal/utils"
+	. "github.com/notaryproject/notation/test/e2e/suite/common"
 
 	. "github.com/onsi/ginkgo/v2"
 )
@@ -123,6 +127,88 @@ var _ = Describe("notation cert", func() {
 		})
 	})
 
+	It("show e2e cert in JSON", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "show", "--type", "ca", "--store", "e2e", "e2e.crt", "--output", "json").
+				MatchKeyWords(
+					`"fileName": "e2e.crt"`,
+					`"issuedTo": "CN=e2e,O=Notary,L=Seattle,ST=WA,C=US"`,
+					`"chainPosition": "root"`,
+					`"keyAlgorithm": "RSA"`,
+					`"keySize": 2048`,
+				)
+		})
+	})
+
+	It("list in JSON", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "list", "--type", "ca", "--store", "e2e", "--output", "json").
+				MatchKeyWords(
+					`"storeType": "ca"`,
+					`"storeName": "e2e"`,
+					`"fileName": "e2e.crt"`,
+					`"SHA256Fingerprint": "`,
+				)
+			notation.Exec("cert", "list", "--store", "non-exist", "--output", "json").
+				MatchContent("[]\n")
+		})
+	})
+
+	It("check", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.ExpectFailure().Exec("cert", "check").
+				MatchKeyWords(
+					`warning: trust store "ca:e2e": certificate "CN=e2e,O=Notary,L=Seattle,ST=WA,C=US" in file e2e.crt is a leaf certificate`,
+					"Found 0 error(s) and 1 warning(s) in 1 trust store(s).",
+				).
+				MatchErrKeyWords("found 0 error(s) and 1 warning(s) in the trust stores")
+		})
+	})
+
+	It("check with expired certificate, invalid file and unreferenced trust store in JSON", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "add", "--type", "tsa", "--store", "expired", filepath.Join(NotationE2ELocalKeysDir, "expired_e2e.crt")).
+				MatchKeyWords("Successfully added following certificates")
+			trustStorePath := vhost.AbsolutePath(NotationDirName, TrustStoreDirName, "x509", TrustStoreTypeCA, "e2e")
+			if err := os.WriteFile(filepath.Join(trustStorePath, "invalid.txt"), []byte("invalid"), 0600); err != nil {
+				Fail(err.Error())
+			}
+
+			notation.ExpectFailure().Exec("cert", "check", "--output", "json").
+				MatchKeyWords(
+					`"trustStores": 2`,
+					`"errors": 2`,
+					`"rule": "certificate-file-invalid"`,
+					`"rule": "certificate-expired"`,
+					`"rule": "trust-store-unreferenced"`,
+					`"trustStore": "tsa:expired"`,
+				)
+		})
+	})
+
+	It("revocation-check a certificate in the trust store", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "revocation-check", "--type", "ca", "--store", "e2e", "e2e.crt").
+				MatchKeyWords(
+					"for codeSigning: NonRevokable",
+					"Certificate 1 of 1: CN=e2e,O=Notary,L=Seattle,ST=WA,C=US",
+				)
+		})
+	})
+
+	It("revocation-check the timestamp certificate chain of a signature without timestamp countersignature", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("sign", artifact.ReferenceWithDigest())
+
+			notation.ExpectFailure().Exec("cert", "revocation-check", "--from-reference", artifact.ReferenceWithDigest(), "--purpose", "timestamping", "-o", "json").
+				MatchKeyWords(
+					`"purpose": "timestamping"`,
+					"has no timestamp countersignature",
+				).
+				MatchErrKeyWords("1 of 1 certificate chain(s) are revoked, of unknown revocation status, or failed to be checked")
+		})
+	})
+
 	It("list", func() {
 		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
 			notation.Exec("cert", "list").
@@ -219,6 +305,42 @@ var _ = Describe("notation cert", func() {
 		})
 	})
 
+	It("generate test PKI and cleanup", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "generate-test-pki", "e2e-test", "--tsa", "--crl-url", "http://localhost:10086").
+				MatchKeyWords(
+					"generating test PKI with RSA 2048 bits keys",
+					"generated certificates expiring on",
+					"wrote:", "e2e-test.key", "e2e-test-pki", "tsa.crt", "intermediate.crl",
+					"Successfully added e2e-test.crt to named store e2e-test of type ca",
+					"Successfully added e2e-test.crt to named store e2e-test of type tsa",
+					"e2e-test: added to the key list",
+					"serve the CRLs at http://localhost:10086",
+				)
+
+			notation.Exec("cert", "cleanup-test", "e2e-test", "-y").
+				MatchKeyWords(
+					"Successfully deleted e2e-test.crt from trust store e2e-test of type ca",
+					"Successfully removed key e2e-test from the key list",
+					"Successfully deleted e2e-test.crt from trust store e2e-test of type tsa",
+					"Successfully deleted test PKI directory:", "e2e-test-pki",
+					"Cleanup completed successfully",
+				)
+
+			pkiDir := vhost.AbsolutePath(NotationDirName, "localkeys", "e2e-test-pki")
+			if _, err := os.Stat(pkiDir); err == nil {
+				Fail(fmt.Sprintf("test PKI directory %s should be deleted", pkiDir))
+			}
+		})
+	})
+
+	It("generate test PKI with invalid CRL URL", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.ExpectFailure().Exec("cert", "generate-test-pki", "e2e-test", "--crl-url", "localhost:10086").
+				MatchErrKeyWords("invalid CRL URL")
+		})
+	})
+
 	It("cleanup test with same name more than one time", func() {
 		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
 			notation.Exec("cert", "generate-test", "e2e-test").
@@ -433,4 +555,244 @@ var _ = Describe("notation cert", func() {
 			}
 		})
 	})
+
+	It("add the root certificate from the signatures of an artifact", func() {
+		var reference string
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			reference = artifact.ReferenceWithDigest()
+			notation.Exec("sign", reference)
+		})
+		Host(Opts(AuthOption("", "")), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "add", "--type", "ca", "--store", "publisher", "--from-reference", reference, "-y").
+				MatchKeyWords(
+					"subject: CN=e2e,O=Notary,L=Seattle,ST=WA,C=US",
+					"SHA-256 fingerprint:",
+					"to named store publisher of type ca",
+				)
+			notation.Exec("cert", "list", "--type", "ca", "--store", "publisher").
+				MatchKeyWords("publisher")
+		})
+	})
+
+	It("add the root certificate from a blob signature", func() {
+		HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			notation.Exec("blob", "sign", blobPath)
+			signaturePath := filepath.Join(filepath.Dir(blobPath), filepath.Base(blobPath)+".jws.sig")
+			notation.Exec("cert", "add", "--type", "ca", "--store", "publisher", "--from-signature", signaturePath, "-y").
+				MatchKeyWords("to named store publisher of type ca")
+			notation.ExpectFailure().Exec("cert", "add", "--type", "tsa", "--store", "publisher", "--from-signature", signaturePath, "-y").
+				MatchErrKeyWords("no root certificate found in the signatures")
+			notation.ExpectFailure().Exec("cert", "add", "--type", "ca", "--store", "publisher", "--from-signature", signaturePath, "cert.pem").
+				MatchErrKeyWords("cannot add certificate files when --from-reference or --from-signature is set")
+		})
+	})
+
+	It("add a PKCS #7 bundle", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "add", "--type", "ca", "--store", "vendor", filepath.Join(NotationE2EConfigPath, "crl", "certchain_with_crl.p7b")).
+				MatchKeyWords("Successfully added following certificates to named store vendor of type ca")
+
+			notation.Exec("cert", "show", "--type", "ca", "--store", "vendor", "certchain_with_crl.pem").
+				MatchKeyWords(
+					"Subject: CN=LeafCert",
+					"Subject: CN=IntermediateCA",
+					"Subject: CN=RootCA",
+				)
+		})
+	})
+
+	It("add the root certificates of a bundle with split", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			bundlePath := filepath.Join(NotationE2EConfigPath, "crl", "certchain_with_crl.pem")
+			notation.Exec("cert", "add", "--type", "ca", "--store", "roots", "--split", "--roots-only", bundlePath).
+				MatchKeyWords(
+					"RootCA-b877a612cdc6bd52.crt from "+bundlePath,
+					"Skipped 2 certificate(s) that are not self-signed root certificates",
+				)
+
+			notation.Exec("cert", "add", "--type", "ca", "--store", "chain", "--split", bundlePath).
+				MatchKeyWords(
+					"LeafCert-57d37c64c070e6d8.crt",
+					"IntermediateCA-4a1ba7b09f27d90c.crt",
+					"RootCA-b877a612cdc6bd52.crt",
+				)
+
+			notation.Exec("cert", "list", "--type", "ca", "--store", "roots").
+				MatchKeyWords("RootCA-b877a612cdc6bd52.crt").
+				NoMatchKeyWords("LeafCert")
+		})
+	})
+
+	It("sync with a directory", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			sourceDir := vhost.AbsolutePath("desired-truststore")
+			for _, relPath := range []string{"ca/acme-rockets/root.crt", "tsa/timestamp/tsa.crt"} {
+				dst := filepath.Join(sourceDir, filepath.FromSlash(relPath))
+				if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+					Fail(err.Error())
+				}
+				copyFile(filepath.Join(NotationE2EConfigPath, "crl", "root.crt"), dst)
+			}
+
+			notation.Exec("cert", "sync", "--prune", "--dry-run", sourceDir).
+				MatchKeyWords(
+					"add    ca/acme-rockets/root.crt",
+					"delete ca/e2e/e2e.crt",
+					"add    tsa/timestamp/tsa.crt",
+					"Dry run: 2 to add, 0 to update, 1 to delete",
+				)
+			notation.Exec("cert", "list").
+				MatchKeyWords("e2e.crt")
+
+			notation.Exec("cert", "sync", "--prune", "-y", sourceDir).
+				MatchKeyWords("2 added, 0 updated, 1 deleted")
+			notation.Exec("cert", "list").
+				MatchKeyWords("acme-rockets", "timestamp").
+				NoMatchKeyWords("e2e.crt")
+
+			notation.Exec("cert", "sync", "--prune", sourceDir).
+				MatchKeyWords("The trust store is in sync with")
+		})
+	})
+
+	It("add a certificate already in the trust store under another name", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			certPath := vhost.AbsolutePath("copy.crt")
+			copyFile(filepath.Join(NotationE2ELocalKeysDir, "e2e.crt"), certPath)
+			notation.ExpectFailure().Exec("cert", "add", "--type", "ca", "--store", "e2e", certPath).
+				MatchErrKeyWords("already exists in the Trust Store as e2e.crt")
+		})
+	})
+
+	It("list and delete duplicate certificates by fingerprint", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			trustStorePath := vhost.AbsolutePath(NotationDirName, TrustStoreDirName, "x509", TrustStoreTypeCA, "e2e")
+			copyFile(filepath.Join(NotationE2ELocalKeysDir, "e2e.crt"), filepath.Join(trustStorePath, "copy.crt"))
+			fingerprint := certFingerprint(filepath.Join(NotationE2ELocalKeysDir, "e2e.crt"))
+
+			notation.Exec("cert", "list", "--type", "ca", "--store", "e2e").
+				MatchKeyWords("copy.crt", "e2e.crt").
+				MatchErrKeyWords(
+					fmt.Sprintf("Warning: certificate with SHA-256 fingerprint %s is stored more than once in trust store e2e of type ca", fingerprint),
+				)
+
+			notation.Exec("cert", "delete", "--type", "ca", "--store", "e2e", "--fingerprint", fingerprint, "-y").
+				MatchKeyWords(
+					"Successfully deleted copy.crt from trust store e2e of type ca",
+					"Successfully deleted e2e.crt from trust store e2e of type ca",
+				)
+			if _, err := os.Stat(trustStorePath); err == nil {
+				Fail(fmt.Sprintf("empty trust store directory %s should be deleted", trustStorePath))
+			}
+		})
+	})
+
+	It("delete a non-exist cert by fingerprint", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			fingerprint := certFingerprint(filepath.Join(NotationE2ELocalKeysDir, "expired_e2e.crt"))
+			notation.ExpectFailure().Exec("cert", "delete", "--type", "ca", "--store", "e2e", "--fingerprint", fingerprint, "-y").
+				MatchErrKeyWords("does not exist in trust store e2e of type ca")
+			notation.ExpectFailure().Exec("cert", "delete", "--type", "ca", "--store", "e2e", "--fingerprint", fingerprint, "--all", "-y").
+				MatchErrKeyWords("if any flags in the group [all fingerprint] are set none of the others can be")
+		})
+	})
+	It("copy certificate to another trust store", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "copy", "ca:e2e", "signingAuthority:e2e-copy", "e2e.crt").
+				MatchKeyWords(
+					"Successfully copied e2e.crt from trust store e2e of type ca to trust store e2e-copy of type signingAuthority",
+				)
+
+			notation.Exec("cert", "list").
+				MatchKeyWords(
+					"ca                 e2e          e2e.crt",
+					"signingAuthority   e2e-copy     e2e.crt",
+				)
+
+			notation.ExpectFailure().Exec("cert", "copy", "--all", "ca:e2e", "signingAuthority:e2e-copy").
+				MatchErrKeyWords("certificate already exists in the Trust Store")
+		})
+	})
+
+	It("move all certificates to another trust store", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "move", "--all", "-y", "ca:e2e", "ca:e2e-moved").
+				MatchKeyWords(
+					"Successfully moved e2e.crt from trust store e2e of type ca to trust store e2e-moved of type ca",
+				).
+				MatchErrKeyWords(
+					"Warning: trust store ca:e2e no longer exists, but is referenced by trust policy statement(s): e2e",
+				)
+
+			trustStorePath := vhost.AbsolutePath(NotationDirName, TrustStoreDirName, "x509", TrustStoreTypeCA, "e2e")
+			if _, err := os.Stat(trustStorePath); err == nil {
+				Fail(fmt.Sprintf("empty trust store directory %s should be deleted", trustStorePath))
+			}
+		})
+	})
+
+	It("move certificate without certificate file names", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.ExpectFailure().Exec("cert", "move", "-y", "ca:e2e", "ca:e2e-moved").
+				MatchErrKeyWords("move requires either the certificate file names, or --all flag to move all certificates in the source trust store")
+		})
+	})
+
+	It("rename trust store and update trust policy", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "store", "rename", "--update-policy", "-y", "ca:e2e", "e2e-renamed").
+				MatchKeyWords(
+					"Successfully renamed trust store e2e of type ca to e2e-renamed",
+					"Updated the reference to ca:e2e in OCI trust policy statement(s): e2e",
+				)
+
+			notation.Exec("policy", "show").
+				MatchKeyWords(`"ca:e2e-renamed"`)
+
+			notation.Exec("sign", artifact.ReferenceWithDigest()).
+				MatchKeyWords(SignSuccessfully)
+
+			notation.Exec("verify", artifact.ReferenceWithDigest()).
+				MatchKeyWords(VerifySuccessfully)
+		})
+	})
+
+	It("rename trust store without updating trust policy", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "store", "rename", "-y", "ca:e2e", "e2e-renamed").
+				MatchKeyWords(
+					"Successfully renamed trust store e2e of type ca to e2e-renamed",
+				).
+				MatchErrKeyWords(
+					"Warning: trust store ca:e2e no longer exists, but is referenced by trust policy statement(s): e2e",
+					`use "--update-policy" to update the references when renaming a trust store`,
+				)
+		})
+	})
 })
+
+// copyFile copies the file at src to dst.
+func copyFile(src, dst string) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		Fail(err.Error())
+	}
+	if err := os.WriteFile(dst, data, 0600); err != nil {
+		Fail(err.Error())
+	}
+}
+
+// certFingerprint returns the SHA-256 fingerprint of the PEM encoded
+// certificate at path.
+func certFingerprint(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		Fail(err.Error())
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		Fail(fmt.Sprintf("no PEM block found in %s", path))
+	}
+	sum := sha256.Sum256(block.Bytes)
+	return hex.EncodeToString(sum[:])
+}
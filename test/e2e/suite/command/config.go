@@ -0,0 +1,86 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"os"
+
+	. "github.com/notaryproject/notation/test/e2e/internal/notation"
+	"github.com/notaryproject/notation/test/e2e/internal/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("notation config", func() {
+	It("should export and import the configuration", func() {
+		var archivePath string
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			archivePath = vhost.AbsolutePath("notation-config.tar.gz")
+			notation.Exec("config", "export", archivePath).
+				MatchKeyWords(
+					"Exported "+OCITrustPolicyName,
+					"Exported truststore/x509/ca/e2e/e2e.crt",
+					"Successfully exported configuration to",
+				)
+			notation.ExpectFailure().Exec("config", "export", archivePath).
+				MatchErrKeyWords("already exists, use --force to override it")
+		})
+		Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("config", "import", "--dry-run", archivePath).
+				MatchKeyWords(
+					"+ "+OCITrustPolicyName,
+					"+ truststore/x509/ca/e2e/e2e.crt",
+					"Dry run: 2 to add, 0 to update, 0 to delete. No changes applied.",
+				)
+			_, err := os.Stat(vhost.AbsolutePath(NotationDirName, OCITrustPolicyName))
+			Expect(err).To(HaveOccurred())
+
+			notation.Exec("config", "import", archivePath).
+				MatchKeyWords("Successfully imported configuration from", "2 added, 0 updated, 0 deleted")
+			_, err = os.Stat(vhost.AbsolutePath(NotationDirName, OCITrustPolicyName))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = os.Stat(vhost.AbsolutePath(NotationDirName, "truststore", "x509", "ca", "e2e", "e2e.crt"))
+			Expect(err).NotTo(HaveOccurred())
+
+			notation.Exec("config", "import", archivePath).
+				MatchKeyWords("The configuration is up to date, no changes to apply.")
+		})
+	})
+
+	It("should replace the existing trust store when importing", func() {
+		var archivePath string
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			archivePath = vhost.AbsolutePath("notation-config.tar.gz")
+			notation.Exec("config", "export", archivePath).
+				MatchKeyWords("Successfully exported configuration to")
+		})
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("cert", "generate-test", "extra")
+			notation.Exec("cert", "add", "--type", "ca", "--store", "e2e", vhost.AbsolutePath(NotationDirName, "localkeys", "extra.crt"))
+			notation.Exec("config", "import", "--force", archivePath).
+				MatchKeyWords("- truststore/x509/ca/e2e/extra.crt", "1 deleted")
+			_, err := os.Stat(vhost.AbsolutePath(NotationDirName, "truststore", "x509", "ca", "e2e", "extra.crt"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("should fail to import a tampered archive", func() {
+		Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			archivePath := vhost.AbsolutePath("invalid.tar.gz")
+			Expect(os.WriteFile(archivePath, []byte("invalid"), 0600)).To(Succeed())
+			notation.ExpectFailure().Exec("config", "import", archivePath).
+				MatchErrKeyWords("failed to read configuration archive")
+		})
+	})
+})
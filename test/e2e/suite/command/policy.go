@@ -14,12 +14,14 @@
 package command
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	. "github.com/notaryproject/notation/test/e2e/internal/notation"
 	"github.com/notaryproject/notation/test/e2e/internal/utils"
+	. "github.com/notaryproject/notation/test/e2e/suite/common"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -267,4 +269,389 @@ var _ = Describe("trust policy maintainer", func() {
 			})
 		})
 	})
+
+	When("initializing configuration", func() {
+		It("should initialize the configuration", func() {
+			Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "init",
+					"--name", "example-policy",
+					"--registry-scope", "*",
+					"--trust-store", "ca:example-store",
+					"--trusted-identity", "*").
+					MatchKeyWords("Successfully initialized OCI trust policy file to")
+
+				notation.Exec("policy", "show").
+					MatchKeyWords(`"name": "example-policy"`, `"level": "strict"`, `"ca:example-store"`)
+			})
+		})
+
+		It("should fail if the trust stores are missing", func() {
+			Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "init", "--name", "example-policy", "--registry-scope", "*").
+					MatchErrKeyWords("invalid OCI trust policy configuration", "missing trust stores or trusted identities")
+			})
+		})
+
+		It("should overwrite the old trust policy file by force", func() {
+			Host(Opts(AddTrustPolicyOption(TrustPolicyName, false)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "init", "--name", "example-policy", "--registry-scope", "*", "--verification-level", "skip", "--force").
+					MatchKeyWords("Deleted old trust policy configuration trustpolicy.json.", "Successfully initialized OCI trust policy file to").
+					MatchErrKeyWords("Warning: existing OCI trust policy configuration will be overwritten")
+				_, err := os.Stat(vhost.AbsolutePath(NotationDirName, TrustPolicyName))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+	})
+
+	When("adding a statement", func() {
+		It("should create the configuration if it does not exist", func() {
+			Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "add",
+					"--name", "example-policy",
+					"--registry-scope", "localhost:5000/net-monitor",
+					"--trust-store", "ca:example-store",
+					"--trusted-identity", "*",
+					"--verification-level", "permissive",
+					"--override", "revocation=log").
+					MatchKeyWords(`Successfully added OCI trust policy statement "example-policy"`)
+
+				notation.Exec("policy", "show").
+					MatchContent(`{
+  "version": "1.0",
+  "trustPolicies": [
+    {
+      "name": "example-policy",
+      "signatureVerification": {
+        "level": "permissive",
+        "override": {
+          "revocation": "log"
+        }
+      },
+      "trustStores": [
+        "ca:example-store"
+      ],
+      "trustedIdentities": [
+        "*"
+      ],
+      "registryScopes": [
+        "localhost:5000/net-monitor"
+      ]
+    }
+  ]
+}`)
+			})
+		})
+
+		It("should add a statement to the old trust policy file", func() {
+			Host(Opts(AddTrustPolicyOption(TrustPolicyName, false)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "add", "--name", "skip-policy", "--registry-scope", "localhost:5000/net-utils", "--verification-level", "skip").
+					MatchKeyWords("Deleted old trust policy configuration trustpolicy.json.", `Successfully added OCI trust policy statement "skip-policy"`)
+				notation.Exec("policy", "show").
+					MatchKeyWords(`"name": "e2e"`, `"name": "skip-policy"`)
+			})
+		})
+
+		It("should fail if the statement already exists", func() {
+			Host(Opts(AddTrustPolicyOption(TrustPolicyName, false)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "add", "--name", "e2e", "--registry-scope", "localhost:5000/net-utils", "--verification-level", "skip").
+					MatchErrKeyWords(`OCI trust policy statement "e2e" already exists`)
+			})
+		})
+
+		It("should fail if a registry scope is used by another statement", func() {
+			Host(Opts(AddTrustPolicyOption(TrustPolicyName, false)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "add", "--name", "new-policy", "--registry-scope", "*", "--verification-level", "skip").
+					MatchErrKeyWords("invalid OCI trust policy configuration", "registry scope \"*\" is present in multiple oci trust policy statements")
+			})
+		})
+	})
+
+	When("updating a statement", func() {
+		It("should update the flags set only", func() {
+			Host(Opts(AddTrustPolicyOption(TrustPolicyName, false)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "update", "--registry-scope", "localhost:5000/net-monitor", "--override", "expiry=log", "e2e").
+					MatchKeyWords(`Successfully updated OCI trust policy statement "e2e"`)
+				notation.Exec("policy", "show").
+					MatchKeyWords(`"localhost:5000/net-monitor"`, `"expiry": "log"`, `"ca:e2e"`)
+			})
+		})
+
+		It("should fail if the statement does not exist", func() {
+			Host(Opts(AddTrustPolicyOption(TrustPolicyName, false)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "update", "--verification-level", "skip", "non-existing").
+					MatchErrKeyWords(`OCI trust policy statement "non-existing" does not exist`)
+			})
+		})
+
+		It("should fail if the configuration does not exist", func() {
+			Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "update", "--verification-level", "skip", "e2e").
+					MatchErrKeyWords("trust policy is not present")
+			})
+		})
+	})
+
+	When("removing a statement", func() {
+		It("should remove the statement", func() {
+			Host(Opts(AddTrustPolicyOption(TrustPolicyName, false)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "add", "--name", "skip-policy", "--registry-scope", "localhost:5000/net-utils", "--verification-level", "skip")
+				notation.Exec("policy", "remove", "--yes", "skip-policy").
+					MatchKeyWords(`Successfully removed OCI trust policy statement "skip-policy"`)
+				content, err := os.ReadFile(vhost.AbsolutePath(NotationDirName, OCITrustPolicyName))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strings.Contains(string(content), "skip-policy")).To(BeFalse())
+			})
+		})
+
+		It("should cancel removing by default", func() {
+			Host(Opts(AddTrustPolicyOption(TrustPolicyName, false)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "add", "--name", "skip-policy", "--registry-scope", "localhost:5000/net-utils", "--verification-level", "skip")
+				notation.Exec("policy", "remove", "skip-policy").
+					MatchKeyWords("Are you sure you want to remove OCI trust policy statement")
+				notation.Exec("policy", "show").
+					MatchKeyWords(`"name": "skip-policy"`)
+			})
+		})
+
+		It("should fail to remove the only statement", func() {
+			Host(Opts(AddTrustPolicyOption(TrustPolicyName, false)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "remove", "--yes", "e2e").
+					MatchErrKeyWords("it is the only statement of the OCI trust policy configuration")
+			})
+		})
+	})
+
+	When("linting configuration", func() {
+		It("should report warnings of a working configuration", func() {
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "lint").
+					MatchKeyWords(
+						`warning: statement "e2e": the wildcard trusted identity "*"`,
+						"[wildcard-identity]",
+						`warning: trust store "ca:e2e": certificate "CN=e2e,O=Notary,L=Seattle,ST=WA,C=US" is a self-signed leaf certificate`,
+						"Found 0 error(s) and 2 warning(s) in",
+					)
+			})
+		})
+
+		It("should fail if a trust store does not exist", func() {
+			Host(Opts(AddTrustPolicyOption(TrustPolicyName, false)), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "lint").
+					MatchKeyWords(`error: statement "e2e", trust store "ca:e2e": the trust store does not exist`, "notation cert add --type ca --store e2e <cert_path>").
+					MatchErrKeyWords("found 1 error(s) in the OCI trust policy configuration")
+			})
+		})
+
+		It("should output the findings as JSON", func() {
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "update", "--verification-level", "audit", "e2e")
+				notation.Exec("policy", "lint", "-o", "json").
+					MatchKeyWords(`"errors": 0`, `"warnings": 3`, `"rule": "weak-verification-level"`, `"statement": "e2e"`, `"trustStore": "ca:e2e"`)
+			})
+		})
+
+		It("should fail if the configuration does not exist", func() {
+			Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "lint").
+					MatchErrKeyWords("failed to load OCI trust policy configuration", "trust policy is not present")
+			})
+		})
+	})
+
+	When("showing the effective configuration", func() {
+		systemPolicy := `{"version":"1.0","trustPolicies":[{"name":"platform","registryScopes":["%s"],"signatureVerification":{"level":"strict"},"trustStores":["ca:e2e"],"trustedIdentities":["*"]}]}`
+
+		setupSystemPolicy := func(vhost *utils.VirtualHost, registryScope string) {
+			systemDir := vhost.AbsolutePath("system")
+			Expect(os.MkdirAll(systemDir, 0700)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(systemDir, OCITrustPolicyName), []byte(fmt.Sprintf(systemPolicy, registryScope)), 0600)).To(Succeed())
+			vhost.UpdateEnv(map[string]string{"NOTATION_SYSTEM_CONFIG": systemDir})
+		}
+
+		It("should merge the system and user statements", func() {
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				setupSystemPolicy(vhost, "localhost:5000/net-monitor")
+				notation.Exec("policy", "show", "--effective").
+					MatchKeyWords(`"name": "platform"`, `"name": "e2e"`)
+				notation.Exec("policy", "test", "localhost:5000/net-monitor:v1").
+					MatchKeyWords("trust policy statement: platform")
+			})
+		})
+
+		It("should ignore the user statements governed by the system wildcard registry scope", func() {
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				setupSystemPolicy(vhost, "*")
+				out := notation.Exec("policy", "show", "--effective").
+					MatchKeyWords(`"name": "platform"`).
+					MatchErrKeyWords(`registry scope "*" of user OCI trust policy statement "e2e" is ignored as it is governed by the system OCI trust policy`).
+					Session.Out.Contents()
+				Expect(string(out)).NotTo(ContainSubstring(`"name": "e2e"`))
+			})
+		})
+
+		It("should use the system configuration only", func() {
+			Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				setupSystemPolicy(vhost, "localhost:5000/net-monitor")
+				notation.Exec("policy", "show", "--effective").
+					MatchKeyWords(`"name": "platform"`)
+			})
+		})
+	})
+
+	When("pushing and pulling configuration", func() {
+		It("should push, pull and install the configuration", func() {
+			pushReference := fmt.Sprintf("%s/trust-config-%d:v1", TestRegistry.Host, GinkgoRandomSeed())
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "push", "--trust-store", "ca:e2e", pushReference).
+					MatchKeyWords(
+						"Pushed the trust configuration to",
+						OCITrustPolicyName,
+						"truststore/x509/ca/e2e/e2e.crt",
+						"Successfully signed",
+					)
+			})
+			Host(Opts(AuthOption("", ""), AddTrustStoreOption("e2e", filepath.Join(NotationE2ELocalKeysDir, "e2e.crt"))), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "pull", pushReference).
+					MatchErrKeyWords(`Use "--bootstrap-policy" to specify one`)
+				notation.Exec("policy", "pull", "--force", "--bootstrap-policy", filepath.Join(NotationE2ETrustPolicyDir, "trustpolicy.json"), pushReference).
+					MatchKeyWords(
+						"Successfully verified signature",
+						"Installed "+OCITrustPolicyName,
+						"Installed truststore/x509/ca/e2e",
+						"Successfully installed the trust configuration",
+					)
+				_, err := os.Stat(vhost.AbsolutePath(NotationDirName, OCITrustPolicyName))
+				Expect(err).NotTo(HaveOccurred())
+
+				// the installed configuration verifies the next pull
+				notation.Exec("policy", "pull", "--force", pushReference).
+					MatchKeyWords("Successfully installed the trust configuration")
+			})
+		})
+
+		It("should fail to push without trust policy configuration", func() {
+			Host(Opts(AuthOption("", ""), AddKeyOption(filepath.Join(NotationE2ELocalKeysDir, "e2e.key"), filepath.Join(NotationE2ELocalKeysDir, "e2e.crt"))), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "push", fmt.Sprintf("%s/trust-config-%d:v1", TestRegistry.Host, GinkgoRandomSeed())).
+					MatchErrKeyWords("neither OCI nor blob trust policy configuration exists")
+			})
+		})
+
+		It("should fail to pull a configuration signed by an untrusted key", func() {
+			pushReference := fmt.Sprintf("%s/trust-config-untrusted-%d:v1", TestRegistry.Host, GinkgoRandomSeed())
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "push", pushReference).
+					MatchKeyWords("Successfully signed")
+			})
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("cert", "generate-test", "untrusted")
+				notation.Exec("cert", "delete", "--type", "ca", "--store", "e2e", "--all", "--yes")
+				notation.Exec("cert", "add", "--type", "ca", "--store", "e2e", vhost.AbsolutePath(NotationDirName, "localkeys", "untrusted.crt"))
+				notation.ExpectFailure().Exec("policy", "pull", "--force", pushReference).
+					MatchErrKeyWords("signature verification failed")
+			})
+		})
+
+		It("should fail to pull a configuration signed by an untrusted key under an audit bootstrap policy", func() {
+			pushReference := fmt.Sprintf("%s/trust-config-audit-%d:v1", TestRegistry.Host, GinkgoRandomSeed())
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "push", pushReference).
+					MatchKeyWords("Successfully signed")
+			})
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("cert", "generate-test", "untrusted")
+				notation.Exec("cert", "delete", "--type", "ca", "--store", "e2e", "--all", "--yes")
+				notation.Exec("cert", "add", "--type", "ca", "--store", "e2e", vhost.AbsolutePath(NotationDirName, "localkeys", "untrusted.crt"))
+				notation.ExpectFailure().Exec("policy", "pull", "--force", "--bootstrap-policy", filepath.Join(NotationE2ETrustPolicyDir, "audit_trustpolicy.json"), pushReference).
+					MatchErrKeyWords("authenticity validation", "refusing to install an unverified trust configuration")
+			})
+		})
+	})
+
+	When("generating configuration", func() {
+		It("should generate an OCI trust policy from a signed artifact", func() {
+			var reference string
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				reference = artifact.ReferenceWithDigest()
+				notation.Exec("sign", reference).
+					MatchKeyWords(SignSuccessfully)
+			})
+			Host(Opts(AuthOption("", "")), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				outputDir := vhost.AbsolutePath("generated")
+				notation.Exec("policy", "generate", "--from", reference, "--name", "e2e", "--output-dir", outputDir, "--import-root").
+					MatchKeyWords(
+						"Fetched 1 signature(s) of",
+						"Proposed trust store ca:e2e",
+						"x509.subject: C=US, ST=WA, L=Seattle, O=Notary, CN=e2e",
+						"Wrote "+filepath.Join(outputDir, OCITrustPolicyName),
+						"Added root certificate",
+					)
+				notation.ExpectFailure().Exec("policy", "generate", "--from", reference, "--name", "e2e", "--output-dir", outputDir).
+					MatchErrKeyWords("already exists, use --force to override it")
+
+				// the generated configuration verifies the artifact
+				notation.Exec("policy", "import", "--force", filepath.Join(outputDir, OCITrustPolicyName))
+				notation.Exec("verify", reference).
+					MatchKeyWords(VerifySuccessfully)
+			})
+		})
+
+		It("should generate a blob trust policy from a blob signature", func() {
+			HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+				notation.Exec("blob", "sign", blobPath)
+				signaturePath := filepath.Join(filepath.Dir(blobPath), filepath.Base(blobPath)+".jws.sig")
+				notation.Exec("policy", "generate", "--from-signature", signaturePath, "--store", "generated").
+					MatchKeyWords(
+						"Proposed trust store ca:generated",
+						"Generated blob trust policy configuration",
+						"x509.subject: C=US, ST=WA, L=Seattle, O=Notary, CN=e2e",
+					)
+			})
+		})
+
+		It("should fail without a source", func() {
+			Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.ExpectFailure().Exec("policy", "generate").
+					MatchErrKeyWords("at least one of the flags in the group [from from-signature] is required")
+			})
+		})
+	})
+
+	When("testing a reference", func() {
+		It("should show the statement with the wildcard registry scope", func() {
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "test", "localhost:5000/net-monitor:v1").
+					MatchKeyWords(
+						"localhost:5000/net-monitor:v1",
+						"trust policy statement: e2e",
+						`reason: no statement has registry scope "localhost:5000/net-monitor", the statement with the wildcard registry scope "*" applies`,
+						"verification level: strict",
+						"revocation: enforce",
+						"ca:e2e",
+						"issued to: CN=e2e,O=Notary,L=Seattle,ST=WA,C=US",
+					)
+			})
+		})
+
+		It("should show the statement with the matching registry scope as JSON", func() {
+			Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "add", "--name", "net-monitor", "--registry-scope", "localhost:5000/net-monitor", "--trust-store", "ca:missing", "--trusted-identity", "*", "--verification-level", "permissive", "--override", "revocation=skip")
+				notation.Exec("policy", "test", "-o", "json", "localhost:5000/net-monitor:v1").
+					MatchKeyWords(
+						`"statement": "net-monitor"`,
+						`"verificationLevel": "permissive"`,
+						`"revocation": "skip"`,
+						`"name": "ca:missing"`,
+						`"error": "the trust store \"missing\" of type \"ca\" does not exist"`,
+					)
+			})
+		})
+
+		It("should fail if no statement applies", func() {
+			Host(Opts(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+				notation.Exec("policy", "init", "--name", "net-logger", "--registry-scope", "localhost:5000/net-logger", "--verification-level", "skip")
+				notation.ExpectFailure().Exec("policy", "test", "localhost:5000/net-monitor:v1").
+					MatchErrKeyWords(`no OCI trust policy statement applies to repository "localhost:5000/net-monitor"`)
+			})
+		})
+	})
 })
@@ -83,4 +83,89 @@ var _ = Describe("notation blob", Serial, func() {
 				MatchKeyWords(VerifySuccessfully)
 		})
 	})
+
+	It("signing and verifying with a test PKI", func() {
+		Host(Opts(), func(notation *utils.ExecOpts, _ *Artifact, vhost *utils.VirtualHost) {
+			workDir := vhost.AbsolutePath()
+
+			// create a file to be signed
+			blobPath := filepath.Join(workDir, "hello.txt")
+			if err := os.WriteFile(blobPath, []byte("hello, world"), 0644); err != nil {
+				Fail(err.Error())
+			}
+
+			// generate a testing PKI with ECDSA keys
+			notation.Exec("cert", "generate-test-pki", "--key-type", "ecdsa", "--curve", "P-384", "--validity", "24h", "--default", "testpki").
+				MatchKeyWords(
+					"generating test PKI with ECDSA P-384 keys",
+					"Successfully added testpki.crt to named store testpki of type ca",
+					"testpki: added to the key list",
+				)
+
+			// sign the file
+			notation.WithWorkDir(workDir).Exec("blob", "sign", blobPath).
+				MatchKeyWords(SignSuccessfully)
+
+			// verify the blob signature with the root certificate
+			notation.Exec("blob", "policy", "init",
+				"--name", "testpolicy",
+				"--trust-store", "ca:testpki",
+				"--trusted-identity", "x509.subject: CN=testpki,O=Notary,L=Seattle,ST=WA,C=US").
+				MatchKeyWords(
+					"Successfully initialized blob trust policy file to",
+				)
+			notation.Exec("blob", "verify",
+				"--signature", blobPath+".jws.sig",
+				"--policy-name", "testpolicy",
+				blobPath).
+				MatchKeyWords(VerifySuccessfully)
+		})
+	})
+
+	It("signing and verifying with generated ECDSA keys in JWS and COSE formats", func() {
+		Host(Opts(), func(notation *utils.ExecOpts, _ *Artifact, vhost *utils.VirtualHost) {
+			workDir := vhost.AbsolutePath()
+
+			// create a file to be signed
+			blobPath := filepath.Join(workDir, "hello.txt")
+			if err := os.WriteFile(blobPath, []byte("hello, world"), 0644); err != nil {
+				Fail(err.Error())
+			}
+
+			for _, curve := range []string{"P-256", "P-384", "P-521"} {
+				keyName := "testcert-" + curve
+
+				// generate a testing key pair with a custom subject
+				notation.Exec("cert", "generate-test", "--key-type", "ecdsa", "--curve", curve,
+					"--validity", "1h", "--organizational-unit", "QA", keyName).
+					MatchKeyWords(
+						"generating ECDSA Key with curve "+curve,
+						"Successfully added "+keyName+".crt to named store "+keyName+" of type ca",
+						keyName+": added to the key list",
+					)
+
+				notation.Exec("blob", "policy", "init",
+					"--name", "testpolicy",
+					"--trust-store", "ca:"+keyName,
+					"--trusted-identity", "x509.subject: CN="+keyName+",OU=QA,O=Notary,L=Seattle,ST=WA,C=US",
+					"--force").
+					MatchKeyWords(
+						"Successfully initialized blob trust policy file to",
+					)
+
+				for _, format := range []string{"jws", "cose"} {
+					signatureDir := filepath.Join(workDir, keyName, format)
+					notation.Exec("blob", "sign", "--key", keyName, "--signature-format", format,
+						"--signature-directory", signatureDir, blobPath).
+						MatchKeyWords(SignSuccessfully)
+
+					notation.Exec("blob", "verify",
+						"--signature", filepath.Join(signatureDir, "hello.txt."+format+".sig"),
+						"--policy-name", "testpolicy",
+						blobPath).
+						MatchKeyWords(VerifySuccessfully)
+				}
+			}
+		})
+	})
 })
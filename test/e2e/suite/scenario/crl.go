@@ -17,6 +17,7 @@ import (
 	"context"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	crlcore "github.com/notaryproject/notation-core-go/revocation/crl"
 	"github.com/notaryproject/notation-go/verifier/crl"
@@ -67,6 +68,44 @@ var _ = Describe("notation CRL revocation check", Serial, func() {
 		})
 	})
 
+	It("cert revocation-check successfully completed with cache", func() {
+		Host(CRLOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			utils.LeafCRLUnrevoke()
+			utils.IntermediateCRLUnrevoke()
+			chainPath := filepath.Join(NotationE2EConfigPath, "crl", "certchain_with_crl.pem")
+
+			// check without cache
+			notation.Exec("cert", "revocation-check", chainPath).
+				MatchKeyWords(
+					"for codeSigning: OK",
+					"(CRL cache miss)",
+				).
+				NoMatchKeyWords("(CRL cache hit)")
+
+			// check with cache
+			notation.Exec("cert", "revocation-check", chainPath).
+				MatchKeyWords(
+					"for codeSigning: OK",
+					"(CRL cache hit)",
+				).
+				NoMatchKeyWords("(CRL cache miss)")
+		})
+	})
+
+	It("cert revocation-check failed with revoked leaf certificate", func() {
+		Host(CRLOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			utils.LeafCRLRevoke()
+			utils.IntermediateCRLUnrevoke()
+
+			notation.ExpectFailure().Exec("cert", "revocation-check", filepath.Join(NotationE2EConfigPath, "crl", "certchain_with_crl.pem")).
+				MatchKeyWords(
+					"for codeSigning: Revoked",
+					": Revoked (CRL cache miss)",
+				).
+				MatchErrKeyWords("1 of 1 certificate chain(s) are revoked")
+		})
+	})
+
 	It("failed with revoked leaf certificate", func() {
 		Host(CRLOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
 			notation.Exec("sign", artifact.ReferenceWithDigest()).